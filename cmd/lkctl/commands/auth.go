@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/baptistegh/go-lakekeeper/cmd/lkctl/errors"
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewAuthCmd(clientOptions *clientOptions) *cobra.Command {
+	command := cobra.Command{
+		Use:   "auth",
+		Short: "Inspect permissions",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command.AddCommand(NewAuthExplainCmd(clientOptions))
+
+	return &command
+}
+
+// explainActions are the actions auth explain can check.
+var explainActions = mergeChoices(
+	choices(permissionv1.ValidServerActions),
+	choices(permissionv1.ValidProjectActions),
+	choices(permissionv1.ValidWarehouseActions),
+)
+
+func NewAuthExplainCmd(clientOptions *clientOptions) *cobra.Command {
+	var (
+		user      string
+		action    string
+		project   string
+		warehouse string
+
+		output string
+	)
+
+	command := cobra.Command{
+		Use:   "explain",
+		Short: "Explain why a user can or cannot perform an action",
+		Long: `Explain why a user can or cannot perform an action.
+
+The access of the user is checked on the server, the project and, if provided,
+the warehouse. The assignments of each of them are then walked, following the
+role memberships of the user, to show which ones produce the action.`,
+		Example: `  # Explain why a user can create a namespace in a warehouse
+  lkctl auth explain --user oidc~0198618c-5be8-7a82-a0b9-1076c9dd12f0 --action create_namespace --warehouse 01986184-3cb1-7526-a98c-72fecfe97731
+
+  # Explain a project action
+  lkctl auth explain --user oidc~0198618c-5be8-7a82-a0b9-1076c9dd12f0 --action create_warehouse --project 01986184-3cb1-7526-a98c-72fecfe97731`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			ctx := cmd.Context()
+
+			errors.Check(validateChoices("action", []string{action}, explainActions))
+
+			opt := permissionv1.ExplainOptions{
+				User:      user,
+				Action:    action,
+				ProjectID: core.Ptr(project),
+			}
+
+			if warehouse != "" {
+				opt.WarehouseID = core.Ptr(warehouse)
+			}
+
+			resp, err := permissionv1.Explain(ctx, MustCreateClient(ctx, clientOptions).PermissionV1(), &opt)
+			errors.Check(err)
+
+			switch output {
+			case "text":
+				printExplanation(resp)
+			case "json":
+				err := PrintResource(resp, output)
+				errors.Check(err)
+			default:
+				log.Fatalf("unknown output format %s\n", output)
+			}
+		},
+	}
+
+	command.Flags().StringVar(&user, "user", "", "User to explain the access of")
+	command.Flags().StringVar(&action, "action", "", "Action to explain, e.g. create_namespace")
+	command.Flags().StringVarP(&project, "project", "p", uuid.Nil.String(), "Select a project")
+	command.Flags().StringVar(&warehouse, "warehouse", "", "Select a warehouse")
+	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
+
+	_ = command.MarkFlagRequired("user")
+	_ = command.MarkFlagRequired("action")
+
+	_ = command.RegisterFlagCompletionFunc("user", completeUsers(clientOptions))
+	_ = command.RegisterFlagCompletionFunc("project", completeProjects(clientOptions))
+	_ = command.RegisterFlagCompletionFunc("warehouse", completeWarehouses(clientOptions))
+	_ = command.RegisterFlagCompletionFunc("action", completeChoices(explainActions))
+
+	return &command
+}
+
+func printExplanation(e *permissionv1.Explanation) {
+	if e.Allowed {
+		scopes := make([]string, 0, len(e.AllowedOn))
+		for _, s := range e.AllowedOn {
+			scopes = append(scopes, string(s))
+		}
+		fmt.Printf("%s is allowed to %s on %s\n", e.User, e.Action, strings.Join(scopes, ", "))
+	} else {
+		fmt.Printf("%s is not allowed to %s\n", e.User, e.Action)
+	}
+
+	fmt.Println()
+
+	if len(e.Grants) == 0 {
+		fmt.Println("No assignments")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "SCOPE\tRESOURCE ID\tASSIGNMENT\tPRINCIPAL TYPE\tPRINCIPAL ID\tVIA\tALLOWS\n")
+	for _, g := range e.Grants {
+		via := "direct"
+		if len(g.Via) > 0 {
+			via = strings.Join(g.Via, " > ")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%t\n", g.Scope, g.ResourceID, g.Assignment, g.PrincipalType, g.PrincipalID, via, g.Allows)
+	}
+	w.Flush()
+}
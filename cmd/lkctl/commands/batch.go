@@ -0,0 +1,224 @@
+package commands
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+	"github.com/baptistegh/go-lakekeeper/pkg/bulk"
+	"github.com/spf13/cobra"
+)
+
+type batchOpts struct {
+	file        string
+	concurrency int
+}
+
+// grantRecord is a line of a grants batch file.
+type grantRecord struct {
+	resource   string
+	assignee   permissionv1.UserOrRole
+	assignment string
+}
+
+func AddBatchFlags(cmd *cobra.Command, opts *batchOpts, columns string) {
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", fmt.Sprintf("CSV file to process in batch, with a header line and the columns %s; use - to read from stdin", columns))
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", bulk.DefaultConcurrency, "Maximum number of requests sent at the same time in batch mode")
+}
+
+// readCSV reads a CSV file with a header line and returns one map per
+// record, keyed by the lower-cased column names. Lines starting with #
+// are ignored.
+func readCSV(path string, required ...string) ([]map[string]string, error) {
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not open %s, %w", path, err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	r := csv.NewReader(in)
+	r.Comment = '#'
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+
+	lines, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s, %w", path, err)
+	}
+
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+
+	header := make([]string, len(lines[0]))
+	for i, h := range lines[0] {
+		header[i] = strings.ToLower(strings.TrimSpace(h))
+	}
+
+	for _, col := range required {
+		found := false
+		for _, h := range header {
+			if h == col {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s: missing column %s", path, col)
+		}
+	}
+
+	records := make([]map[string]string, 0, len(lines)-1)
+	for n, line := range lines[1:] {
+		if len(line) > len(header) {
+			return nil, fmt.Errorf("%s: record %d has more columns than the header", path, n+1)
+		}
+
+		record := make(map[string]string, len(header))
+		for i, v := range line {
+			record[header[i]] = strings.TrimSpace(v)
+		}
+		for _, col := range required {
+			if record[col] == "" {
+				return nil, fmt.Errorf("%s: record %d, column %s must not be empty", path, n+1, col)
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// readGrants reads a grants batch file with the columns
+// <resourceColumn>, type, principal and assignment. The resource
+// column is optional, the grant is made on each of defaultResources
+// when it is empty. Assignments must be one of valid.
+func readGrants(path, resourceColumn string, defaultResources, valid []string) ([]grantRecord, error) {
+	records, err := readCSV(path, "type", "principal", "assignment")
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []grantRecord
+	for i, r := range records {
+		assignee := permissionv1.UserOrRole{
+			Type:  permissionv1.UserOrRoleType(strings.ToLower(r["type"])),
+			Value: r["principal"],
+		}
+
+		if assignee.Type != permissionv1.UserType && assignee.Type != permissionv1.RoleType {
+			return nil, fmt.Errorf("%s: record %d, type must be user or role, got %s", path, i+1, r["type"])
+		}
+
+		if !slices.Contains(valid, r["assignment"]) {
+			return nil, fmt.Errorf("%s: record %d, invalid assignment %s, must be one of %s", path, i+1, r["assignment"], strings.Join(valid, ", "))
+		}
+
+		resources := defaultResources
+		if r[resourceColumn] != "" {
+			resources = []string{r[resourceColumn]}
+		}
+		if len(resources) == 0 {
+			return nil, fmt.Errorf("%s: record %d, column %s must not be empty", path, i+1, resourceColumn)
+		}
+
+		for _, resource := range resources {
+			grants = append(grants, grantRecord{
+				resource:   resource,
+				assignee:   assignee,
+				assignment: r["assignment"],
+			})
+		}
+	}
+
+	return grants, nil
+}
+
+// expandGrants returns the grants of each assignment to each
+// user and role, on each resource.
+func expandGrants(resources, users, roles, assignments []string) []grantRecord {
+	var assignees []permissionv1.UserOrRole
+	for _, u := range users {
+		assignees = append(assignees, permissionv1.UserOrRole{Type: permissionv1.UserType, Value: u})
+	}
+	for _, r := range roles {
+		assignees = append(assignees, permissionv1.UserOrRole{Type: permissionv1.RoleType, Value: r})
+	}
+
+	var grants []grantRecord
+	for _, resource := range resources {
+		for _, assignee := range assignees {
+			for _, assignment := range assignments {
+				grants = append(grants, grantRecord{resource: resource, assignee: assignee, assignment: assignment})
+			}
+		}
+	}
+	return grants
+}
+
+// groupGrants groups grants by resource, keeping the order
+// of first appearance.
+func groupGrants(grants []grantRecord) ([]string, map[string][]grantRecord) {
+	var order []string
+	groups := map[string][]grantRecord{}
+
+	for _, g := range grants {
+		if _, ok := groups[g.resource]; !ok {
+			order = append(order, g.resource)
+		}
+		groups[g.resource] = append(groups[g.resource], g)
+	}
+
+	return order, groups
+}
+
+// printBatchResults prints the outcome of a batch and returns
+// an error when at least one item failed.
+func printBatchResults[T, R any](output string, results bulk.Results[T, R], describe func(T) string) error {
+	switch output {
+	case "json":
+		type item struct {
+			Item  string `json:"item"`
+			Error string `json:"error,omitempty"`
+		}
+
+		items := make([]item, 0, len(results))
+		for _, r := range results {
+			i := item{Item: describe(r.Item)}
+			if r.Err != nil {
+				i.Error = r.Err.Error()
+			}
+			items = append(items, i)
+		}
+
+		if err := PrintResource(items, output); err != nil {
+			return err
+		}
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "ITEM\tSTATUS\tERROR\n")
+		for _, r := range results {
+			status, msg := "ok", ""
+			if r.Err != nil {
+				status, msg = "failed", r.Err.Error()
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", describe(r.Item), status, msg)
+		}
+		w.Flush()
+	}
+
+	if failed := len(results.Failed()); failed > 0 {
+		return fmt.Errorf("%d of %d items failed", failed, len(results))
+	}
+
+	return nil
+}
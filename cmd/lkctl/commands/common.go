@@ -1,12 +1,17 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strings"
 	"text/tabwriter"
 
 	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+	credentialv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/credential"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +27,7 @@ type clientOptions struct {
 	scope        []string
 	boostrap     bool
 	debug        bool
+	rateLimit    float64
 }
 
 type accessOpts struct {
@@ -54,6 +60,56 @@ func PrintResource(resource any, output string) error {
 	return nil
 }
 
+// loadFunc decodes a document, resolving its secret references.
+type loadFunc[T any] func(ctx context.Context, r io.Reader, resolvers credentialv1.SecretResolvers) (T, error)
+
+// loadConfig decodes the JSON file at path, or stdin if path is "-",
+// with load. The secret references of the file are resolved, commands
+// are only run if allowExec is set.
+func loadConfig[T any](cmd *cobra.Command, path string, allowExec bool, load loadFunc[T]) (T, error) {
+	var reader io.Reader
+
+	if path == "-" {
+		reader = cmd.InOrStdin()
+	} else {
+		file, err := os.Open(path)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		defer file.Close()
+
+		reader = file
+	}
+
+	resolvers := credentialv1.DefaultSecretResolvers()
+	if allowExec {
+		resolvers["exec"] = credentialv1.ExecSecretResolver{Stderr: os.Stderr}
+	}
+
+	return load(cmd.Context(), reader, resolvers)
+}
+
+// addAllowExecSecretsFlag adds the flag enabling the ${exec:...}
+// secret references of config files.
+func addAllowExecSecretsFlag(cmd *cobra.Command, allowExec *bool) {
+	cmd.Flags().BoolVar(allowExec, "allow-exec-secrets", false, "Resolve ${exec:COMMAND} secret references of config files by running the commands")
+}
+
+// checkValid exits if err, the result of a client side validation,
+// is not nil, printing one invalid field per line.
+func checkValid(what string, err error) {
+	if err == nil {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "invalid %s:\n", what)
+	for line := range strings.SplitSeq(err.Error(), "\n") {
+		fmt.Fprintf(os.Stderr, "  - %s\n", line)
+	}
+	os.Exit(1)
+}
+
 func PrintAssignments[T permissionv1.Assignment](assignments ...T) {
 	if len(assignments) == 0 {
 		fmt.Println("No assignments")
@@ -74,6 +130,50 @@ func FormatPString(s *string) string {
 	return *s
 }
 
+// indent prefixes each line of s.
+func indent(s, prefix string) string {
+	lines := strings.SplitAfter(s, "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = prefix + l
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// sparkline draws values as a line of block characters, at most width
+// wide. When there are more values than width, each character shows the
+// largest value of the values it covers.
+func sparkline(values []int64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+
+	if len(values) > width {
+		buckets := make([]int64, width)
+		for i := range buckets {
+			from, to := i*len(values)/width, (i+1)*len(values)/width
+			buckets[i] = slices.Max(values[from:to])
+		}
+		values = buckets
+	}
+
+	const blocks = "▁▂▃▄▅▆▇█"
+	levels := []rune(blocks)
+
+	lowest, highest := slices.Min(values), slices.Max(values)
+
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if highest > lowest {
+			level = int((v - lowest) * int64(len(levels)-1) / (highest - lowest))
+		}
+		b.WriteRune(levels[level])
+	}
+	return b.String()
+}
+
 func AddAccessFlags(cmd *cobra.Command, opts *accessOpts) {
 	cmd.Flags().StringVar(&opts.user, "user", "", "Filter by user")
 	cmd.Flags().StringVar(&opts.role, "role", "", "Filter by role")
@@ -0,0 +1,221 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/baptistegh/go-lakekeeper/internal/listing"
+	"github.com/baptistegh/go-lakekeeper/pkg/client"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// listCompletions returns the completions of a resource, as IDs
+// described by their name.
+type listCompletions func(ctx context.Context, c *client.Client, project string) ([]cobra.Completion, error)
+
+// completeProjects completes project IDs.
+func completeProjects(clientOpts *clientOptions) cobra.CompletionFunc {
+	return completeFromServer(clientOpts, func(ctx context.Context, c *client.Client, _ string) ([]cobra.Completion, error) {
+		projects, err := listing.Projects(ctx, c, nil)
+		if err != nil {
+			return nil, err
+		}
+		completions := make([]cobra.Completion, 0, len(projects))
+		for _, p := range projects {
+			completions = append(completions, cobra.CompletionWithDesc(p.ID, p.Name))
+		}
+		return completions, nil
+	})
+}
+
+// completeWarehouses completes the warehouse IDs of the selected project.
+func completeWarehouses(clientOpts *clientOptions) cobra.CompletionFunc {
+	return completeFromServer(clientOpts, func(ctx context.Context, c *client.Client, project string) ([]cobra.Completion, error) {
+		warehouses, err := listing.Warehouses(ctx, c, project)
+		if err != nil {
+			return nil, err
+		}
+		completions := make([]cobra.Completion, 0, len(warehouses))
+		for _, w := range warehouses {
+			completions = append(completions, cobra.CompletionWithDesc(w.ID, w.Name))
+		}
+		return completions, nil
+	})
+}
+
+// completeRoles completes the role IDs of the selected project.
+func completeRoles(clientOpts *clientOptions) cobra.CompletionFunc {
+	return completeFromServer(clientOpts, func(ctx context.Context, c *client.Client, project string) ([]cobra.Completion, error) {
+		roles, err := listing.Roles(ctx, c, project)
+		if err != nil {
+			return nil, err
+		}
+		completions := make([]cobra.Completion, 0, len(roles))
+		for _, r := range roles {
+			completions = append(completions, cobra.CompletionWithDesc(r.ID, r.Name))
+		}
+		return completions, nil
+	})
+}
+
+// completeUsers completes user IDs.
+func completeUsers(clientOpts *clientOptions) cobra.CompletionFunc {
+	return completeFromServer(clientOpts, func(ctx context.Context, c *client.Client, _ string) ([]cobra.Completion, error) {
+		users, err := listing.Users(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		completions := make([]cobra.Completion, 0, len(users))
+		for _, u := range users {
+			completions = append(completions, cobra.CompletionWithDesc(u.ID, u.Name))
+		}
+		return completions, nil
+	})
+}
+
+// completeFromServer queries the server for completions. The selected
+// project is read from the --project flag, if the command has one.
+// Errors are not printed, as they would mess with the shell.
+func completeFromServer(clientOpts *clientOptions, list listCompletions) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		c, err := CreateClient(ctx, clientOpts)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		completions, err := list(ctx, c, projectFlag(cmd))
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		return filterCompletions(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// projectFlag returns the value of the --project flag of cmd,
+// or the default project.
+func projectFlag(cmd *cobra.Command) string {
+	f := cmd.Flag("project")
+	if f == nil || f.Value.Type() != "string" || f.Value.String() == "" {
+		return uuid.Nil.String()
+	}
+	return f.Value.String()
+}
+
+// completeArgs limits completion to the first n positional arguments.
+func completeArgs(n int, complete cobra.CompletionFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) >= n {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// completeChoices completes a fixed set of values. Values already
+// typed in a comma separated list are kept as a prefix.
+func completeChoices(choices []string) cobra.CompletionFunc {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		typed, current := "", toComplete
+		if i := strings.LastIndex(toComplete, ","); i >= 0 {
+			typed, current = toComplete[:i+1], toComplete[i+1:]
+		}
+
+		var completions []cobra.Completion
+		for _, c := range choices {
+			if strings.HasPrefix(c, current) {
+				completions = append(completions, typed+c)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func filterCompletions(completions []cobra.Completion, toComplete string) []cobra.Completion {
+	return slices.DeleteFunc(completions, func(c cobra.Completion) bool {
+		return !strings.HasPrefix(c, toComplete)
+	})
+}
+
+// choices converts a list of enum values to strings.
+func choices[T ~string](values []T) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, string(v))
+	}
+	return out
+}
+
+// mergeChoices concatenates lists of choices, without duplicates.
+func mergeChoices(lists ...[]string) []string {
+	var out []string
+	for _, l := range lists {
+		for _, c := range l {
+			if !slices.Contains(out, c) {
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+// validateChoices returns an error for the first value of flag
+// not in valid, suggesting the closest valid value.
+func validateChoices(flag string, values, valid []string) error {
+	for _, v := range values {
+		if slices.Contains(valid, v) {
+			continue
+		}
+
+		if s := suggest(v, valid); s != "" {
+			return fmt.Errorf("invalid value %q for --%s, did you mean %q? Valid values are: %s", v, flag, s, strings.Join(valid, ", "))
+		}
+		return fmt.Errorf("invalid value %q for --%s, valid values are: %s", v, flag, strings.Join(valid, ", "))
+	}
+	return nil
+}
+
+// suggest returns the value of valid closest to v,
+// or an empty string if none is close enough.
+func suggest(v string, valid []string) string {
+	best, bestDistance := "", len(v)/2+1
+	for _, c := range valid {
+		if strings.HasPrefix(c, v) && v != "" {
+			return c
+		}
+		if d := levenshtein(v, c); d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+
+	return prev[len(b)]
+}
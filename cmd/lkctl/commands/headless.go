@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"errors"
 
 	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
 	"github.com/baptistegh/go-lakekeeper/pkg/client"
@@ -12,20 +13,33 @@ import (
 	"golang.org/x/oauth2/clientcredentials"
 )
 
+// MustCreateClient returns a client authenticated with the OAuth2 client
+// credentials of opts, and exits on errors.
 func MustCreateClient(ctx context.Context, opts *clientOptions) *client.Client {
+	cli, err := CreateClient(ctx, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return cli
+}
+
+// CreateClient returns a client authenticated with the OAuth2 client
+// credentials of opts.
+func CreateClient(ctx context.Context, opts *clientOptions) (*client.Client, error) {
 	opt := []client.ClientOptionFunc{}
 
 	switch {
 	case opts.server == "":
-		log.Fatal("You must provide server url")
+		return nil, errors.New("you must provide server url")
 	case opts.authURL == "":
-		log.Fatal("You must provide auth url")
+		return nil, errors.New("you must provide auth url")
 	case opts.clientID == "":
-		log.Fatal("You must provide OAuth client_id")
+		return nil, errors.New("you must provide OAuth client_id")
 	case opts.clientSecret == "":
-		log.Fatal("You must provide OAuth client_secret")
+		return nil, errors.New("you must provide OAuth client_secret")
 	case len(opts.scope) == 0:
-		log.Fatal("You must provide OAuth scope")
+		return nil, errors.New("you must provide OAuth scope")
 	}
 
 	oauthConfig := clientcredentials.Config{
@@ -37,7 +51,7 @@ func MustCreateClient(ctx context.Context, opts *clientOptions) *client.Client {
 
 	log.Debug("testing OAuth2 client credentials")
 	if _, err := oauthConfig.Token(ctx); err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	as := core.OAuthTokenSource{
@@ -49,10 +63,10 @@ func MustCreateClient(ctx context.Context, opts *clientOptions) *client.Client {
 		opt = append(opt, client.WithInitialBootstrapV1Enabled(true, true, core.Ptr(managementv1.ApplicationUserType)))
 	}
 
-	cli, err := client.NewAuthSourceClient(ctx, &as, opts.server, opt...)
-	if err != nil {
-		log.Fatal(err)
+	if opts.rateLimit > 0 {
+		log.Debugf("limiting requests to %.2f per second", opts.rateLimit)
+		opt = append(opt, client.WithRateLimit(opts.rateLimit, max(1, int(opts.rateLimit))))
 	}
 
-	return cli
+	return client.NewAuthSourceClient(ctx, &as, opts.server, opt...)
 }
@@ -0,0 +1,240 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/baptistegh/go-lakekeeper/cmd/lkctl/errors"
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+	"github.com/baptistegh/go-lakekeeper/pkg/client"
+	"github.com/baptistegh/go-lakekeeper/pkg/snapshot"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// exitDrift is the exit code of permissions diff when assignments changed.
+const exitDrift = 2
+
+// copyAssignmentsFunc copies the assignments of a resource to another one.
+type copyAssignmentsFunc func(ctx context.Context, c *client.Client, src, dst string, opts *permissionv1.CopyOptions) (*permissionv1.Plan, error)
+
+func NewPermissionsCmd(clientOpts *clientOptions) *cobra.Command {
+	command := cobra.Command{
+		Use:     "permissions",
+		Aliases: []string{"perms"},
+		Short:   "Snapshot permission assignments and detect drift",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command.AddCommand(NewPermissionsSnapshotCmd(clientOpts))
+	command.AddCommand(NewPermissionsDiffCmd(clientOpts))
+
+	return &command
+}
+
+func NewPermissionsSnapshotCmd(clientOpts *clientOptions) *cobra.Command {
+	var file string
+
+	command := cobra.Command{
+		Use:   "snapshot",
+		Short: "Capture all the server, project, warehouse and role assignments",
+		Example: `  # Write a snapshot to permissions-<timestamp>.json
+  lkctl permissions snapshot
+
+  # Write a snapshot to stdout
+  lkctl permissions snapshot -f -`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			ctx := cmd.Context()
+
+			s, err := snapshot.Take(ctx, MustCreateClient(ctx, clientOpts))
+			errors.Check(err)
+
+			if file == "-" {
+				b, err := s.Marshal()
+				errors.Check(err)
+				_, err = os.Stdout.Write(b)
+				errors.Check(err)
+				return
+			}
+
+			if file == "" {
+				file = fmt.Sprintf("permissions-%s.json", s.CreatedAt.Format("20060102T150405Z"))
+			}
+
+			errors.Check(s.Save(file))
+			fmt.Printf("Snapshot of %d resources written to %s\n", len(s.Resources), file)
+		},
+	}
+
+	command.Flags().StringVarP(&file, "file", "f", "", "File to write the snapshot to, permissions-<timestamp>.json by default; use - to write to stdout")
+
+	return &command
+}
+
+func NewPermissionsDiffCmd(clientOpts *clientOptions) *cobra.Command {
+	var output string
+
+	command := cobra.Command{
+		Use:   "diff SNAPSHOT [SNAPSHOT]",
+		Short: "Show the assignments added and removed between two snapshots",
+		Long: fmt.Sprintf(`Show the assignments added and removed between two snapshots.
+
+With a single snapshot, it is compared to the live assignments.
+
+The command exits with 0 when there is no difference, %d when assignments
+changed, and 1 on errors, to be usable as a drift check.`, exitDrift),
+		Example: `  # Compare two snapshots
+  lkctl permissions diff permissions-20250101T000000Z.json permissions-20250201T000000Z.json
+
+  # Check for drift against the live assignments
+  lkctl permissions diff baseline.json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 1 || len(args) > 2 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			from, err := snapshot.Load(args[0])
+			errors.Check(err)
+
+			var to *snapshot.Snapshot
+			if len(args) == 2 {
+				to, err = snapshot.Load(args[1])
+			} else {
+				ctx := cmd.Context()
+				to, err = snapshot.Take(ctx, MustCreateClient(ctx, clientOpts))
+			}
+			errors.Check(err)
+
+			d := snapshot.Compare(from, to)
+
+			switch output {
+			case "text":
+				fmt.Print(d.String())
+			case "json":
+				errors.Check(PrintResource(d, output))
+			default:
+				log.Fatalf("unknown output format %s\n", output)
+			}
+
+			if !d.IsEmpty() {
+				os.Exit(exitDrift)
+			}
+		},
+	}
+
+	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
+
+	return &command
+}
+
+func NewWarehousePermissionsCmd(clientOpts *clientOptions) *cobra.Command {
+	command := cobra.Command{
+		Use:   "permissions",
+		Short: "Manage warehouse permissions",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command.AddCommand(newPermissionsCopyCmd(clientOpts, "warehouse", completeWarehouses(clientOpts), choices(permissionv1.ValidWarehouseAssignmentTypes), func(ctx context.Context, c *client.Client, src, dst string, opts *permissionv1.CopyOptions) (*permissionv1.Plan, error) {
+		return permissionv1.CopyWarehouseAssignments(ctx, c.PermissionV1().WarehousePermission(), src, dst, opts)
+	}))
+
+	return &command
+}
+
+func NewProjectPermissionsCmd(clientOpts *clientOptions) *cobra.Command {
+	command := cobra.Command{
+		Use:   "permissions",
+		Short: "Manage project permissions",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command.AddCommand(newPermissionsCopyCmd(clientOpts, "project", completeProjects(clientOpts), choices(permissionv1.ValidProjectAssignmentTypes), func(ctx context.Context, c *client.Client, src, dst string, opts *permissionv1.CopyOptions) (*permissionv1.Plan, error) {
+		return permissionv1.CopyProjectAssignments(ctx, c.PermissionV1().ProjectPermission(), src, dst, opts)
+	}))
+
+	return &command
+}
+
+func newPermissionsCopyCmd(clientOpts *clientOptions, resource string, completeResources cobra.CompletionFunc, validRelations []string, copyFn copyAssignmentsFunc) *cobra.Command {
+	var (
+		merge     bool
+		replace   bool
+		relations []string
+		dryRun    bool
+
+		output string
+	)
+
+	command := cobra.Command{
+		Use:   "copy SRC DST",
+		Short: fmt.Sprintf("Copy the permission assignments of a %s to another one", resource),
+		Long: fmt.Sprintf(`Copy the permission assignments of a %[1]s to another one.
+
+By default, or with --merge, the assignments of SRC are merged into DST.
+With --replace, the assignments of DST missing from SRC are deleted.
+
+User assignments can be copied across projects. Role assignments are only
+valid if the role exists in the project of DST.`, resource),
+		Example: fmt.Sprintf(`  # Preview the changes
+  lkctl %[1]s permissions copy 01986184-3cb1-7526-a98c-72fecfe97731 0198618c-5be8-7a82-a0b9-1076c9dd12f0 --dry-run
+
+  # Make the assignments of DST identical to the ones of SRC
+  lkctl %[1]s permissions copy 01986184-3cb1-7526-a98c-72fecfe97731 0198618c-5be8-7a82-a0b9-1076c9dd12f0 --replace`, resource),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			errors.Check(validateChoices("relations", relations, validRelations))
+
+			ctx := cmd.Context()
+
+			plan, err := copyFn(ctx, MustCreateClient(ctx, clientOpts), args[0], args[1], &permissionv1.CopyOptions{
+				Replace:   replace,
+				Relations: relations,
+				DryRun:    dryRun,
+			})
+			errors.Check(err)
+
+			printPlan(plan, output)
+		},
+	}
+
+	command.Flags().BoolVar(&merge, "merge", false, "Keep the assignments of DST missing from SRC (default)")
+	command.Flags().BoolVar(&replace, "replace", false, "Delete the assignments of DST missing from SRC")
+	command.Flags().StringSliceVar(&relations, "relations", []string{}, "Only copy these relations; can be repeated multiple times or comma separated")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Print the changes without applying them")
+	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
+
+	command.MarkFlagsMutuallyExclusive("merge", "replace")
+
+	command.ValidArgsFunction = completeArgs(2, completeResources)
+	_ = command.RegisterFlagCompletionFunc("relations", completeChoices(validRelations))
+
+	return &command
+}
+
+// printPlan prints a permission plan and whether it was applied.
+func printPlan(plan *permissionv1.Plan, output string) {
+	switch output {
+	case "text":
+		fmt.Print(plan.String())
+		if !plan.IsEmpty() && !plan.Applied {
+			fmt.Println("Dry run, no changes applied")
+		}
+	case "json":
+		err := PrintResource(plan, output)
+		errors.Check(err)
+	default:
+		log.Fatalf("unknown output format %s\n", output)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/baptistegh/go-lakekeeper/cmd/lkctl/errors"
 	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
 	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+	"github.com/baptistegh/go-lakekeeper/pkg/bulk"
 	"github.com/baptistegh/go-lakekeeper/pkg/core"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
@@ -34,6 +35,9 @@ func NewProjectCmd(clientOpts *clientOptions) *cobra.Command {
 	command.AddCommand(NewProjectAccessCmd(clientOpts))
 	command.AddCommand(NewProjectAssignmentsCmd(clientOpts))
 	command.AddCommand(NewProjectGrantCmd(clientOpts))
+	command.AddCommand(NewProjectRevokeCmd(clientOpts))
+	command.AddCommand(NewProjectPermissionsCmd(clientOpts))
+	command.AddCommand(NewProjectStatsCmd(clientOpts))
 
 	return &command
 }
@@ -111,6 +115,8 @@ func NewProjectGetCmd(clientOpts *clientOptions) *cobra.Command {
 
 	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
 
+	command.ValidArgsFunction = completeArgs(1, completeProjects(clientOpts))
+
 	return &command
 }
 
@@ -157,6 +163,8 @@ func NewProjectDeleteCmd(clientOpts *clientOptions) *cobra.Command {
 		},
 	}
 
+	command.ValidArgsFunction = completeArgs(1, completeProjects(clientOpts))
+
 	return &command
 }
 
@@ -184,6 +192,8 @@ func NewProjectRenameCmd(clientOpts *clientOptions) *cobra.Command {
 			fmt.Printf("Project %s renamed to %s\n", args[0], args[1])
 		},
 	}
+	command.ValidArgsFunction = completeArgs(1, completeProjects(clientOpts))
+
 	return &command
 }
 
@@ -260,6 +270,11 @@ func NewProjectAccessCmd(clientOpts *clientOptions) *cobra.Command {
 	AddAccessFlags(&command, &accessOpts)
 	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
 
+	command.ValidArgsFunction = completeArgs(1, completeProjects(clientOpts))
+
+	_ = command.RegisterFlagCompletionFunc("user", completeUsers(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("role", completeRoles(clientOpts))
+
 	return &command
 }
 
@@ -291,6 +306,8 @@ func NewProjectAssignmentsCmd(clientOpts *clientOptions) *cobra.Command {
 
 			ctx := cmd.Context()
 
+			errors.Check(validateChoices("relations", assignmentsOpts.relations, choices(permissionv1.ValidProjectAssignmentTypes)))
+
 			var relations []permissionv1.ProjectAssignmentType
 			for _, v := range assignmentsOpts.relations {
 				relations = append(relations, permissionv1.ProjectAssignmentType(v))
@@ -318,6 +335,10 @@ func NewProjectAssignmentsCmd(clientOpts *clientOptions) *cobra.Command {
 	AddAssignmentsFlags(&command, &assignmentsOpts)
 	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
 
+	command.ValidArgsFunction = completeArgs(1, completeProjects(clientOpts))
+
+	_ = command.RegisterFlagCompletionFunc("relations", completeChoices(choices(permissionv1.ValidProjectAssignmentTypes)))
+
 	return &command
 }
 
@@ -327,12 +348,19 @@ func NewProjectGrantCmd(clientOpts *clientOptions) *cobra.Command {
 		roles []string
 
 		assignments []string
+
+		batch batchOpts
 	)
 
 	command := cobra.Command{
 		Use:     "grant PROJECT-ID",
 		Short:   "add project assignments",
 		Aliases: []string{"assign"},
+		Example: `  # Grant project_admin to a user on the default project
+  lkctl project grant --users oidc~d223d88c-85b6-4859-b5c5-27f3825e47f6 --assignments project_admin
+
+  # Grant assignments in batch from a CSV file with the columns project, type, principal and assignment
+  lkctl project grant -f grants.csv`,
 		Run: func(cmd *cobra.Command, args []string) {
 			var project string
 			if len(args) != 1 {
@@ -341,6 +369,12 @@ func NewProjectGrantCmd(clientOpts *clientOptions) *cobra.Command {
 				project = args[0]
 			}
 
+			if batch.file != "" {
+				err := grantProjects(cmd.Context(), clientOpts, batch, project)
+				errors.Check(err)
+				return
+			}
+
 			opt := permissionv1.UpdateProjectPermissionsOptions{}
 			assignees := []permissionv1.UserOrRole{}
 
@@ -348,6 +382,8 @@ func NewProjectGrantCmd(clientOpts *clientOptions) *cobra.Command {
 				log.Fatal("you must set at lest one assignment")
 			}
 
+			errors.Check(validateChoices("assignments", assignments, choices(permissionv1.ValidProjectAssignmentTypes)))
+
 			if len(users) < 1 && len(roles) < 1 {
 				log.Fatal("you must set at least one user or role")
 			}
@@ -389,12 +425,47 @@ func NewProjectGrantCmd(clientOpts *clientOptions) *cobra.Command {
 	command.Flags().StringSliceVar(&roles, "roles", []string{}, "Grant access to roles; can be repeated multiple times to add multiple roles")
 	command.Flags().StringSliceVar(&assignments, "assignments", []string{}, "Assignments to use; can be repeated multiple times to add multiple assignments")
 
-	err := command.MarkFlagRequired("assignments")
-	errors.Check(err)
+	AddBatchFlags(&command, &batch, "project (optional), type (user or role), principal and assignment")
+
+	command.MarkFlagsOneRequired("assignments", "file")
+	command.MarkFlagsMutuallyExclusive("assignments", "file")
+
+	command.ValidArgsFunction = completeArgs(1, completeProjects(clientOpts))
+
+	_ = command.RegisterFlagCompletionFunc("users", completeUsers(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("roles", completeRoles(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("assignments", completeChoices(choices(permissionv1.ValidProjectAssignmentTypes)))
 
 	return &command
 }
 
+func grantProjects(ctx context.Context, clientOpts *clientOptions, batch batchOpts, defaultProject string) error {
+	grants, err := readGrants(batch.file, "project", []string{defaultProject}, choices(permissionv1.ValidProjectAssignmentTypes))
+	if err != nil {
+		return err
+	}
+
+	projects, groups := groupGrants(grants)
+	c := MustCreateClient(ctx, clientOpts).PermissionV1().ProjectPermission()
+
+	results := bulk.Do(ctx, projects, func(ctx context.Context, project string) error {
+		opt := permissionv1.UpdateProjectPermissionsOptions{}
+		for _, g := range groups[project] {
+			opt.Writes = append(opt.Writes, &permissionv1.ProjectAssignment{
+				Assignee:   g.assignee,
+				Assignment: permissionv1.ProjectAssignmentType(g.assignment),
+			})
+		}
+
+		_, err := c.Update(ctx, project, &opt)
+		return err
+	}, bulk.WithConcurrency(batch.concurrency))
+
+	return printBatchResults("text", results, func(project string) string {
+		return fmt.Sprintf("project %s (%d assignments)", project, len(groups[project]))
+	})
+}
+
 func createProject(ctx context.Context, clientOpts *clientOptions, name, output string) error {
 	opt := managementv1.CreateProjectOptions{
 		Name: name,
@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/baptistegh/go-lakekeeper/cmd/lkctl/errors"
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewProjectStatsCmd(clientOpts *clientOptions) *cobra.Command {
+	var (
+		since       = durationValue(24 * time.Hour)
+		warehouse   string
+		statusCodes []int32
+		top         int
+		output      string
+	)
+
+	command := cobra.Command{
+		Use:   "stats [PROJECT-ID]",
+		Short: "Show the most called endpoints and the error rates of a project",
+		Long: `Show the most called endpoints and the error rates of a project.
+
+The calls are counted per hour by the server, per endpoint, status code
+and warehouse. Calls with a status code of 400 or more are errors.
+
+The server returns the statistics of a time window per call, periods
+longer than a day are fetched in consecutive windows of a day at most.`,
+		Example: `  # Show the calls of the default project over the last 24 hours
+  lkctl project stats
+
+  # Show the 5 most called endpoints of a warehouse over the last 30 days
+  lkctl project stats 01986184-3cb1-7526-a98c-72fecfe97731 --since 30d --top 5 \
+    --warehouse 019861a0-6d4e-7bf3-96c6-9aef2d4a2749
+
+  # Show the calls not associated with a warehouse, e.g. user management
+  lkctl project stats --warehouse unmapped`,
+		Run: func(cmd *cobra.Command, args []string) {
+			var project string
+			switch len(args) {
+			case 0:
+				project = uuid.Nil.String()
+			case 1:
+				project = args[0]
+			default:
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			ctx := cmd.Context()
+
+			if top <= 0 {
+				log.Fatal("--top must be positive")
+			}
+			if since <= 0 {
+				log.Fatal("--since must be positive")
+			}
+
+			pages, interval := statisticsWindows(time.Duration(since))
+
+			opt := managementv1.GetAPIStatisticsOptions{
+				RangeSpecifier: managementv1.NewAPIStatisticsWindow(time.Now(), interval),
+				StatusCodes:    statusCodes,
+				Warehouse:      managementv1.AllWarehousesFilter(),
+			}
+
+			switch warehouse {
+			case "":
+			case string(managementv1.WarehouseFilterUnmapped):
+				opt.Warehouse = managementv1.UnmappedWarehousesFilter()
+			default:
+				opt.Warehouse = managementv1.WarehouseIDFilter(warehouse)
+			}
+
+			resp, err := managementv1.GetAPIStatisticsPages(ctx, MustCreateClient(ctx, clientOpts).ProjectV1(), project, &opt, managementv1.PreviousStatisticsPage, pages)
+			errors.Check(err)
+
+			stats, err := managementv1.AggregateAPIStatistics(resp...)
+			errors.Check(err)
+
+			if len(stats.Endpoints) > top {
+				stats.Endpoints = stats.Endpoints[:top]
+			}
+
+			switch output {
+			case "text":
+				printAPIStatistics(stats, time.Duration(since))
+			case "json":
+				err := PrintResource(stats, output)
+				errors.Check(err)
+			default:
+				log.Fatalf("unknown output format %s\n", output)
+			}
+		},
+	}
+
+	command.Flags().Var(&since, "since", "Show the calls of this last period, e.g. 30d or 12h")
+	command.Flags().StringVar(&warehouse, "warehouse", "", "Only show the calls of this warehouse ID, or of no warehouse with 'unmapped'")
+	command.Flags().Int32SliceVar(&statusCodes, "status-codes", nil, "Only show the calls with these status codes, e.g. 404,500")
+	command.Flags().IntVar(&top, "top", 10, "Number of endpoints to show")
+	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
+
+	command.ValidArgsFunction = completeArgs(1, completeProjects(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("warehouse", completeWarehouses(clientOpts))
+
+	return &command
+}
+
+// statisticsWindows splits since in the smallest number of windows
+// of equal length, of a day at most.
+func statisticsWindows(since time.Duration) (int, time.Duration) {
+	const maxWindow = 24 * time.Hour
+
+	pages := int((since + maxWindow - 1) / maxWindow)
+	return pages, since / time.Duration(pages)
+}
+
+func printAPIStatistics(stats *managementv1.AggregatedAPIStatistics, since time.Duration) {
+	if stats.Total == 0 {
+		fmt.Printf("No calls in the last %s\n", since)
+		return
+	}
+
+	fmt.Printf("%d calls from %s to %s, %d errors (%.1f%%)\n\n",
+		stats.Total, stats.Start.Format(time.RFC3339), stats.End.Format(time.RFC3339), stats.Errors, 100*stats.ErrorRate())
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "ENDPOINT\tCALLS\tERRORS\tERROR RATE\n")
+	for _, s := range stats.Endpoints {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.1f%%\n", s.Key, s.Total, s.Errors, 100*s.ErrorRate())
+	}
+
+	fmt.Fprintf(w, "\nSTATUS CODE\tCALLS\n")
+	for _, s := range stats.StatusCodes {
+		fmt.Fprintf(w, "%s\t%d\n", s.Key, s.Total)
+	}
+
+	fmt.Fprintf(w, "\nWAREHOUSE\tNAME\tCALLS\tERRORS\tERROR RATE\n")
+	for _, s := range stats.Warehouses {
+		id, name := s.Key, s.Name
+		if id == "" {
+			id, name = "-", "(no warehouse)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%.1f%%\n", id, name, s.Total, s.Errors, 100*s.ErrorRate())
+	}
+
+	w.Flush()
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/baptistegh/go-lakekeeper/cmd/lkctl/errors"
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+	"github.com/baptistegh/go-lakekeeper/pkg/bulk"
+	"github.com/baptistegh/go-lakekeeper/pkg/report"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewReportCmd(clientOptions *clientOptions) *cobra.Command {
+	command := cobra.Command{
+		Use:   "report",
+		Short: "Build audit reports",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command.AddCommand(NewReportAccessCmd(clientOptions))
+
+	return &command
+}
+
+// reportActions are the actions report access can check.
+var reportActions = mergeChoices(
+	choices(permissionv1.ValidProjectActions),
+	choices(permissionv1.ValidWarehouseActions),
+)
+
+func NewReportAccessCmd(clientOptions *clientOptions) *cobra.Command {
+	var (
+		projects    []string
+		actions     []string
+		concurrency int
+
+		output string
+	)
+
+	command := cobra.Command{
+		Use:   "access",
+		Short: "Report the actions users and roles are allowed on projects and warehouses",
+		Example: `  # Report who can read or modify each warehouse of a project, as CSV
+  lkctl report access --project 01986184-3cb1-7526-a98c-72fecfe97731 --action list_namespaces,create_namespace -o csv
+
+  # Report all the access of all projects as a Markdown table
+  lkctl report access -o markdown`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			ctx := cmd.Context()
+
+			errors.Check(validateChoices("action", actions, reportActions))
+
+			m, err := report.Access(ctx, MustCreateClient(ctx, clientOptions), &report.AccessOptions{
+				ProjectIDs:  projects,
+				Actions:     actions,
+				Concurrency: concurrency,
+			})
+			errors.Check(err)
+
+			switch output {
+			case "csv":
+				errors.Check(m.WriteCSV(os.Stdout))
+			case "markdown", "md":
+				errors.Check(m.WriteMarkdown(os.Stdout))
+			case "json":
+				errors.Check(PrintResource(m, output))
+			default:
+				log.Fatalf("unknown output format %s\n", output)
+			}
+		},
+	}
+
+	command.Flags().StringSliceVarP(&projects, "project", "p", []string{}, "Limit the report to projects; can be repeated multiple times or comma separated")
+	command.Flags().StringSliceVar(&actions, "action", []string{}, "Limit the report to actions; can be repeated multiple times or comma separated")
+	command.Flags().IntVar(&concurrency, "concurrency", bulk.DefaultConcurrency, "Maximum number of requests sent at the same time")
+	command.Flags().StringVarP(&output, "output", "o", "csv", "Output format. One of: csv|json|markdown")
+
+	_ = command.RegisterFlagCompletionFunc("project", completeProjects(clientOptions))
+	_ = command.RegisterFlagCompletionFunc("action", completeChoices(reportActions))
+
+	return &command
+}
@@ -0,0 +1,281 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/baptistegh/go-lakekeeper/cmd/lkctl/errors"
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+	"github.com/baptistegh/go-lakekeeper/pkg/client"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// revokeAssignmentsFunc revokes assignments on a resource.
+type revokeAssignmentsFunc func(ctx context.Context, c *client.Client, id string, opts *permissionv1.RevokeOptions) (*permissionv1.Plan, error)
+
+// revokeResource describes the resource a revoke command acts on.
+type revokeResource struct {
+	// name is the scope of the resource, e.g. project.
+	name string
+	// arg is the name of the ID argument, empty for the server.
+	arg string
+	// defaultID is used when the ID argument is omitted,
+	// the argument is required if empty.
+	defaultID string
+	// complete completes the ID argument.
+	complete cobra.CompletionFunc
+	// relations are the valid assignment types of the resource.
+	relations []string
+	revoke    revokeAssignmentsFunc
+}
+
+// notAssigned is a requested assignment the principal did not have.
+type notAssigned struct {
+	PrincipalType permissionv1.UserOrRoleType `json:"principal-type"`
+	PrincipalID   string                      `json:"principal-id"`
+	Assignment    string                      `json:"assignment"`
+}
+
+type revokeResult struct {
+	Plan        *permissionv1.Plan `json:"plan"`
+	NotAssigned []notAssigned      `json:"not-assigned"`
+}
+
+func NewServerRevokeCmd(clientOpts *clientOptions) *cobra.Command {
+	return newRevokeCmd(clientOpts, revokeResource{
+		name:      "server",
+		relations: choices(permissionv1.ValidServerAssignmentTypes),
+		revoke: func(ctx context.Context, c *client.Client, _ string, opts *permissionv1.RevokeOptions) (*permissionv1.Plan, error) {
+			return permissionv1.RevokeServer(ctx, c.PermissionV1().ServerPermission(), opts)
+		},
+	})
+}
+
+func NewProjectRevokeCmd(clientOpts *clientOptions) *cobra.Command {
+	return newRevokeCmd(clientOpts, revokeResource{
+		name:      "project",
+		arg:       "PROJECT-ID",
+		defaultID: uuid.Nil.String(),
+		complete:  completeProjects(clientOpts),
+		relations: choices(permissionv1.ValidProjectAssignmentTypes),
+		revoke: func(ctx context.Context, c *client.Client, id string, opts *permissionv1.RevokeOptions) (*permissionv1.Plan, error) {
+			return permissionv1.RevokeProject(ctx, c.PermissionV1().ProjectPermission(), id, opts)
+		},
+	})
+}
+
+func NewRoleRevokeCmd(clientOpts *clientOptions) *cobra.Command {
+	return newRevokeCmd(clientOpts, revokeResource{
+		name:      "role",
+		arg:       "ROLEID",
+		complete:  completeRoles(clientOpts),
+		relations: choices(permissionv1.ValidRoleAssignmentTypes),
+		revoke: func(ctx context.Context, c *client.Client, id string, opts *permissionv1.RevokeOptions) (*permissionv1.Plan, error) {
+			return permissionv1.RevokeRole(ctx, c.PermissionV1().RolePermission(), id, opts)
+		},
+	})
+}
+
+func NewWarehouseRevokeCmd(clientOpts *clientOptions) *cobra.Command {
+	return newRevokeCmd(clientOpts, revokeResource{
+		name:      "warehouse",
+		arg:       "WAREHOUSEID",
+		complete:  completeWarehouses(clientOpts),
+		relations: choices(permissionv1.ValidWarehouseAssignmentTypes),
+		revoke: func(ctx context.Context, c *client.Client, id string, opts *permissionv1.RevokeOptions) (*permissionv1.Plan, error) {
+			return permissionv1.RevokeWarehouse(ctx, c.PermissionV1().WarehousePermission(), id, opts)
+		},
+	})
+}
+
+func newRevokeCmd(clientOpts *clientOptions, resource revokeResource) *cobra.Command {
+	var (
+		users       []string
+		roles       []string
+		assignments []string
+		all         bool
+		yes         bool
+		dryRun      bool
+
+		output string
+	)
+
+	use, example := "revoke", ""
+	if resource.arg != "" {
+		use += " " + resource.arg
+		example = " 0198618c-5be8-7a82-a0b9-1076c9dd12f0"
+	}
+
+	command := cobra.Command{
+		Use:   use,
+		Short: fmt.Sprintf("remove %s assignments", resource.name),
+		Long: fmt.Sprintf(`Remove assignments of users and roles on a %s.
+
+The assignments to delete are listed, along with the requested ones the
+principals do not have, and a confirmation is asked before deleting them.
+
+With --all, every assignment of a single user or role is revoked.`, resource.name),
+		Example: fmt.Sprintf(`  # Revoke select from a user
+  lkctl %[1]s revoke%[2]s --users oidc~d223d88c-85b6-4859-b5c5-27f3825e47f6 --assignments select
+
+  # Revoke everything from a role, without confirmation
+  lkctl %[1]s revoke%[2]s --roles 01986184-3cb1-7526-a98c-72fecfe97731 --all --yes`, resource.name, example),
+		Run: func(cmd *cobra.Command, args []string) {
+			var id string
+			switch {
+			case resource.arg == "" && len(args) == 0:
+			case len(args) == 1 && resource.arg != "":
+				id = args[0]
+			case len(args) == 0 && resource.defaultID != "":
+				id = resource.defaultID
+			default:
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			if output != "text" && output != "json" {
+				log.Fatalf("unknown output format %s\n", output)
+			}
+
+			principals := revokePrincipals(users, roles)
+			if len(principals) < 1 {
+				log.Fatal("you must set at least one user or role")
+			}
+			if all && len(principals) != 1 {
+				log.Fatal("--all revokes the assignments of a single user or role")
+			}
+
+			errors.Check(validateChoices("assignments", assignments, resource.relations))
+
+			opts := permissionv1.RevokeOptions{
+				Principals: principals,
+				Relations:  assignments,
+				DryRun:     true,
+			}
+
+			target := strings.TrimSpace(resource.name + " " + id)
+
+			ctx := cmd.Context()
+			c := MustCreateClient(ctx, clientOpts)
+
+			plan, err := resource.revoke(ctx, c, id, &opts)
+			errors.Check(err)
+
+			result := revokeResult{
+				Plan:        plan,
+				NotAssigned: missingAssignments(plan, principals, assignments),
+			}
+
+			if output == "text" {
+				printRevokeResult(result)
+			}
+
+			if plan.IsEmpty() || dryRun {
+				if output == "json" {
+					errors.Check(PrintResource(result, output))
+				} else if !plan.IsEmpty() {
+					fmt.Println("Dry run, no changes applied")
+				}
+				return
+			}
+
+			if !yes && !confirm(fmt.Sprintf("Revoke %d assignments on %s?", len(plan.Deletes), target)) {
+				log.Fatal("aborted, no changes applied")
+			}
+
+			opts.DryRun = false
+			result.Plan, err = resource.revoke(ctx, c, id, &opts)
+			errors.Check(err)
+
+			switch output {
+			case "text":
+				fmt.Printf("%d assignments revoked on %s\n", len(result.Plan.Deletes), target)
+			case "json":
+				errors.Check(PrintResource(result, output))
+			}
+		},
+	}
+
+	command.Flags().StringSliceVar(&users, "users", []string{}, "Revoke access from users; can be repeated multiple times to add multiple users")
+	command.Flags().StringSliceVar(&roles, "roles", []string{}, "Revoke access from roles; can be repeated multiple times to add multiple roles")
+	command.Flags().StringSliceVar(&assignments, "assignments", []string{}, "Assignments to revoke; can be repeated multiple times to add multiple assignments")
+	command.Flags().BoolVar(&all, "all", false, "Revoke all the assignments of the user or role")
+	command.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Print the assignments to revoke without revoking them")
+	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
+
+	command.MarkFlagsOneRequired("assignments", "all")
+	command.MarkFlagsMutuallyExclusive("assignments", "all")
+
+	if resource.complete != nil {
+		command.ValidArgsFunction = completeArgs(1, resource.complete)
+	}
+	_ = command.RegisterFlagCompletionFunc("users", completeUsers(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("roles", completeRoles(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("assignments", completeChoices(resource.relations))
+
+	return &command
+}
+
+func revokePrincipals(users, roles []string) []permissionv1.UserOrRole {
+	principals := make([]permissionv1.UserOrRole, 0, len(users)+len(roles))
+	for _, v := range users {
+		principals = append(principals, permissionv1.UserOrRole{Type: permissionv1.UserType, Value: v})
+	}
+	for _, v := range roles {
+		principals = append(principals, permissionv1.UserOrRole{Type: permissionv1.RoleType, Value: v})
+	}
+	return principals
+}
+
+// missingAssignments returns the requested assignments that are not
+// in the plan, because the principals do not have them.
+func missingAssignments(plan *permissionv1.Plan, principals []permissionv1.UserOrRole, assignments []string) []notAssigned {
+	missing := []notAssigned{}
+	for _, p := range principals {
+		for _, a := range assignments {
+			if slices.ContainsFunc(plan.Deletes, func(d permissionv1.Assignment) bool {
+				return d.GetPrincipalType() == p.Type && d.GetPrincipalID() == p.Value && d.GetAssignment() == a
+			}) {
+				continue
+			}
+			missing = append(missing, notAssigned{PrincipalType: p.Type, PrincipalID: p.Value, Assignment: a})
+		}
+	}
+	return missing
+}
+
+func printRevokeResult(r revokeResult) {
+	if r.Plan.IsEmpty() {
+		fmt.Println("Nothing to revoke")
+	} else {
+		fmt.Print(r.Plan.String())
+	}
+
+	for _, m := range r.NotAssigned {
+		fmt.Printf("  %s %s does not have %s, skipped\n", m.PrincipalType, m.PrincipalID, m.Assignment)
+	}
+}
+
+// confirm asks a yes/no question on stderr and reads the answer from stdin.
+func confirm(question string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
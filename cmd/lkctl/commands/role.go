@@ -9,6 +9,8 @@ import (
 	"github.com/baptistegh/go-lakekeeper/cmd/lkctl/errors"
 	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
 	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+	"github.com/baptistegh/go-lakekeeper/pkg/bulk"
+	"github.com/baptistegh/go-lakekeeper/pkg/client"
 	"github.com/baptistegh/go-lakekeeper/pkg/core"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
@@ -36,6 +38,10 @@ func NewRoleCmd(clientOptions *clientOptions) *cobra.Command {
 	command.AddCommand(NewRoleAccessCmd(clientOptions, &project))
 	command.AddCommand(NewRoleAssignmentsCmd(clientOptions, &project))
 	command.AddCommand(NewRoleGrantCmd(clientOptions, &project))
+	command.AddCommand(NewRoleRevokeCmd(clientOptions))
+	command.AddCommand(NewRoleMembersCmd(clientOptions, &project))
+
+	_ = command.RegisterFlagCompletionFunc("project", completeProjects(clientOptions))
 
 	return &command
 }
@@ -159,6 +165,8 @@ func NewRoleGetCmd(clientOptions *clientOptions, project *string) *cobra.Command
 
 	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text|wide")
 
+	command.ValidArgsFunction = completeArgs(1, completeRoles(clientOptions))
+
 	return &command
 }
 
@@ -214,6 +222,8 @@ func NewRoleDeleteCmd(clientOpts *clientOptions, project *string) *cobra.Command
 		},
 	}
 
+	command.ValidArgsFunction = completeArgs(1, completeRoles(clientOpts))
+
 	return &command
 }
 
@@ -244,6 +254,8 @@ func NewRoleUpdateCmd(clientOpts *clientOptions, project *string) *cobra.Command
 	command.Flags().StringVar(&description, "description", "", "Add a description to the role")
 	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
 
+	command.ValidArgsFunction = completeArgs(1, completeRoles(clientOpts))
+
 	return &command
 }
 
@@ -311,6 +323,11 @@ func NewRoleAccessCmd(clientOpts *clientOptions, _ *string) *cobra.Command {
 	AddAccessFlags(&command, &accessOpts)
 	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
 
+	command.ValidArgsFunction = completeArgs(1, completeRoles(clientOpts))
+
+	_ = command.RegisterFlagCompletionFunc("user", completeUsers(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("role", completeRoles(clientOpts))
+
 	return &command
 }
 
@@ -340,6 +357,8 @@ func NewRoleAssignmentsCmd(clientOpts *clientOptions, _ *string) *cobra.Command
 
 			ctx := cmd.Context()
 
+			errors.Check(validateChoices("relations", assignmentsOpts.relations, choices(permissionv1.ValidRoleAssignmentTypes)))
+
 			var relations []permissionv1.RoleAssignmentType
 			for _, v := range assignmentsOpts.relations {
 				relations = append(relations, permissionv1.RoleAssignmentType(v))
@@ -365,6 +384,10 @@ func NewRoleAssignmentsCmd(clientOpts *clientOptions, _ *string) *cobra.Command
 	AddAssignmentsFlags(&command, &assignmentsOpts)
 	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
 
+	command.ValidArgsFunction = completeArgs(1, completeRoles(clientOpts))
+
+	_ = command.RegisterFlagCompletionFunc("relations", completeChoices(choices(permissionv1.ValidRoleAssignmentTypes)))
+
 	return &command
 }
 
@@ -374,13 +397,30 @@ func NewRoleGrantCmd(clientOpts *clientOptions, _ *string) *cobra.Command {
 		roles []string
 
 		assignments []string
+
+		batch batchOpts
 	)
 
 	command := cobra.Command{
 		Use:     "grant ROLEID",
 		Short:   "add role assignments",
 		Aliases: []string{"assign"},
+		Example: `  # Grant ownership of a role to a user
+  lkctl role grant 0198618c-5be8-7a82-a0b9-1076c9dd12f0 --users oidc~d223d88c-85b6-4859-b5c5-27f3825e47f6 --assignments ownership
+
+  # Grant assignments in batch from a CSV file with the columns role, type, principal and assignment
+  lkctl role grant -f grants.csv`,
 		Run: func(cmd *cobra.Command, args []string) {
+			if batch.file != "" && len(args) <= 1 {
+				var role string
+				if len(args) == 1 {
+					role = args[0]
+				}
+				err := grantRoles(cmd.Context(), clientOpts, batch, role)
+				errors.Check(err)
+				return
+			}
+
 			if len(args) != 1 {
 				cmd.HelpFunc()(cmd, args)
 				os.Exit(1)
@@ -391,6 +431,8 @@ func NewRoleGrantCmd(clientOpts *clientOptions, _ *string) *cobra.Command {
 			if len(assignments) < 1 {
 				log.Fatal("you must set at lest one assignment")
 			}
+
+			errors.Check(validateChoices("assignments", assignments, choices(permissionv1.ValidRoleAssignmentTypes)))
 			if len(users) < 1 && len(roles) < 1 {
 				log.Fatal("you must set at least one user or role")
 			}
@@ -431,12 +473,52 @@ func NewRoleGrantCmd(clientOpts *clientOptions, _ *string) *cobra.Command {
 	command.Flags().StringSliceVar(&roles, "roles", []string{}, "Grant access to roles; can be repeated multiple times to add multiple roles")
 	command.Flags().StringSliceVar(&assignments, "assignments", []string{}, "Assignments to use; can be repeated multiple times to add multiple assignments")
 
-	err := command.MarkFlagRequired("assignments")
-	errors.Check(err)
+	AddBatchFlags(&command, &batch, "role (optional), type (user or role), principal and assignment")
+
+	command.MarkFlagsOneRequired("assignments", "file")
+	command.MarkFlagsMutuallyExclusive("assignments", "file")
+
+	command.ValidArgsFunction = completeArgs(1, completeRoles(clientOpts))
+
+	_ = command.RegisterFlagCompletionFunc("users", completeUsers(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("roles", completeRoles(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("assignments", completeChoices(choices(permissionv1.ValidRoleAssignmentTypes)))
 
 	return &command
 }
 
+func grantRoles(ctx context.Context, clientOpts *clientOptions, batch batchOpts, defaultRole string) error {
+	var defaultRoles []string
+	if defaultRole != "" {
+		defaultRoles = []string{defaultRole}
+	}
+
+	grants, err := readGrants(batch.file, "role", defaultRoles, choices(permissionv1.ValidRoleAssignmentTypes))
+	if err != nil {
+		return err
+	}
+
+	roles, groups := groupGrants(grants)
+	c := MustCreateClient(ctx, clientOpts).PermissionV1().RolePermission()
+
+	results := bulk.Do(ctx, roles, func(ctx context.Context, role string) error {
+		opt := permissionv1.UpdateRolePermissionsOptions{}
+		for _, g := range groups[role] {
+			opt.Writes = append(opt.Writes, &permissionv1.RoleAssignment{
+				Assignee:   g.assignee,
+				Assignment: permissionv1.RoleAssignmentType(g.assignment),
+			})
+		}
+
+		_, err := c.Update(ctx, role, &opt)
+		return err
+	}, bulk.WithConcurrency(batch.concurrency))
+
+	return printBatchResults("text", results, func(role string) string {
+		return fmt.Sprintf("role %s (%d assignments)", role, len(groups[role]))
+	})
+}
+
 func createRole(ctx context.Context, clientOpts *clientOptions, name, project, description, output string) error {
 	opt := managementv1.CreateRoleOptions{
 		Name:      name,
@@ -487,3 +569,221 @@ func updateRole(ctx context.Context, clientOpts *clientOptions, id, project, nam
 		return fmt.Errorf("unknown output format: %s", output)
 	}
 }
+
+// roleMember is a principal assigned to a role, with
+// its name and email resolved when possible.
+type roleMember struct {
+	Type  permissionv1.UserOrRoleType `json:"type"`
+	ID    string                      `json:"id"`
+	Name  string                      `json:"name,omitempty"`
+	Email string                      `json:"email,omitempty"`
+}
+
+func NewRoleMembersCmd(clientOpts *clientOptions, project *string) *cobra.Command {
+	command := cobra.Command{
+		Use:   "members",
+		Short: "Manage role members",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command.AddCommand(NewRoleMembersListCmd(clientOpts, project))
+	command.AddCommand(NewRoleMembersAddCmd(clientOpts, project))
+	command.AddCommand(NewRoleMembersRemoveCmd(clientOpts, project))
+
+	return &command
+}
+
+func NewRoleMembersListCmd(clientOpts *clientOptions, project *string) *cobra.Command {
+	var output string
+
+	command := cobra.Command{
+		Use:     "list ROLEID",
+		Short:   "List the members of a role",
+		Aliases: []string{"ls"},
+		Example: `  # List the members of a role
+  lkctl role members ls 0198618c-5be8-7a82-a0b9-1076c9dd12f0`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			ctx := cmd.Context()
+			c := MustCreateClient(ctx, clientOpts)
+
+			opt := permissionv1.GetRoleAssignmentsOptions{
+				Relations: []permissionv1.RoleAssignmentType{permissionv1.AssigneeRoleAssignment},
+			}
+
+			resp, _, err := c.PermissionV1().RolePermission().GetAssignments(ctx, args[0], &opt)
+			errors.Check(err)
+
+			members := make([]roleMember, 0, len(resp.Assignments))
+			for _, a := range resp.Assignments {
+				if a.Assignment != permissionv1.AssigneeRoleAssignment {
+					continue
+				}
+				members = append(members, resolveRoleMember(ctx, c, *project, a.Assignee))
+			}
+
+			switch output {
+			case "text":
+				if len(members) == 0 {
+					fmt.Println("No members")
+					return
+				}
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintf(w, "TYPE\tID\tNAME\tEMAIL\n")
+				for _, m := range members {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.Type, m.ID, m.Name, m.Email)
+				}
+				w.Flush()
+			case "json":
+				err := PrintResource(members, output)
+				errors.Check(err)
+			default:
+				log.Fatalf("unknown output format %s\n", output)
+			}
+		},
+	}
+
+	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
+
+	command.ValidArgsFunction = completeArgs(1, completeRoles(clientOpts))
+
+	return &command
+}
+
+func NewRoleMembersAddCmd(clientOpts *clientOptions, _ *string) *cobra.Command {
+	var (
+		users []string
+		roles []string
+	)
+
+	command := cobra.Command{
+		Use:   "add ROLEID",
+		Short: "Add members to a role",
+		Example: `  # Add a user to a role
+  lkctl role members add 0198618c-5be8-7a82-a0b9-1076c9dd12f0 --user oidc~d223d88c-85b6-4859-b5c5-27f3825e47f6
+
+  # Make the members of a role members of another one
+  lkctl role members add 0198618c-5be8-7a82-a0b9-1076c9dd12f0 --role 01986184-3cb1-7526-a98c-72fecfe97731`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			opt := permissionv1.UpdateRolePermissionsOptions{
+				Writes: roleMemberAssignments(users, roles),
+			}
+
+			ctx := cmd.Context()
+			_, err := MustCreateClient(ctx, clientOpts).PermissionV1().RolePermission().Update(ctx, args[0], &opt)
+			errors.Check(err)
+
+			fmt.Printf("%d members added to role %s\n", len(opt.Writes), args[0])
+		},
+	}
+
+	AddRoleMembersFlags(&command, &users, &roles)
+
+	command.ValidArgsFunction = completeArgs(1, completeRoles(clientOpts))
+
+	_ = command.RegisterFlagCompletionFunc("user", completeUsers(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("role", completeRoles(clientOpts))
+
+	return &command
+}
+
+func NewRoleMembersRemoveCmd(clientOpts *clientOptions, _ *string) *cobra.Command {
+	var (
+		users []string
+		roles []string
+	)
+
+	command := cobra.Command{
+		Use:     "remove ROLEID",
+		Short:   "Remove members from a role",
+		Aliases: []string{"rm"},
+		Example: `  # Remove a user from a role
+  lkctl role members rm 0198618c-5be8-7a82-a0b9-1076c9dd12f0 --user oidc~d223d88c-85b6-4859-b5c5-27f3825e47f6`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			opt := permissionv1.UpdateRolePermissionsOptions{
+				Deletes: roleMemberAssignments(users, roles),
+			}
+
+			ctx := cmd.Context()
+			_, err := MustCreateClient(ctx, clientOpts).PermissionV1().RolePermission().Update(ctx, args[0], &opt)
+			errors.Check(err)
+
+			fmt.Printf("%d members removed from role %s\n", len(opt.Deletes), args[0])
+		},
+	}
+
+	AddRoleMembersFlags(&command, &users, &roles)
+
+	command.ValidArgsFunction = completeArgs(1, completeRoles(clientOpts))
+
+	_ = command.RegisterFlagCompletionFunc("user", completeUsers(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("role", completeRoles(clientOpts))
+
+	return &command
+}
+
+func AddRoleMembersFlags(cmd *cobra.Command, users, roles *[]string) {
+	cmd.Flags().StringSliceVar(users, "user", []string{}, "User ID; can be repeated multiple times or comma separated")
+	cmd.Flags().StringSliceVar(roles, "role", []string{}, "Role ID; can be repeated multiple times or comma separated")
+	cmd.MarkFlagsOneRequired("user", "role")
+}
+
+// roleMemberAssignments returns the assignee assignments of users and roles.
+func roleMemberAssignments(users, roles []string) []*permissionv1.RoleAssignment {
+	assignments := make([]*permissionv1.RoleAssignment, 0, len(users)+len(roles))
+	for _, v := range users {
+		assignments = append(assignments, &permissionv1.RoleAssignment{
+			Assignee:   permissionv1.UserOrRole{Type: permissionv1.UserType, Value: v},
+			Assignment: permissionv1.AssigneeRoleAssignment,
+		})
+	}
+	for _, v := range roles {
+		assignments = append(assignments, &permissionv1.RoleAssignment{
+			Assignee:   permissionv1.UserOrRole{Type: permissionv1.RoleType, Value: v},
+			Assignment: permissionv1.AssigneeRoleAssignment,
+		})
+	}
+	return assignments
+}
+
+// resolveRoleMember looks up the name and email of a member. Lookup
+// failures are logged and the member is returned with its ID only.
+func resolveRoleMember(ctx context.Context, c *client.Client, project string, assignee permissionv1.UserOrRole) roleMember {
+	m := roleMember{Type: assignee.Type, ID: assignee.Value}
+
+	switch assignee.Type {
+	case permissionv1.UserType:
+		u, _, err := c.UserV1().Get(ctx, assignee.Value)
+		if err != nil {
+			log.Debugf("could not get user %s, %v", assignee.Value, err)
+			return m
+		}
+		m.Name = u.Name
+		m.Email = FormatPString(u.Email)
+	case permissionv1.RoleType:
+		r, _, err := c.RoleV1(project).Get(ctx, assignee.Value)
+		if err != nil {
+			log.Debugf("could not get role %s, %v", assignee.Value, err)
+			return m
+		}
+		m.Name = r.Name
+	}
+
+	return m
+}
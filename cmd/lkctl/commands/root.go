@@ -46,7 +46,10 @@ func NewCommand() *cobra.Command {
 		},
 	}
 
+	command.AddCommand(NewAuthCmd(&clientOpts))
+	command.AddCommand(NewPermissionsCmd(&clientOpts))
 	command.AddCommand(NewProjectCmd(&clientOpts))
+	command.AddCommand(NewReportCmd(&clientOpts))
 	command.AddCommand(NewRoleCmd(&clientOpts))
 	command.AddCommand(NewServerCmd(&clientOpts))
 	command.AddCommand(NewUserCmd(&clientOpts))
@@ -63,6 +66,7 @@ func NewCommand() *cobra.Command {
 	command.PersistentFlags().StringSliceVar(&clientOpts.scope, "scopes", common.GetEnvSlice(common.EnvScope, " ", common.DefaultScope), fmt.Sprintf("OAuth2 scopes; set this or %s environment variable", common.EnvScope))
 	command.PersistentFlags().BoolVar(&clientOpts.boostrap, "bootstrap", common.GetBoolEnv(common.EnvBootstrap), fmt.Sprintf("If set to true, the CLI will try to bootstrap the server with the current user first; set this or %s environment variable", common.EnvBootstrap))
 	command.PersistentFlags().BoolVar(&clientOpts.debug, "debug", false, "Enable debug mode")
+	command.PersistentFlags().Float64Var(&clientOpts.rateLimit, "rate-limit", 0, "Maximum number of requests per second sent to the server; 0 means unlimited")
 
 	return command
 }
@@ -29,6 +29,7 @@ func NewServerCmd(clientOptions *clientOptions) *cobra.Command {
 	command.AddCommand(NewServerAccessCmd(clientOptions))
 	command.AddCommand(NewServerAssignmentsCmd(clientOptions))
 	command.AddCommand(NewServerGrantCmd(clientOptions))
+	command.AddCommand(NewServerRevokeCmd(clientOptions))
 
 	return &command
 }
@@ -107,6 +108,8 @@ func NewServerAssignmentsCmd(clientOpts *clientOptions) *cobra.Command {
 
 			ctx := cmd.Context()
 
+			errors.Check(validateChoices("relations", assignmentsOpts.relations, choices(permissionv1.ValidServerAssignmentTypes)))
+
 			var relations []permissionv1.ServerAssignmentType
 			for _, v := range assignmentsOpts.relations {
 				relations = append(relations, permissionv1.ServerAssignmentType(v))
@@ -134,6 +137,8 @@ func NewServerAssignmentsCmd(clientOpts *clientOptions) *cobra.Command {
 	AddAssignmentsFlags(&command, &assignmentsOpts)
 	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
 
+	_ = command.RegisterFlagCompletionFunc("relations", completeChoices(choices(permissionv1.ValidServerAssignmentTypes)))
+
 	return &command
 }
 
@@ -166,6 +171,8 @@ func NewServerGrantCmd(clientOpts *clientOptions) *cobra.Command {
 				log.Fatal("you must set at lest one assignment")
 			}
 
+			errors.Check(validateChoices("assignments", assignments, choices(permissionv1.ValidServerAssignmentTypes)))
+
 			if len(users) < 1 && len(roles) < 1 {
 				log.Fatal("you must set at least one user or role")
 			}
@@ -210,9 +217,25 @@ func NewServerGrantCmd(clientOpts *clientOptions) *cobra.Command {
 	err := command.MarkFlagRequired("assignments")
 	errors.Check(err)
 
+	_ = command.RegisterFlagCompletionFunc("users", completeUsers(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("roles", completeRoles(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("assignments", completeChoices(choices(permissionv1.ValidServerAssignmentTypes)))
+
 	return &command
 }
 
+// serverFeatures lists the features gated by server capabilities,
+// reported by the info command.
+var serverFeatures = []struct {
+	key  string
+	name string
+	reqs []core.Requirement
+}{
+	{"permissions", "Permissions API", []core.Requirement{core.RequireOpenFGA()}},
+	{"allowed-actions", "Allowed Actions API", []core.Requirement{core.RequireVersion(core.MinVersionAllowedActions)}},
+	{"authorizer-actions", "Authorizer Actions API", []core.Requirement{core.RequireOpenFGA(), core.RequireVersion(core.MinVersionAllowedActions)}},
+}
+
 func NewInfoCmd(clientOptions *clientOptions) *cobra.Command {
 	var output string
 
@@ -225,6 +248,8 @@ func NewInfoCmd(clientOptions *clientOptions) *cobra.Command {
 			resp, _, err := MustCreateClient(ctx, clientOptions).ServerV1().Info(ctx)
 			errors.Check(err)
 
+			caps := resp.Capabilities()
+
 			switch output {
 			case "text":
 				fmt.Printf("ID: %s\n", resp.ServerID)
@@ -239,8 +264,24 @@ func NewInfoCmd(clientOptions *clientOptions) *cobra.Command {
 				for _, q := range resp.Queues {
 					fmt.Printf("  %s\n", q)
 				}
+				fmt.Println("Capabilities:")
+				for _, f := range serverFeatures {
+					if err := caps.Check(f.reqs...); err != nil {
+						fmt.Printf("  %s: unsupported, %s\n", f.name, err)
+						continue
+					}
+					fmt.Printf("  %s: supported\n", f.name)
+				}
 			case "json":
-				err := PrintResource(resp, output)
+				features := make(map[string]bool, len(serverFeatures))
+				for _, f := range serverFeatures {
+					features[f.key] = caps.Check(f.reqs...) == nil
+				}
+
+				err := PrintResource(struct {
+					*managementv1.ServerInfo
+					Capabilities map[string]bool `json:"capabilities"`
+				}{resp, features}, output)
 				errors.Check(err)
 			default:
 				log.Printf("unknown output format: %s\n", output)
@@ -318,5 +359,8 @@ func NewServerAccessCmd(clientOpts *clientOptions) *cobra.Command {
 	AddAccessFlags(&command, &accessOpts)
 	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
 
+	_ = command.RegisterFlagCompletionFunc("user", completeUsers(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("role", completeRoles(clientOpts))
+
 	return &command
 }
@@ -1,13 +1,18 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/baptistegh/go-lakekeeper/cmd/lkctl/errors"
 	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+	"github.com/baptistegh/go-lakekeeper/pkg/bulk"
 	"github.com/baptistegh/go-lakekeeper/pkg/core"
+	"github.com/baptistegh/go-lakekeeper/pkg/offboard"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -26,6 +31,7 @@ func NewUserCmd(clientOpts *clientOptions) *cobra.Command {
 	command.AddCommand(NewUserGetCmd(clientOpts))
 	command.AddCommand(NewUserDeleteCmd(clientOpts))
 	command.AddCommand(NewUserCreateCmd(clientOpts))
+	command.AddCommand(NewUserOffboardCmd(clientOpts))
 
 	return &command
 }
@@ -113,6 +119,8 @@ func NewUserGetCmd(clientOpts *clientOptions) *cobra.Command {
 
 	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text|wide")
 
+	command.ValidArgsFunction = completeArgs(1, completeUsers(clientOpts))
+
 	return &command
 }
 
@@ -136,6 +144,8 @@ func NewUserDeleteCmd(clientOpts *clientOptions) *cobra.Command {
 		},
 	}
 
+	command.ValidArgsFunction = completeArgs(1, completeUsers(clientOpts))
+
 	return &command
 }
 
@@ -145,6 +155,8 @@ func NewUserCreateCmd(clientOpts *clientOptions) *cobra.Command {
 
 		email  string
 		update bool
+
+		batch batchOpts
 	)
 
 	command := cobra.Command{
@@ -158,8 +170,20 @@ func NewUserCreateCmd(clientOpts *clientOptions) *cobra.Command {
   lkctl user create kubernetes~d223d88c-85b6-4859-b5c5-27f3825e47f6 "Service Account" application
 
   # Create a user with an email
-  lkctl user create oidc~d223d88c-85b6-4859-b5c5-27f3825e47f6 "Peter Cold" human --email peter.cold@example.com`,
+  lkctl user create oidc~d223d88c-85b6-4859-b5c5-27f3825e47f6 "Peter Cold" human --email peter.cold@example.com
+
+  # Create users in batch from a CSV file with the columns id, name, type and email
+  lkctl user create -f users.csv --concurrency 8`,
 		Run: func(cmd *cobra.Command, args []string) {
+			if batch.file != "" {
+				if len(args) != 0 {
+					log.Fatal("arguments are not allowed with --file")
+				}
+				err := provisionUsers(cmd.Context(), clientOpts, batch, update, output)
+				errors.Check(err)
+				return
+			}
+
 			if len(args) != 3 {
 				cmd.HelpFunc()(cmd, args)
 				os.Exit(1)
@@ -198,10 +222,50 @@ func NewUserCreateCmd(clientOpts *clientOptions) *cobra.Command {
 	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
 	command.Flags().StringVar(&email, "email", "", "Add an email to the user")
 	command.Flags().BoolVar(&update, "update", false, "Update the user if exists")
+	AddBatchFlags(&command, &batch, "id, name, type and email (optional)")
 
 	return &command
 }
 
+func provisionUsers(ctx context.Context, clientOpts *clientOptions, batch batchOpts, update bool, output string) error {
+	records, err := readCSV(batch.file, "id", "name", "type")
+	if err != nil {
+		return err
+	}
+
+	opts := make([]*managementv1.ProvisionUserOptions, 0, len(records))
+	for i, r := range records {
+		userType := managementv1.UserType(strings.ToLower(r["type"]))
+		if userType != managementv1.HumanUserType && userType != managementv1.ApplicationUserType {
+			return fmt.Errorf("%s: record %d, type must be human or application, got %s", batch.file, i+1, r["type"])
+		}
+
+		opt := managementv1.ProvisionUserOptions{
+			ID:       core.Ptr(r["id"]),
+			Name:     core.Ptr(r["name"]),
+			UserType: core.Ptr(userType),
+		}
+		if r["email"] != "" {
+			opt.Email = core.Ptr(r["email"])
+		}
+		if update {
+			opt.UpdateIfExists = core.Ptr(update)
+		}
+		opts = append(opts, &opt)
+	}
+
+	c := MustCreateClient(ctx, clientOpts).UserV1()
+
+	results := bulk.Run(ctx, opts, func(ctx context.Context, opt *managementv1.ProvisionUserOptions) (*managementv1.User, error) {
+		u, _, err := c.Provision(ctx, opt)
+		return u, err
+	}, bulk.WithConcurrency(batch.concurrency))
+
+	return printBatchResults(output, results, func(opt *managementv1.ProvisionUserOptions) string {
+		return *opt.ID
+	})
+}
+
 func printUsers(output string, nextPageToken *string, users ...*managementv1.User) {
 	switch output {
 	case "text":
@@ -226,3 +290,106 @@ func printUsers(output string, nextPageToken *string, users ...*managementv1.Use
 		}
 	}
 }
+
+func NewUserOffboardCmd(clientOpts *clientOptions) *cobra.Command {
+	var (
+		transferToUser string
+		transferToRole string
+		keepUser       bool
+		dryRun         bool
+		yes            bool
+
+		output string
+	)
+
+	command := cobra.Command{
+		Use:   "offboard USERID",
+		Short: "Delete all the assignments of a user, then the user",
+		Long: `Delete all the assignments of a user, then the user.
+
+The assignments of the user are discovered on the server, and on every
+project, warehouse and role. Ownership assignments can be transferred to
+another user or role before they are deleted.
+
+The changes are printed and confirmed before they are applied, unless
+--yes is set.`,
+		Example: `  # Preview the changes
+  lkctl user offboard oidc~d223d88c-85b6-4859-b5c5-27f3825e47f6 --dry-run
+
+  # Offboard a user, transferring its ownerships to a role
+  lkctl user offboard oidc~d223d88c-85b6-4859-b5c5-27f3825e47f6 --transfer-ownership-to-role 0198618c-5be8-7a82-a0b9-1076c9dd12f0
+
+  # Offboard a user without confirmation, e.g. in a script
+  lkctl user offboard oidc~d223d88c-85b6-4859-b5c5-27f3825e47f6 --yes`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			ctx := cmd.Context()
+			c := MustCreateClient(ctx, clientOpts)
+
+			opts := offboard.Options{DeleteUser: !keepUser}
+			switch {
+			case transferToUser != "":
+				opts.TransferOwnershipTo = &permissionv1.UserOrRole{Type: permissionv1.UserType, Value: transferToUser}
+			case transferToRole != "":
+				opts.TransferOwnershipTo = &permissionv1.UserOrRole{Type: permissionv1.RoleType, Value: transferToRole}
+			}
+
+			if output != "text" && output != "json" {
+				log.Fatalf("unknown output format %s\n", output)
+			}
+
+			plan, err := offboard.NewPlan(ctx, c, args[0], &opts)
+			errors.Check(err)
+
+			if output == "text" {
+				fmt.Print(plan.String())
+			}
+
+			if dryRun || (len(plan.Changes) == 0 && !plan.DeleteUser) {
+				if output == "json" {
+					errors.Check(PrintResource(plan, output))
+				} else if dryRun {
+					fmt.Println("Dry run, no changes applied")
+				}
+				return
+			}
+
+			if !yes && !confirm(fmt.Sprintf("Offboard user %s, changing the assignments of %d resources?", plan.User, len(plan.Changes))) {
+				log.Fatal("aborted, no changes applied")
+			}
+
+			err = plan.Apply(ctx, c)
+
+			switch output {
+			case "text":
+				if err == nil {
+					fmt.Printf("user %s offboarded\n", plan.User)
+				}
+			case "json":
+				errors.Check(PrintResource(plan, output))
+			}
+
+			errors.Check(err)
+		},
+	}
+
+	command.Flags().StringVar(&transferToUser, "transfer-ownership-to-user", "", "Transfer the ownership assignments of the user to this user")
+	command.Flags().StringVar(&transferToRole, "transfer-ownership-to-role", "", "Transfer the ownership assignments of the user to this role")
+	command.Flags().BoolVar(&keepUser, "keep-user", false, "Only delete the assignments, keep the user")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Print the changes without applying them")
+	command.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation")
+	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
+
+	command.MarkFlagsMutuallyExclusive("transfer-ownership-to-user", "transfer-ownership-to-role")
+
+	command.ValidArgsFunction = completeArgs(1, completeUsers(clientOpts))
+
+	_ = command.RegisterFlagCompletionFunc("transfer-ownership-to-user", completeUsers(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("transfer-ownership-to-role", completeRoles(clientOpts))
+
+	return &command
+}
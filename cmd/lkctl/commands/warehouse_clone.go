@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/baptistegh/go-lakekeeper/cmd/lkctl/errors"
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	profilev1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/profile"
+	"github.com/spf13/cobra"
+)
+
+func NewWarehouseCloneCmd(clientOpts *clientOptions, project *string) *cobra.Command {
+	var (
+		source          credentialSource
+		keyPrefix       string
+		softDelete      durationValue
+		hardDelete      bool
+		copyProtection  bool
+		copyAssignments bool
+	)
+
+	command := cobra.Command{
+		Use:   "clone SRC-WAREHOUSEID NEW-NAME --key-prefix PREFIX (-f JSONCREDENTIALFILE | --aws-profile PROFILE | --gcs-key-file KEYFILE)",
+		Short: "Create a new warehouse from the configuration of an existing one",
+		Long: `Create a new warehouse from the configuration of an existing one.
+
+The storage profile of the source warehouse is copied with a new key prefix,
+which must be neither the source one nor one of its parents for the
+warehouses not to share tables.
+The delete profile is copied unless overridden.
+
+The API never returns the storage credentials, the credential of the new
+warehouse must be provided, as for warehouse set-credential.
+
+The new warehouse is created in the project of the source, or in the
+project given with --project. The protection status and the permission
+assignments of the source can be copied as well.`,
+		Example: `  # Create a warehouse for a new team, next to an existing one
+  lkctl warehouse clone 019861a0-6d4e-7bf3-96c6-9aef2d4a2749 team-b --key-prefix team-b --aws-profile lakekeeper
+
+  # Copy the protection and the permissions, in another project
+  lkctl warehouse clone 019861a0-6d4e-7bf3-96c6-9aef2d4a2749 team-b --key-prefix team-b -f credential.json \
+    --project 0198618c-5be8-7a82-a0b9-1076c9dd12f0 --copy-protection --copy-assignments`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if len(args) != 2 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			opt := managementv1.CloneWarehouseOptions{
+				Name:              args[1],
+				KeyPrefix:         keyPrefix,
+				StorageCredential: source.load(cmd),
+				CopyProtection:    copyProtection,
+				CopyAssignments:   copyAssignments,
+			}
+
+			switch {
+			case hardDelete:
+				opt.DeleteProfile = profilev1.NewTabularDeleteProfileHard().AsProfile()
+			case cmd.Flags().Changed("soft-delete"):
+				dp, err := softDeleteProfile(time.Duration(softDelete))
+				errors.Check(err)
+				opt.DeleteProfile = dp
+			}
+
+			client := MustCreateClient(ctx, clientOpts)
+
+			src, _, err := client.WarehouseV1(*project).Get(ctx, args[0])
+			errors.Check(err)
+
+			createOpt, err := src.CloneOptions(&opt)
+			errors.Check(err)
+			checkValid("warehouse config", createOpt.Validate())
+
+			target := src.ProjectID
+			if cmd.Flags().Changed("project") {
+				target = *project
+			}
+
+			resp, _, err := managementv1.CloneWarehouse(ctx, client.WarehouseV1(target), client.PermissionV1().WarehousePermission(), src, &opt)
+			if resp != nil && err != nil {
+				fmt.Printf("Warehouse %s created with id %s\n", opt.Name, resp.ID)
+			}
+			errors.Check(err)
+
+			fmt.Printf("Warehouse %s created with id %s, cloned from %s\n", opt.Name, resp.ID, src.Name)
+		},
+	}
+
+	source.register(&command)
+	command.Flags().StringVar(&keyPrefix, "key-prefix", "", "Key prefix of the new warehouse, in the bucket or filesystem of the source")
+	command.Flags().Var(&softDelete, "soft-delete", "Keep dropped tables and views for this duration before deleting them, e.g. 7d or 12h")
+	command.Flags().BoolVar(&hardDelete, "hard-delete", false, "Delete dropped tables and views immediately")
+	command.Flags().BoolVar(&copyProtection, "copy-protection", false, "Protect the new warehouse if the source is protected")
+	command.Flags().BoolVar(&copyAssignments, "copy-assignments", false, "Copy the permission assignments of the source")
+
+	_ = command.MarkFlagRequired("key-prefix")
+	command.MarkFlagsMutuallyExclusive("soft-delete", "hard-delete")
+
+	command.ValidArgsFunction = completeArgs(1, completeWarehouses(clientOpts))
+
+	return &command
+}
@@ -0,0 +1,493 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	credentialv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/credential"
+	profilev1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/profile"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// warehouseFlags build the storage profile, the storage credential
+// and the delete profile of a warehouse from flags, on top of the
+// ones read from a config file, if any.
+type warehouseFlags struct {
+	keyPrefix  string
+	softDelete durationValue
+	hardDelete bool
+
+	s3Bucket         string
+	s3Region         string
+	s3Endpoint       string
+	s3Flavor         string
+	s3PathStyle      bool
+	stsEnabled       bool
+	stsRoleARN       string
+	assumeRoleARN    string
+	kmsKeyARN        string
+	stsTokenValidity int64
+
+	adlsAccount       string
+	adlsFilesystem    string
+	adlsHost          string
+	adlsAuthorityHost string
+	sasTokenValidity  int64
+
+	gcsBucket string
+
+	credentialFile    string
+	allowExecSecrets  bool
+	s3AccessKeyID     string
+	s3SecretAccessKey string
+	s3ExternalID      string
+	s3SystemIdentity  bool
+	awsProfile        string
+	azClientID        string
+	azClientSecret    string
+	azTenantID        string
+	azSharedKey       string
+	azManagedIdentity bool
+	gcsSystemIdentity bool
+	gcsKeyFile        string
+}
+
+var (
+	s3ProfileFlags   = []string{"s3-bucket", "s3-region", "s3-endpoint", "s3-flavor", "s3-path-style", "sts-enabled", "sts-role-arn", "assume-role-arn", "kms-key-arn", "sts-token-validity-seconds"}
+	adlsProfileFlags = []string{"adls-account", "adls-filesystem", "adls-host", "adls-authority-host", "sas-token-validity-seconds"}
+	gcsProfileFlags  = []string{"gcs-bucket"}
+
+	s3CredentialFlags   = []string{"s3-access-key-id", "s3-secret-access-key", "s3-external-id", "s3-system-identity", "aws-profile"}
+	adlsCredentialFlags = []string{"az-client-id", "az-client-secret", "az-tenant-id", "az-shared-key", "az-managed-identity"}
+	gcsCredentialFlags  = []string{"gcs-system-identity", "gcs-key-file"}
+
+	s3Flavors = []profilev1.S3Flavor{profilev1.AWSFlavor, profilev1.S3CompatFlavor}
+)
+
+func (f *warehouseFlags) register(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	flags.StringVar(&f.keyPrefix, "key-prefix", "", "Subpath in the bucket or filesystem to use")
+	flags.Var(&f.softDelete, "soft-delete", "Keep dropped tables and views for this duration before deleting them, e.g. 7d or 12h")
+	flags.BoolVar(&f.hardDelete, "hard-delete", false, "Delete dropped tables and views immediately")
+
+	flags.StringVar(&f.s3Bucket, "s3-bucket", "", "Name of the S3 bucket")
+	flags.StringVar(&f.s3Region, "s3-region", "", "Region of the S3 bucket")
+	flags.StringVar(&f.s3Endpoint, "s3-endpoint", "", "Endpoint of S3-compatible storage, e.g. http://minio:9000")
+	flags.StringVar(&f.s3Flavor, "s3-flavor", "", "S3 flavor. One of: aws|s3-compat")
+	flags.BoolVar(&f.s3PathStyle, "s3-path-style", false, "Use path style access for S3 requests")
+	flags.BoolVar(&f.stsEnabled, "sts-enabled", false, "Enable STS to vend credentials")
+	flags.StringVar(&f.stsRoleARN, "sts-role-arn", "", "ARN of the role to assume for STS vended credentials")
+	flags.StringVar(&f.assumeRoleARN, "assume-role-arn", "", "ARN of the role to assume when accessing the bucket")
+	flags.StringVar(&f.kmsKeyARN, "kms-key-arn", "", "ARN of the KMS key used to encrypt the bucket")
+	flags.Int64Var(&f.stsTokenValidity, "sts-token-validity-seconds", 0, "Validity of the STS tokens in seconds")
+
+	flags.StringVar(&f.adlsAccount, "adls-account", "", "Name of the Azure storage account")
+	flags.StringVar(&f.adlsFilesystem, "adls-filesystem", "", "Name of the ADLS filesystem")
+	flags.StringVar(&f.adlsHost, "adls-host", "", "Host of the storage account, e.g. dfs.core.windows.net")
+	flags.StringVar(&f.adlsAuthorityHost, "adls-authority-host", "", "Authority host to use for authentication")
+	flags.Int64Var(&f.sasTokenValidity, "sas-token-validity-seconds", 0, "Validity of the SAS tokens in seconds")
+
+	flags.StringVar(&f.gcsBucket, "gcs-bucket", "", "Name of the GCS bucket")
+
+	flags.StringVar(&f.credentialFile, "credential-file", "", "Storage credential file. JSON file or '-' for stdin")
+	addAllowExecSecretsFlag(cmd, &f.allowExecSecrets)
+	flags.StringVar(&f.s3AccessKeyID, "s3-access-key-id", "", "S3 access key ID. Defaults to $AWS_ACCESS_KEY_ID")
+	flags.StringVar(&f.s3SecretAccessKey, "s3-secret-access-key", "", "S3 secret access key. Defaults to $AWS_SECRET_ACCESS_KEY")
+	flags.StringVar(&f.s3ExternalID, "s3-external-id", "", "External ID used to assume roles")
+	flags.BoolVar(&f.s3SystemIdentity, "s3-system-identity", false, "Use the AWS identity of the Lakekeeper server")
+	flags.StringVar(&f.awsProfile, "aws-profile", "", "Read the S3 access key from this profile of the AWS shared credentials and config files")
+	flags.StringVar(&f.azClientID, "az-client-id", "", "Azure client ID. Defaults to $AZURE_CLIENT_ID")
+	flags.StringVar(&f.azClientSecret, "az-client-secret", "", "Azure client secret. Defaults to $AZURE_CLIENT_SECRET")
+	flags.StringVar(&f.azTenantID, "az-tenant-id", "", "Azure tenant ID. Defaults to $AZURE_TENANT_ID")
+	flags.StringVar(&f.azSharedKey, "az-shared-key", "", "Shared access key of the Azure storage account")
+	flags.BoolVar(&f.azManagedIdentity, "az-managed-identity", false, "Use the Azure managed identity of the Lakekeeper server")
+	flags.BoolVar(&f.gcsSystemIdentity, "gcs-system-identity", false, "Use the GCP identity of the Lakekeeper server")
+	flags.StringVar(&f.gcsKeyFile, "gcs-key-file", "", "GCP service account JSON key file")
+
+	cmd.MarkFlagsMutuallyExclusive("soft-delete", "hard-delete")
+	cmd.MarkFlagsMutuallyExclusive("s3-system-identity", "s3-access-key-id")
+	cmd.MarkFlagsMutuallyExclusive("s3-system-identity", "s3-secret-access-key")
+	cmd.MarkFlagsMutuallyExclusive("s3-system-identity", "aws-profile")
+	cmd.MarkFlagsMutuallyExclusive("gcs-system-identity", "gcs-key-file")
+	cmd.MarkFlagsMutuallyExclusive("az-client-id", "az-shared-key", "az-managed-identity")
+	cmd.MarkFlagsMutuallyExclusive("az-client-secret", "az-shared-key", "az-managed-identity")
+
+	_ = cmd.RegisterFlagCompletionFunc("s3-flavor", completeChoices(choices(s3Flavors)))
+	_ = cmd.MarkFlagFilename("credential-file", "json")
+	_ = cmd.MarkFlagFilename("gcs-key-file", "json")
+}
+
+// apply sets the storage profile, credential and delete profile of opt
+// from the flags. Flags override the values of opt, read from a file.
+func (f *warehouseFlags) apply(cmd *cobra.Command, sp *profilev1.StorageProfile, sc *credentialv1.StorageCredential, dp **profilev1.DeleteProfile) error {
+	if err := f.applyProfile(cmd.Flags(), sp); err != nil {
+		return err
+	}
+
+	if err := f.applyCredential(cmd, sp, sc); err != nil {
+		return err
+	}
+
+	switch {
+	case f.hardDelete:
+		*dp = profilev1.NewTabularDeleteProfileHard().AsProfile()
+	case cmd.Flags().Changed("soft-delete"):
+		soft, err := softDeleteProfile(time.Duration(f.softDelete))
+		if err != nil {
+			return err
+		}
+		*dp = soft
+	}
+
+	return nil
+}
+
+func (f *warehouseFlags) applyProfile(flags *pflag.FlagSet, sp *profilev1.StorageProfile) error {
+	var families []profilev1.StorageFamily
+	for family, names := range map[profilev1.StorageFamily][]string{
+		profilev1.StorageFamilyS3:   s3ProfileFlags,
+		profilev1.StorageFamilyADLS: adlsProfileFlags,
+		profilev1.StorageFamilyGCS:  gcsProfileFlags,
+	} {
+		if anyChanged(flags, names...) {
+			families = append(families, family)
+		}
+	}
+
+	switch {
+	case len(families) > 1:
+		return fmt.Errorf("flags of several storage families are set: %v", families)
+	case len(families) == 0 && sp.StorageSettings == nil:
+		if flags.Changed("key-prefix") {
+			return errors.New("--key-prefix requires a storage profile")
+		}
+		return nil
+	case len(families) == 0:
+		families = append(families, sp.StorageSettings.GetStorageFamily())
+	case sp.StorageSettings != nil && sp.StorageSettings.GetStorageFamily() != families[0]:
+		return fmt.Errorf("the config file has a %s storage profile, %s flags cannot be used", sp.StorageSettings.GetStorageFamily(), families[0])
+	}
+
+	switch families[0] {
+	case profilev1.StorageFamilyS3:
+		f.applyS3Profile(flags, sp)
+	case profilev1.StorageFamilyADLS:
+		f.applyADLSProfile(flags, sp)
+	case profilev1.StorageFamilyGCS:
+		f.applyGCSProfile(flags, sp)
+	}
+
+	return nil
+}
+
+func (f *warehouseFlags) applyS3Profile(flags *pflag.FlagSet, sp *profilev1.StorageProfile) {
+	var opts []profilev1.S3StorageSettingsOptions
+
+	if flags.Changed("s3-endpoint") {
+		opts = append(opts, profilev1.WithEndpoint(f.s3Endpoint))
+	}
+	if flags.Changed("s3-flavor") {
+		opts = append(opts, profilev1.WithFlavor(profilev1.S3Flavor(f.s3Flavor)))
+	}
+	if flags.Changed("s3-path-style") {
+		opts = append(opts, func(s *profilev1.S3StorageSettings) { s.PathStyleAccess = &f.s3PathStyle })
+	}
+	if flags.Changed("sts-enabled") {
+		opts = append(opts, func(s *profilev1.S3StorageSettings) { s.STSEnabled = f.stsEnabled })
+	}
+	if flags.Changed("sts-role-arn") {
+		opts = append(opts, profilev1.WithSTSRoleARN(f.stsRoleARN))
+	}
+	if flags.Changed("assume-role-arn") {
+		opts = append(opts, profilev1.WithAssumeRoleARN(f.assumeRoleARN))
+	}
+	if flags.Changed("kms-key-arn") {
+		opts = append(opts, profilev1.WithAWSKMSKeyARN(f.kmsKeyARN))
+	}
+	if flags.Changed("sts-token-validity-seconds") {
+		opts = append(opts, profilev1.WithSTSTokenValiditySeconds(f.stsTokenValidity))
+	}
+	if flags.Changed("key-prefix") {
+		opts = append(opts, profilev1.WithS3KeyPrefix(f.keyPrefix))
+	}
+
+	s3, ok := sp.AsS3()
+	if !ok {
+		*sp = profilev1.NewS3StorageSettings(f.s3Bucket, f.s3Region, opts...).AsProfile()
+		return
+	}
+
+	if flags.Changed("s3-bucket") {
+		s3.Bucket = f.s3Bucket
+	}
+	if flags.Changed("s3-region") {
+		s3.Region = f.s3Region
+	}
+	for _, opt := range opts {
+		opt(s3)
+	}
+}
+
+func (f *warehouseFlags) applyADLSProfile(flags *pflag.FlagSet, sp *profilev1.StorageProfile) {
+	var opts []profilev1.ADLSStorageSettingsOptions
+
+	if flags.Changed("adls-host") {
+		opts = append(opts, profilev1.WithHost(f.adlsHost))
+	}
+	if flags.Changed("adls-authority-host") {
+		opts = append(opts, profilev1.WithAuthorityHost(f.adlsAuthorityHost))
+	}
+	if flags.Changed("sas-token-validity-seconds") {
+		opts = append(opts, profilev1.WithSASTokenValiditySeconds(f.sasTokenValidity))
+	}
+	if flags.Changed("key-prefix") {
+		opts = append(opts, profilev1.WithADLSKeyPrefix(f.keyPrefix))
+	}
+
+	adls, ok := sp.AsADLS()
+	if !ok {
+		*sp = profilev1.NewADLSStorageSettings(f.adlsAccount, f.adlsFilesystem, opts...).AsProfile()
+		return
+	}
+
+	if flags.Changed("adls-account") {
+		adls.AccountName = f.adlsAccount
+	}
+	if flags.Changed("adls-filesystem") {
+		adls.Filesystem = f.adlsFilesystem
+	}
+	for _, opt := range opts {
+		opt(adls)
+	}
+}
+
+func (f *warehouseFlags) applyGCSProfile(flags *pflag.FlagSet, sp *profilev1.StorageProfile) {
+	var opts []profilev1.GCSStorageSettingsOptions
+
+	if flags.Changed("key-prefix") {
+		opts = append(opts, profilev1.WithGCSKeyPrefix(f.keyPrefix))
+	}
+
+	gcs, ok := sp.AsGCS()
+	if !ok {
+		*sp = profilev1.NewGCSStorageSettings(f.gcsBucket, opts...).AsProfile()
+		return
+	}
+
+	if flags.Changed("gcs-bucket") {
+		gcs.Bucket = f.gcsBucket
+	}
+	for _, opt := range opts {
+		opt(gcs)
+	}
+}
+
+// applyCredential sets the credential from --credential-file, then from
+// the credential flags of the storage family. Unset secrets are read
+// from the environment.
+func (f *warehouseFlags) applyCredential(cmd *cobra.Command, sp *profilev1.StorageProfile, sc *credentialv1.StorageCredential) error {
+	flags := cmd.Flags()
+
+	if f.credentialFile != "" {
+		cred, err := loadConfig(cmd, f.credentialFile, f.allowExecSecrets, credentialv1.LoadStorageCredential)
+		if err != nil {
+			return fmt.Errorf("could not read credential file: %w", err)
+		}
+		*sc = *cred
+	}
+
+	if sp.StorageSettings == nil {
+		if anyChanged(flags, mergeChoices(s3CredentialFlags, adlsCredentialFlags, gcsCredentialFlags)...) {
+			return errors.New("credential flags require a storage profile")
+		}
+		return nil
+	}
+
+	family := sp.StorageSettings.GetStorageFamily()
+	for other, names := range map[profilev1.StorageFamily][]string{
+		profilev1.StorageFamilyS3:   s3CredentialFlags,
+		profilev1.StorageFamilyADLS: adlsCredentialFlags,
+		profilev1.StorageFamilyGCS:  gcsCredentialFlags,
+	} {
+		if other != family && anyChanged(flags, names...) {
+			return fmt.Errorf("%s credential flags cannot be used with a %s storage profile", other, family)
+		}
+	}
+
+	switch family {
+	case profilev1.StorageFamilyS3:
+		return f.applyS3Credential(flags, sc)
+	case profilev1.StorageFamilyADLS:
+		return f.applyADLSCredential(flags, sc)
+	case profilev1.StorageFamilyGCS:
+		switch {
+		case f.gcsSystemIdentity:
+			*sc = credentialv1.NewGCSCredentialSystemIdentity().AsCredential()
+		case f.gcsKeyFile != "":
+			key, err := credentialv1.LoadGCSServiceAccountKey(f.gcsKeyFile)
+			if err != nil {
+				return err
+			}
+			*sc = key.AsCredential()
+		}
+	}
+
+	return nil
+}
+
+func (f *warehouseFlags) applyS3Credential(flags *pflag.FlagSet, sc *credentialv1.StorageCredential) error {
+	if f.s3SystemIdentity {
+		*sc = credentialv1.NewS3CredentialSystemIdentity(f.s3ExternalID).AsCredential()
+		return nil
+	}
+
+	explicit := anyChanged(flags, "s3-access-key-id", "s3-secret-access-key", "s3-external-id", "aws-profile")
+
+	key, ok := sc.Settings.(*credentialv1.S3CredentialAccessKey)
+	switch {
+	case flags.Changed("aws-profile"):
+		loaded, err := credentialv1.LoadS3CredentialAccessKey(credentialv1.WithAWSProfile(f.awsProfile))
+		if err != nil {
+			return err
+		}
+		key = loaded
+	case ok:
+	case sc.Settings != nil && !explicit:
+		// keep the credential of the file
+		return nil
+	default:
+		key = credentialv1.NewS3CredentialAccessKey("", "")
+	}
+
+	key.AWSAccessKeyID = flagOr(flags, "s3-access-key-id", key.AWSAccessKeyID)
+	key.AWSSecretAccessKey = flagOr(flags, "s3-secret-access-key", key.AWSSecretAccessKey)
+
+	// fall back on the access key of the environment or of
+	// the default profile, as the AWS CLI does
+	if key.AWSAccessKeyID == "" && key.AWSSecretAccessKey == "" {
+		loaded, err := credentialv1.LoadS3CredentialAccessKey()
+		switch {
+		case err == nil:
+			key.AWSAccessKeyID, key.AWSSecretAccessKey = loaded.AWSAccessKeyID, loaded.AWSSecretAccessKey
+		case !errors.Is(err, credentialv1.ErrCredentialsNotFound):
+			return err
+		}
+	}
+
+	if flags.Changed("s3-external-id") {
+		credentialv1.WithExternalID(f.s3ExternalID)(key)
+	}
+
+	if !explicit && key.AWSAccessKeyID == "" && key.AWSSecretAccessKey == "" {
+		return nil
+	}
+	*sc = key.AsCredential()
+	return nil
+}
+
+func (f *warehouseFlags) applyADLSCredential(flags *pflag.FlagSet, sc *credentialv1.StorageCredential) error {
+	switch {
+	case f.azManagedIdentity:
+		*sc = credentialv1.NewAZCredentialManagedIdentity().AsCredential()
+		return nil
+	case flags.Changed("az-shared-key"):
+		*sc = credentialv1.NewAZCredentialSharedAccessKey(f.azSharedKey).AsCredential()
+		return nil
+	}
+
+	explicit := anyChanged(flags, "az-client-id", "az-client-secret", "az-tenant-id")
+
+	cc, ok := sc.Settings.(*credentialv1.AZCredentialClientCredentials)
+	switch {
+	case ok:
+	case sc.Settings != nil && !explicit:
+		// keep the credential of the file
+		return nil
+	default:
+		cc = credentialv1.NewAZCredentialClientCredentials("", "", "")
+	}
+
+	cc.ClientID = flagOr(flags, "az-client-id", cc.ClientID)
+	cc.ClientSecret = flagOr(flags, "az-client-secret", cc.ClientSecret)
+	cc.TenantID = flagOr(flags, "az-tenant-id", cc.TenantID)
+
+	// fall back on the client credentials of the environment
+	if cc.ClientID == "" && cc.ClientSecret == "" && cc.TenantID == "" {
+		loaded, err := credentialv1.LoadAZCredentialClientCredentials()
+		switch {
+		case err == nil:
+			cc.ClientID, cc.ClientSecret, cc.TenantID = loaded.ClientID, loaded.ClientSecret, loaded.TenantID
+		case !errors.Is(err, credentialv1.ErrCredentialsNotFound):
+			return err
+		}
+	}
+
+	if !explicit && cc.ClientID == "" && cc.ClientSecret == "" && cc.TenantID == "" {
+		return nil
+	}
+	*sc = cc.AsCredential()
+	return nil
+}
+
+// flagOr returns the value of the flag if set, otherwise current.
+func flagOr(flags *pflag.FlagSet, name, current string) string {
+	if flags.Changed(name) {
+		v, _ := flags.GetString(name)
+		return v
+	}
+	return current
+}
+
+func anyChanged(flags *pflag.FlagSet, names ...string) bool {
+	for _, n := range names {
+		if flags.Changed(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// softDeleteProfile returns a soft delete profile expiring after d.
+func softDeleteProfile(d time.Duration) (*profilev1.DeleteProfile, error) {
+	seconds := d / time.Second
+	if seconds <= 0 || seconds > math.MaxInt32 {
+		return nil, fmt.Errorf("invalid soft-delete expiration %s", d)
+	}
+	return profilev1.NewTabularDeleteProfileSoft(int32(seconds)).AsProfile(), nil
+}
+
+// durationValue is a time.Duration flag also accepting a number
+// of days, e.g. 7d.
+type durationValue time.Duration
+
+func (d *durationValue) Set(s string) error {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseUint(days, 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", s)
+		}
+		*d = durationValue(time.Duration(n) * 24 * time.Hour)
+		return nil
+	}
+
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = durationValue(v)
+	return nil
+}
+
+func (d *durationValue) String() string {
+	return time.Duration(*d).String()
+}
+
+func (*durationValue) Type() string {
+	return "duration"
+}
@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/baptistegh/go-lakekeeper/cmd/lkctl/errors"
+	credentialv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/credential"
+	profilev1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/profile"
+	"github.com/baptistegh/go-lakekeeper/pkg/rotate"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// storageFamilies are the values of the --family flag.
+var storageFamilies = choices([]profilev1.StorageFamily{
+	profilev1.StorageFamilyS3,
+	profilev1.StorageFamilyADLS,
+	profilev1.StorageFamilyGCS,
+})
+
+func NewWarehouseRotateCredentialCmd(clientOpts *clientOptions, project *string) *cobra.Command {
+	var (
+		source        credentialSource
+		previousFile  string
+		allProjects   bool
+		selector      rotate.Selector
+		family        string
+		stopOnFailure bool
+		yes           bool
+		dryRun        bool
+
+		output string
+	)
+
+	command := cobra.Command{
+		Use:   "rotate-credential (-f JSONCREDENTIALFILE | --aws-profile PROFILE | --gcs-key-file KEYFILE)",
+		Short: "Replace the storage credential of many warehouses",
+		Long: `Replace the storage credential of many warehouses, e.g. after a key leaked.
+
+The active and inactive warehouses of the project accepting the new
+credential are selected, or of all the projects with --all-projects, and
+can be narrowed by storage
+family, bucket, endpoint or name. They are listed, and a confirmation is
+asked before updating them.
+
+Warehouses are updated one at a time. Each active warehouse is verified
+after its update by loading its catalog config and listing its namespaces.
+The API never returns the stored credentials: when a verification fails,
+the warehouse is rolled back only if the previous credential is given with
+--previous-file.
+
+A summary of every warehouse is printed, and lkctl exits with an error if
+any of them is not using the new credential.`,
+		Example: `  # Preview the warehouses using a MinIO endpoint
+  lkctl warehouse rotate-credential --all-projects --endpoint http://minio:9000 --aws-profile minio --dry-run
+
+  # Rotate the key of a bucket, rolling back on failure
+  lkctl warehouse rotate-credential --bucket lake -f new-key.json --previous-file old-key.json
+
+  # Stop at the first failure, without confirmation
+  lkctl warehouse rotate-credential --aws-profile lakekeeper --stop-on-failure --yes`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if len(args) != 0 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			if output != "text" && output != "json" {
+				log.Fatalf("unknown output format %s\n", output)
+			}
+
+			if family != "" {
+				errors.Check(validateChoices("family", []string{family}, storageFamilies))
+			}
+			selector.Family = profilev1.StorageFamily(family)
+
+			if !allProjects {
+				selector.ProjectIDs = []string{*project}
+			}
+
+			opts := rotate.Options{
+				Selector:      selector,
+				Credential:    source.load(cmd),
+				StopOnFailure: stopOnFailure,
+				DryRun:        true,
+			}
+
+			if previousFile != "" {
+				previous, err := loadConfig(cmd, previousFile, source.allowExec, credentialv1.LoadStorageCredential)
+				checkValid("previous storage credential", err)
+				checkValid("previous storage credential", previous.Validate())
+				opts.Previous = previous
+			}
+
+			c := MustCreateClient(ctx, clientOpts)
+
+			plan, err := rotate.Rotate(ctx, c, &opts)
+			errors.Check(err)
+
+			if len(plan.Results) == 0 || dryRun {
+				printRotateReport(plan, output)
+				if output == "text" && dryRun && len(plan.Results) > 0 {
+					fmt.Println("Dry run, no changes applied")
+				}
+				return
+			}
+
+			if output == "text" {
+				fmt.Print(plan.String())
+			}
+
+			if !yes && !confirm(fmt.Sprintf("Rotate the storage credential of %d warehouses?", len(plan.Results))) {
+				log.Fatal("aborted, no changes applied")
+			}
+
+			// Rotate exactly the confirmed warehouses.
+			opts.Selector.Warehouses = make([]string, 0, len(plan.Results))
+			for _, r := range plan.Results {
+				opts.Selector.Warehouses = append(opts.Selector.Warehouses, r.WarehouseID)
+			}
+			opts.DryRun = false
+
+			report, err := rotate.Rotate(ctx, c, &opts)
+			errors.Check(err)
+
+			printRotateReport(report, output)
+
+			if report.Failed() {
+				os.Exit(1)
+			}
+		},
+	}
+
+	source.register(&command)
+	command.Flags().StringVar(&previousFile, "previous-file", "", "Previous storage credential file, restored on the warehouses failing verification")
+	command.Flags().BoolVar(&allProjects, "all-projects", false, "Select the warehouses of all the projects")
+	command.Flags().StringSliceVar(&selector.Warehouses, "warehouses", []string{}, "Only rotate these warehouses, by ID or name; can be repeated multiple times or comma separated")
+	command.Flags().StringVar(&family, "family", "", "Only rotate the warehouses of this storage family. One of: s3|adls|gcs")
+	command.Flags().StringVar(&selector.Bucket, "bucket", "", "Only rotate the warehouses of this S3 or GCS bucket, or ADLS filesystem")
+	command.Flags().StringVar(&selector.Endpoint, "endpoint", "", "Only rotate the warehouses of this S3 endpoint or ADLS host")
+	command.Flags().BoolVar(&stopOnFailure, "stop-on-failure", false, "Skip the remaining warehouses after a failure")
+	command.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Print the selected warehouses without rotating their credential")
+	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: json|text")
+
+	_ = command.MarkFlagFilename("previous-file", "json")
+	_ = command.RegisterFlagCompletionFunc("warehouses", completeWarehouses(clientOpts))
+	_ = command.RegisterFlagCompletionFunc("family", completeChoices(storageFamilies))
+
+	return &command
+}
+
+func printRotateReport(r *rotate.Report, output string) {
+	switch output {
+	case "text":
+		fmt.Print(r.String())
+	case "json":
+		errors.Check(PrintResource(r, output))
+	}
+}
@@ -0,0 +1,238 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/baptistegh/go-lakekeeper/cmd/lkctl/errors"
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	credentialv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/credential"
+	profilev1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/profile"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewWarehouseSetStorageCmd(clientOpts *clientOptions, project *string) *cobra.Command {
+	var (
+		config    string
+		allowExec bool
+		diff      bool
+		yes       bool
+	)
+
+	command := cobra.Command{
+		Use:   "set-storage WAREHOUSEID -f JSONCONFIGFILE",
+		Short: "Update the storage profile and credential of a warehouse",
+		Long: `Update the storage profile and credential of a warehouse.
+
+The file contains the storage-profile and, optionally, the storage-credential
+of the warehouse, in the same format as the warehouse create config file,
+including secret references.
+
+With --diff, the changes to the current storage profile are printed and a
+confirmation is asked before applying them. Risky changes, like a bucket or
+key prefix change orphaning the existing tables, are flagged.`,
+		Example: `  # Update the storage profile from file
+  lkctl warehouse set-storage 019861a0-6d4e-7bf3-96c6-9aef2d4a2749 -f storage.json
+
+  # Update the storage profile from stdin
+  cat storage.json | lkctl warehouse set-storage 019861a0-6d4e-7bf3-96c6-9aef2d4a2749 -f -
+
+  # Review the changes before applying them
+  lkctl warehouse set-storage 019861a0-6d4e-7bf3-96c6-9aef2d4a2749 -f storage.json --diff`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if len(args) != 1 || config == "" {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			opt, err := loadConfig(cmd, config, allowExec, managementv1.LoadUpdateStorageProfileOptions)
+			checkValid("storage config", err)
+
+			checkValid("storage config", opt.Validate())
+
+			service := MustCreateClient(ctx, clientOpts).WarehouseV1(*project)
+
+			if diff {
+				current, _, err := service.Get(ctx, args[0])
+				errors.Check(err)
+
+				d, err := profilev1.DiffProfiles(current.StorageProfile, opt.StorageProfile)
+				errors.Check(err)
+
+				fmt.Print(d.String())
+
+				if d.IsEmpty() && opt.StorageCredential == nil {
+					return
+				}
+
+				question := "Update the storage profile?"
+				if d.Risky() {
+					question = "Some changes are risky, update the storage profile anyway?"
+				}
+				if !yes && !confirm(question) {
+					log.Fatal("aborted, no changes applied")
+				}
+			}
+
+			_, err = service.UpdateStorageProfile(ctx, args[0], opt)
+			errors.Check(err)
+
+			fmt.Printf("Storage profile of warehouse %s updated\n", args[0])
+		},
+	}
+
+	command.Flags().StringVarP(&config, "file", "f", "", "Storage config file. JSON file or '-' for stdin")
+	addAllowExecSecretsFlag(&command, &allowExec)
+	command.Flags().BoolVar(&diff, "diff", false, "Print the changes to the storage profile and ask for confirmation before applying them")
+	command.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation")
+
+	command.ValidArgsFunction = completeArgs(1, completeWarehouses(clientOpts))
+
+	return &command
+}
+
+func NewWarehouseSetCredentialCmd(clientOpts *clientOptions, project *string) *cobra.Command {
+	var source credentialSource
+
+	command := cobra.Command{
+		Use:   "set-credential WAREHOUSEID (-f JSONCREDENTIALFILE | --aws-profile PROFILE | --gcs-key-file KEYFILE)",
+		Short: "Update the storage credential of a warehouse",
+		Long: `Update the storage credential of a warehouse, without modifying its
+storage profile. Useful to refresh expiring credentials.
+
+The file contains the storage credential, in the same format as the
+storage-credential of the warehouse create config file, including secret
+references. The credential can
+also be an S3 access key read from a profile of the AWS shared files, or a
+GCS service account key file.`,
+		Example: `  # Update the storage credential from file
+  lkctl warehouse set-credential 019861a0-6d4e-7bf3-96c6-9aef2d4a2749 -f credential.json
+
+  # Update the S3 access key from the AWS shared credentials file
+  lkctl warehouse set-credential 019861a0-6d4e-7bf3-96c6-9aef2d4a2749 --aws-profile lakekeeper`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if len(args) != 1 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			cred := source.load(cmd)
+
+			opt := managementv1.UpdateStorageCredentialOptions{
+				StorageCredential: &cred,
+			}
+
+			_, err := MustCreateClient(ctx, clientOpts).WarehouseV1(*project).UpdateStorageCredential(ctx, args[0], &opt)
+			errors.Check(err)
+
+			fmt.Printf("Storage credential of warehouse %s updated\n", args[0])
+		},
+	}
+
+	source.register(&command)
+
+	command.ValidArgsFunction = completeArgs(1, completeWarehouses(clientOpts))
+
+	return &command
+}
+
+// credentialSource holds the flags selecting where a storage credential
+// is read from: a config file, an AWS profile or a GCS key file.
+type credentialSource struct {
+	file       string
+	allowExec  bool
+	awsProfile string
+	gcsKeyFile string
+}
+
+func (s *credentialSource) register(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&s.file, "file", "f", "", "Storage credential file. JSON file or '-' for stdin")
+	addAllowExecSecretsFlag(cmd, &s.allowExec)
+	cmd.Flags().StringVar(&s.awsProfile, "aws-profile", "", "Read the S3 access key from this profile of the AWS shared credentials and config files")
+	cmd.Flags().StringVar(&s.gcsKeyFile, "gcs-key-file", "", "GCP service account JSON key file")
+
+	cmd.MarkFlagsOneRequired("file", "aws-profile", "gcs-key-file")
+	cmd.MarkFlagsMutuallyExclusive("file", "aws-profile", "gcs-key-file")
+	_ = cmd.MarkFlagFilename("gcs-key-file", "json")
+}
+
+// load reads and validates the credential, and exits on errors.
+func (s *credentialSource) load(cmd *cobra.Command) credentialv1.StorageCredential {
+	var cred credentialv1.StorageCredential
+
+	switch {
+	case s.file != "":
+		loaded, err := loadConfig(cmd, s.file, s.allowExec, credentialv1.LoadStorageCredential)
+		checkValid("storage credential", err)
+		cred = *loaded
+	case s.awsProfile != "":
+		key, err := credentialv1.LoadS3CredentialAccessKey(credentialv1.WithAWSProfile(s.awsProfile))
+		errors.Check(err)
+		cred = key.AsCredential()
+	default:
+		key, err := credentialv1.LoadGCSServiceAccountKey(s.gcsKeyFile)
+		errors.Check(err)
+		cred = key.AsCredential()
+	}
+
+	checkValid("storage credential", cred.Validate())
+
+	return cred
+}
+
+func NewWarehouseSetDeleteProfileCmd(clientOpts *clientOptions, project *string) *cobra.Command {
+	var (
+		hard       bool
+		expiration durationValue
+	)
+
+	command := cobra.Command{
+		Use:   "set-delete-profile WAREHOUSEID",
+		Short: "Configure the soft-delete behavior of a warehouse",
+		Example: `  # Keep dropped tables and views for a week
+  lkctl warehouse set-delete-profile 019861a0-6d4e-7bf3-96c6-9aef2d4a2749 --soft 7d
+
+  # Delete dropped tables and views immediately
+  lkctl warehouse set-delete-profile 019861a0-6d4e-7bf3-96c6-9aef2d4a2749 --hard`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if len(args) != 1 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			dp := profilev1.NewTabularDeleteProfileHard().AsProfile()
+			if !hard {
+				var err error
+				dp, err = softDeleteProfile(time.Duration(expiration))
+				errors.Check(err)
+			}
+
+			opt := managementv1.UpdateDeleteProfileOptions{
+				DeleteProfile: *dp,
+			}
+
+			_, err := MustCreateClient(ctx, clientOpts).WarehouseV1(*project).UpdateDeleteProfile(ctx, args[0], &opt)
+			errors.Check(err)
+
+			fmt.Printf("Delete profile of warehouse %s updated\n", args[0])
+		},
+	}
+
+	command.Flags().BoolVar(&hard, "hard", false, "Delete dropped tables and views immediately")
+	command.Flags().Var(&expiration, "soft", "Keep dropped tables and views for this duration before deleting them, e.g. 7d or 24h")
+
+	command.MarkFlagsOneRequired("hard", "soft")
+	command.MarkFlagsMutuallyExclusive("hard", "soft")
+
+	command.ValidArgsFunction = completeArgs(1, completeWarehouses(clientOpts))
+
+	return &command
+}
@@ -6,9 +6,13 @@ package integration
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"math/rand"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
 	credentialv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/credential"
@@ -16,6 +20,7 @@ import (
 
 	"github.com/baptistegh/go-lakekeeper/pkg/client"
 	"github.com/baptistegh/go-lakekeeper/pkg/core"
+	"github.com/baptistegh/go-lakekeeper/pkg/recorder"
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"golang.org/x/oauth2/clientcredentials"
@@ -25,10 +30,59 @@ var (
 	adminID = "oidc~6deeb417-cdf9-4320-8a30-ddecea77a4bd"
 
 	defaultProjectID = new(uuid.UUID).String()
+
+	randomsMu sync.Mutex
+	randoms   = map[string]*rand.Rand{}
 )
 
+// Setup creates a client for the integration tests.
+//
+// LAKEKEEPER_RECORDER_MODE controls how requests are handled:
+//   - "" or "passthrough": requests are sent to the running Lakekeeper
+//   - "record": requests are sent to Lakekeeper and recorded in testdata/cassettes
+//   - "replay": requests are served from testdata/cassettes, no server is needed
+//
+// Cassettes are recorded locally and not committed, see CONTRIBUTING.md.
+//
+// Iceberg catalog requests do not go through the recorder and always
+// need a running server.
 func Setup(t *testing.T) *client.Client {
-	err := godotenv.Load("../.env")
+	mode, err := recorder.ParseMode(os.Getenv("LAKEKEEPER_RECORDER_MODE"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Requests are matched on their method, URL and body, the names
+	// and identifiers sent by the tests are seeded, see random.
+	cassette := filepath.Join("testdata", "cassettes", t.Name()+".json")
+	rec, err := recorder.New(cassette, mode)
+	if err != nil {
+		if mode == recorder.ModeReplay {
+			t.Fatalf("could not replay %s, record it first with LAKEKEEPER_RECORDER_MODE=record, %v", cassette, err)
+		}
+		t.Fatalf("could not create recorder, %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := rec.Stop(); err != nil {
+			t.Errorf("could not save cassette, %v", err)
+		}
+	})
+
+	opts := []client.ClientOptionFunc{
+		client.WithHTTPTransport(rec),
+		client.WithInitialBootstrapV1Enabled(true, true, core.Ptr(managementv1.ApplicationUserType)),
+	}
+
+	if mode == recorder.ModeReplay {
+		c, err := client.NewClient(t.Context(), "replay", "http://localhost:8181", opts...)
+		if err != nil {
+			t.Fatalf("could not create client, %v", err)
+		}
+		return c
+	}
+
+	err = godotenv.Load("../.env")
 	if err != nil {
 		t.Fatalf("Error loading .env file, %v", err)
 	}
@@ -44,7 +98,7 @@ func Setup(t *testing.T) *client.Client {
 		TokenSource: oauth.TokenSource(context.Background()),
 	}
 
-	c, err := client.NewAuthSourceClient(t.Context(), &as, os.Getenv("LAKEKEEPER_BASE_URL"), client.WithInitialBootstrapV1Enabled(true, true, core.Ptr(managementv1.ApplicationUserType)))
+	c, err := client.NewAuthSourceClient(t.Context(), &as, os.Getenv("LAKEKEEPER_BASE_URL"), opts...)
 	if err != nil {
 		t.Fatalf("could not create client, %v", err)
 	}
@@ -52,9 +106,42 @@ func Setup(t *testing.T) *client.Client {
 	return c
 }
 
+// random returns the source of the random names and identifiers of a test.
+//
+// When recording or replaying, it is seeded with the test name, so that
+// the requests of a replay are the same as the recorded ones.
+func random(t *testing.T) *rand.Rand {
+	randomsMu.Lock()
+	defer randomsMu.Unlock()
+
+	if r, ok := randoms[t.Name()]; ok {
+		return r
+	}
+
+	seed := time.Now().UnixNano()
+	if mode, _ := recorder.ParseMode(os.Getenv("LAKEKEEPER_RECORDER_MODE")); mode != recorder.ModePassthrough {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(t.Name()))
+		seed = int64(h.Sum64())
+	}
+
+	r := rand.New(rand.NewSource(seed))
+	randoms[t.Name()] = r
+	t.Cleanup(func() {
+		randomsMu.Lock()
+		delete(randoms, t.Name())
+		randomsMu.Unlock()
+	})
+
+	return r
+}
+
 func MustProvisionUser(t *testing.T, c *client.Client) *managementv1.User {
-	id := uuid.New()
-	rNb := rand.Int()
+	id, err := uuid.NewRandomFromReader(random(t))
+	if err != nil {
+		t.Fatalf("could not generate user id, %v", err)
+	}
+	rNb := random(t).Int()
 
 	u, _, err := c.UserV1().Provision(t.Context(), &managementv1.ProvisionUserOptions{
 		ID:             core.Ptr(fmt.Sprintf("oidc~%s", id.String())),
@@ -78,7 +165,7 @@ func MustProvisionUser(t *testing.T, c *client.Client) *managementv1.User {
 }
 
 func MustCreateRole(t *testing.T, c *client.Client, projectID string) *managementv1.Role {
-	rNb := rand.Int()
+	rNb := random(t).Int()
 
 	r, _, err := c.RoleV1(projectID).Create(t.Context(), &managementv1.CreateRoleOptions{
 		Name: fmt.Sprintf("test-role-%d", rNb),
@@ -99,7 +186,7 @@ func MustCreateRole(t *testing.T, c *client.Client, projectID string) *managemen
 
 func MustCreateProject(t *testing.T, c *client.Client) string {
 	p, _, err := c.ProjectV1().Create(t.Context(), &managementv1.CreateProjectOptions{
-		Name: fmt.Sprintf("test-project-%d", rand.Int()),
+		Name: fmt.Sprintf("test-project-%d", random(t).Int()),
 	})
 	if err != nil {
 		t.Fatalf("could not create user, %v", err)
@@ -116,7 +203,7 @@ func MustCreateProject(t *testing.T, c *client.Client) string {
 }
 
 func MustCreateWarehouse(t *testing.T, c *client.Client, projectID string) (string, string) {
-	rNb := rand.Int()
+	rNb := random(t).Int()
 	name := fmt.Sprintf("test-role-%d", rNb)
 
 	w, _, err := c.WarehouseV1(projectID).Create(t.Context(), &managementv1.CreateWarehouseOptions{
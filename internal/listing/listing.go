@@ -0,0 +1,94 @@
+// Package listing lists resources of the management API across pages.
+package listing
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/client"
+
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+)
+
+// Projects returns the projects with the given ids, or all the
+// projects visible to the caller if ids is empty.
+func Projects(ctx context.Context, c client.Interface, ids []string) ([]*managementv1.Project, error) {
+	resp, _, err := c.ProjectV1().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return resp.Projects, nil
+	}
+
+	projects := make([]*managementv1.Project, 0, len(ids))
+	for _, id := range ids {
+		i := slices.IndexFunc(resp.Projects, func(p *managementv1.Project) bool { return p.ID == id })
+		if i < 0 {
+			return nil, fmt.Errorf("project %s not found", id)
+		}
+		projects = append(projects, resp.Projects[i])
+	}
+
+	return projects, nil
+}
+
+// Users returns all the users.
+func Users(ctx context.Context, c client.Interface) ([]*managementv1.User, error) {
+	var (
+		users []*managementv1.User
+		opt   managementv1.ListUsersOptions
+	)
+
+	for {
+		resp, _, err := c.UserV1().List(ctx, &opt)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, resp.Users...)
+
+		if resp.NextPageToken == nil || *resp.NextPageToken == "" || len(resp.Users) == 0 {
+			return users, nil
+		}
+		opt.PageToken = resp.NextPageToken
+	}
+}
+
+// Roles returns all the roles of a project.
+func Roles(ctx context.Context, c client.Interface, project string) ([]*managementv1.Role, error) {
+	var (
+		roles []*managementv1.Role
+		opt   managementv1.ListRolesOptions
+	)
+
+	for {
+		resp, _, err := c.RoleV1(project).List(ctx, &opt)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, resp.Roles...)
+
+		if resp.NextPageToken == nil || *resp.NextPageToken == "" || len(resp.Roles) == 0 {
+			return roles, nil
+		}
+		opt.PageToken = resp.NextPageToken
+	}
+}
+
+// Warehouses returns the active and inactive warehouses of a project.
+func Warehouses(ctx context.Context, c client.Interface, project string) ([]*managementv1.Warehouse, error) {
+	resp, _, err := c.WarehouseV1(project).List(ctx, &managementv1.ListWarehouseOptions{
+		WarehouseStatus: []managementv1.WarehouseStatus{
+			managementv1.WarehouseStatusActive,
+			managementv1.WarehouseStatusInactive,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Warehouses, nil
+}
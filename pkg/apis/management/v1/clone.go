@@ -0,0 +1,159 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+	"github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/credential"
+	"github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/profile"
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+)
+
+// CloneWarehouseOptions represents the CloneWarehouse() options.
+type CloneWarehouseOptions struct {
+	// Name of the new warehouse.
+	Name string
+	// KeyPrefix of the new warehouse. It must be neither the key prefix
+	// of the source nor one of its parents, for the warehouses not to
+	// share tables.
+	KeyPrefix string
+	// StorageCredential of the new warehouse. It is required, the API
+	// never returns the credential of the source warehouse.
+	StorageCredential credential.StorageCredential
+	// DeleteProfile overrides the delete profile of the source.
+	DeleteProfile *profile.DeleteProfile
+	// CopyProtection protects the new warehouse if the source is protected.
+	CopyProtection bool
+	// CopyAssignments copies the permission assignments of the source.
+	CopyAssignments bool
+}
+
+// CloneOptions derives the options creating a copy of w: a copy of its
+// storage profile with the key prefix of opt, its delete profile unless
+// opt overrides it, and the credential of opt.
+func (w *Warehouse) CloneOptions(opt *CloneWarehouseOptions) (*CreateWarehouseOptions, error) {
+	if opt.Name == "" {
+		return nil, errors.New("the name of the new warehouse must be provided")
+	}
+	if opt.StorageCredential.Settings == nil {
+		return nil, errors.New("a storage credential must be provided, the credential of the source warehouse is never returned by the API")
+	}
+
+	sp, err := cloneStorageProfile(w.StorageProfile, opt.KeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	dp := opt.DeleteProfile
+	if dp == nil {
+		dp = w.DeleteProfile
+	}
+
+	return &CreateWarehouseOptions{
+		Name:              opt.Name,
+		StorageProfile:    sp,
+		StorageCredential: opt.StorageCredential,
+		DeleteProfile:     dp,
+	}, nil
+}
+
+// CloneWarehouse creates a new warehouse with the storage profile and the
+// delete profile of src, and the key prefix and credential of opt. See
+// Warehouse.CloneOptions.
+//
+// The warehouse is created by s, in its project. The protection status and
+// the permission assignments of src are copied if requested, the latter
+// with perms. If copying them fails, the new warehouse is kept and returned
+// along with the error.
+func CloneWarehouse(ctx context.Context, s WarehouseServiceInterface, perms permission.WarehousePermissionServiceInterface, src *Warehouse, opt *CloneWarehouseOptions, options ...core.RequestOptionFunc) (*CreateWarehouseResponse, *http.Response, error) {
+	if opt == nil {
+		opt = &CloneWarehouseOptions{}
+	}
+	if opt.CopyAssignments && perms == nil {
+		return nil, nil, errors.New("a warehouse permission service must be provided to copy the assignments")
+	}
+
+	createOpt, err := src.CloneOptions(opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created, resp, err := s.Create(ctx, createOpt, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if opt.CopyProtection && src.Protected {
+		if _, resp, err := s.SetWarehouseProtection(ctx, created.ID, &SetProtectionOptions{Protected: true}, options...); err != nil {
+			return created, resp, fmt.Errorf("warehouse %s created, but could not be protected, %w", created.ID, err)
+		}
+	}
+
+	if opt.CopyAssignments {
+		if _, err := permission.CopyWarehouseAssignments(ctx, perms, src.ID, created.ID, nil); err != nil {
+			return created, resp, fmt.Errorf("warehouse %s created, but the assignments of %s could not be copied, %w", created.ID, src.ID, err)
+		}
+	}
+
+	return created, resp, nil
+}
+
+// cloneStorageProfile returns a deep copy of sp, using keyPrefix.
+func cloneStorageProfile(sp profile.StorageProfile, keyPrefix string) (profile.StorageProfile, error) {
+	var clone profile.StorageProfile
+
+	data, err := json.Marshal(sp)
+	if err != nil {
+		return clone, err
+	}
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return clone, err
+	}
+
+	var current **string
+	switch settings := clone.StorageSettings.(type) {
+	case *profile.S3StorageSettings:
+		current = &settings.KeyPrefix
+	case *profile.ADLSStorageSettings:
+		current = &settings.KeyPrefix
+	case *profile.GCSStorageSettings:
+		current = &settings.KeyPrefix
+	case nil:
+		return clone, errors.New("the source warehouse has no storage profile")
+	default:
+		return clone, fmt.Errorf("%s storage profiles cannot be cloned", sp.StorageSettings.GetStorageFamily())
+	}
+
+	if err := checkClonedKeyPrefix(*current, keyPrefix); err != nil {
+		return clone, err
+	}
+	*current = &keyPrefix
+
+	return clone, nil
+}
+
+// checkClonedKeyPrefix returns an error if keyPrefix is the key prefix
+// of the source warehouse, or one of its parents.
+func checkClonedKeyPrefix(source *string, keyPrefix string) error {
+	n := profile.NormalizeKeyPrefix(keyPrefix)
+	if source == nil || profile.NormalizeKeyPrefix(*source) == "" {
+		if n == "" {
+			return errors.New("the key prefix must be provided, the source warehouse has none")
+		}
+		return nil
+	}
+
+	s := profile.NormalizeKeyPrefix(*source)
+	switch {
+	case n == s:
+		return fmt.Errorf("the key prefix must differ from the key prefix %q of the source warehouse", *source)
+	case n == "" || strings.HasPrefix(s, n+"/"):
+		return fmt.Errorf("the key prefix must not contain the key prefix %q of the source warehouse", *source)
+	}
+	return nil
+}
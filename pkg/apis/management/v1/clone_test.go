@@ -0,0 +1,145 @@
+package v1_test
+
+import (
+	"net/http"
+	"testing"
+
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	"github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/credential"
+	"github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/profile"
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+	"github.com/baptistegh/go-lakekeeper/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneWarehouse(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	const (
+		projectID = "01f2fdfc-81fc-444d-8368-5b6701566e35"
+		targetID  = "0198618c-5be8-7a82-a0b9-1076c9dd12f0"
+		srcID     = "a4b2c1d0-e3f4-5a6b-7c8d-9e0f1a2b3c4d"
+		newID     = "b5c3d2e1-f4a5-6b7c-8d9e-0f1a2b3c4d5e"
+	)
+
+	src := &managementv1.Warehouse{
+		ID:             srcID,
+		Name:           "team-a",
+		ProjectID:      projectID,
+		Status:         managementv1.WarehouseStatusActive,
+		Protected:      true,
+		StorageProfile: profile.NewGCSStorageSettings("lake", profile.WithGCSKeyPrefix("team-a")).AsProfile(),
+		DeleteProfile:  profile.NewTabularDeleteProfileSoft(604800).AsProfile(),
+	}
+
+	mux.HandleFunc("POST /management/v1/warehouse", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestHeader(t, r, "x-project-id", targetID)
+		testutil.TestBodyJSON(t, r, map[string]any{
+			"warehouse-name":     "team-b",
+			"project-id":         targetID,
+			"storage-profile":    map[string]any{"type": "gcs", "bucket": "lake", "key-prefix": "team-b"},
+			"storage-credential": map[string]any{"type": "gcs", "credential-type": "gcp-system-identity"},
+			"delete-profile":     map[string]any{"type": "soft", "expiration-seconds": float64(604800)},
+		})
+		testutil.MustWriteJSONResponse(t, w, map[string]string{"warehouse-id": newID})
+	})
+	mux.HandleFunc("POST /management/v1/warehouse/"+newID+"/protection", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestBodyJSON(t, r, map[string]bool{"protected": true})
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"protected": true})
+	})
+	mux.HandleFunc("GET /management/v1/permissions/warehouse/"+srcID+"/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+			map[string]string{"type": "select", "role": "analysts"},
+			map[string]string{"type": "ownership", "user": "oidc~alice"},
+		}})
+	})
+	mux.HandleFunc("GET /management/v1/permissions/warehouse/"+newID+"/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+			map[string]string{"type": "ownership", "user": "oidc~alice"},
+		}})
+	})
+	mux.HandleFunc("POST /management/v1/permissions/warehouse/"+newID+"/assignments", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestBodyJSON(t, r, map[string][]map[string]string{
+			"writes": {{"type": "select", "role": "analysts"}},
+		})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, _, err := managementv1.CloneWarehouse(t.Context(), client.WarehouseV1(targetID), client.PermissionV1().WarehousePermission(), src, &managementv1.CloneWarehouseOptions{
+		Name:              "team-b",
+		KeyPrefix:         "team-b",
+		StorageCredential: credential.NewGCSCredentialSystemIdentity().AsCredential(),
+		CopyProtection:    true,
+		CopyAssignments:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, newID, resp.ID)
+
+	_, _, err = managementv1.CloneWarehouse(t.Context(), client.WarehouseV1(targetID), nil, src, &managementv1.CloneWarehouseOptions{
+		Name:              "team-b",
+		KeyPrefix:         "team-b",
+		StorageCredential: credential.NewGCSCredentialSystemIdentity().AsCredential(),
+		CopyAssignments:   true,
+	})
+	require.EqualError(t, err, "a warehouse permission service must be provided to copy the assignments")
+}
+
+func TestWarehouse_CloneOptions(t *testing.T) {
+	t.Parallel()
+
+	src := &managementv1.Warehouse{
+		Name:           "team-a",
+		StorageProfile: profile.NewS3StorageSettings("lake", "eu-west-1", profile.WithS3KeyPrefix("lakes/team-a")).AsProfile(),
+	}
+	cred := credential.NewS3CredentialAccessKey("AKIA", "secret").AsCredential()
+
+	opt, err := src.CloneOptions(&managementv1.CloneWarehouseOptions{Name: "team-b", KeyPrefix: "lakes/team-b", StorageCredential: cred})
+	require.NoError(t, err)
+
+	sp, ok := opt.StorageProfile.AsS3()
+	require.True(t, ok)
+	assert.Equal(t, "lakes/team-b", *sp.KeyPrefix)
+
+	// The source is not modified.
+	srcProfile, _ := src.StorageProfile.AsS3()
+	assert.Equal(t, "lakes/team-a", *srcProfile.KeyPrefix)
+
+	_, err = src.CloneOptions(&managementv1.CloneWarehouseOptions{Name: "team-b", KeyPrefix: "lakes/team-b", StorageCredential: credential.StorageCredential{}})
+	require.EqualError(t, err, "a storage credential must be provided, the credential of the source warehouse is never returned by the API")
+
+	tests := []struct {
+		name      string
+		source    *string
+		keyPrefix string
+		err       string
+	}{
+		{name: "same", source: core.Ptr("lakes/team-a"), keyPrefix: "/lakes/team-a/", err: `the key prefix must differ from the key prefix "lakes/team-a" of the source warehouse`},
+		{name: "parent", source: core.Ptr("lakes/team-a"), keyPrefix: "lakes", err: `the key prefix must not contain the key prefix "lakes/team-a" of the source warehouse`},
+		{name: "empty", source: core.Ptr("lakes/team-a"), keyPrefix: "/", err: `the key prefix must not contain the key prefix "lakes/team-a" of the source warehouse`},
+		{name: "sibling with common start", source: core.Ptr("lakes/team-a"), keyPrefix: "lakes/team"},
+		{name: "child", source: core.Ptr("lakes/team-a"), keyPrefix: "lakes/team-a/archive"},
+		{name: "no source prefix", keyPrefix: "team-b"},
+		{name: "none", keyPrefix: "", err: "the key prefix must be provided, the source warehouse has none"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			src := &managementv1.Warehouse{
+				Name:           "team-a",
+				StorageProfile: profile.NewS3StorageSettings("lake", "eu-west-1").AsProfile(),
+			}
+			s3, _ := src.StorageProfile.AsS3()
+			s3.KeyPrefix = tt.source
+
+			_, err := src.CloneOptions(&managementv1.CloneWarehouseOptions{Name: "team-b", KeyPrefix: tt.keyPrefix, StorageCredential: cred})
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -1,7 +1,42 @@
 package permission
 
+import (
+	"cmp"
+	"slices"
+)
+
 type Assignment interface {
 	GetPrincipalType() UserOrRoleType
 	GetPrincipalID() string
 	GetAssignment() string
 }
+
+// Scope is a kind of resource permissions are assigned on. The server,
+// project and warehouse scopes are also the levels of the hierarchy
+// walked by Explain.
+type Scope string
+
+const (
+	ServerScope    Scope = "server"
+	ProjectScope   Scope = "project"
+	RoleScope      Scope = "role"
+	WarehouseScope Scope = "warehouse"
+)
+
+// CompareAssignments orders assignments by principal type,
+// principal ID, then assignment type.
+func CompareAssignments(a, b Assignment) int {
+	return cmp.Or(
+		cmp.Compare(a.GetPrincipalType(), b.GetPrincipalType()),
+		cmp.Compare(a.GetPrincipalID(), b.GetPrincipalID()),
+		cmp.Compare(a.GetAssignment(), b.GetAssignment()),
+	)
+}
+
+// SortAssignments sorts assignments in place with CompareAssignments,
+// giving them a stable order whatever the order returned by the server.
+func SortAssignments[T Assignment](assignments []T) {
+	slices.SortStableFunc(assignments, func(a, b T) int {
+		return CompareAssignments(a, b)
+	})
+}
@@ -0,0 +1,27 @@
+package permission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortAssignments(t *testing.T) {
+	t.Parallel()
+
+	assignments := []*WarehouseAssignment{
+		{Assignee: UserOrRole{UserType, "b"}, Assignment: SelectWarehouseAssignment},
+		{Assignee: UserOrRole{UserType, "a"}, Assignment: SelectWarehouseAssignment},
+		{Assignee: UserOrRole{RoleType, "c"}, Assignment: ModifyWarehouseAssignment},
+		{Assignee: UserOrRole{UserType, "a"}, Assignment: CreateWarehouseAssignment},
+	}
+
+	SortAssignments(assignments)
+
+	assert.Equal(t, []*WarehouseAssignment{
+		{Assignee: UserOrRole{RoleType, "c"}, Assignment: ModifyWarehouseAssignment},
+		{Assignee: UserOrRole{UserType, "a"}, Assignment: CreateWarehouseAssignment},
+		{Assignee: UserOrRole{UserType, "a"}, Assignment: SelectWarehouseAssignment},
+		{Assignee: UserOrRole{UserType, "b"}, Assignment: SelectWarehouseAssignment},
+	}, assignments)
+}
@@ -0,0 +1,86 @@
+package permission
+
+import (
+	"context"
+	"slices"
+)
+
+// CopyOptions represents the Copy*Assignments() options.
+type CopyOptions struct {
+	// Replace deletes the target assignments missing from the source.
+	// By default, they are kept and the source assignments are merged in.
+	Replace bool
+	// Relations limits the copy to these assignment types.
+	// If empty, all assignments are copied.
+	Relations []string
+	// DryRun computes the plan without applying it.
+	DryRun bool
+}
+
+// CopyWarehouseAssignments copies the assignments of the src warehouse
+// to the dst warehouse.
+//
+// Warehouses can belong to different projects: user assignments are
+// always valid, role assignments only if the role exists in the
+// project of dst.
+func CopyWarehouseAssignments(ctx context.Context, s WarehousePermissionServiceInterface, src, dst string, opts *CopyOptions) (*Plan, error) {
+	source, _, err := s.GetAssignments(ctx, src, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	target, _, err := s.GetAssignments(ctx, dst, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	replace, ropts := copyReconcileOptions(opts)
+	return reconcile(WarehouseScope, dst, target.Assignments, filterRelations(source.Assignments, ropts.Relations), ropts, !replace, func(writes, deletes []*WarehouseAssignment) error {
+		_, err := s.Update(ctx, dst, &UpdateWarehousePermissionsOptions{Writes: writes, Deletes: deletes})
+		return err
+	})
+}
+
+// CopyProjectAssignments copies the assignments of the src project
+// to the dst project.
+//
+// User assignments are always valid, role assignments only
+// if the role can be assigned in dst.
+func CopyProjectAssignments(ctx context.Context, s ProjectPermissionServiceInterface, src, dst string, opts *CopyOptions) (*Plan, error) {
+	source, _, err := s.GetAssignments(ctx, src, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	target, _, err := s.GetAssignments(ctx, dst, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	replace, ropts := copyReconcileOptions(opts)
+	return reconcile(ProjectScope, dst, target.Assignments, filterRelations(source.Assignments, ropts.Relations), ropts, !replace, func(writes, deletes []*ProjectAssignment) error {
+		_, err := s.Update(ctx, dst, &UpdateProjectPermissionsOptions{Writes: writes, Deletes: deletes})
+		return err
+	})
+}
+
+func copyReconcileOptions(opts *CopyOptions) (bool, *ReconcileOptions) {
+	if opts == nil {
+		return false, &ReconcileOptions{}
+	}
+	return opts.Replace, &ReconcileOptions{
+		Relations: opts.Relations,
+		DryRun:    opts.DryRun,
+	}
+}
+
+// filterRelations returns the assignments of the given relations,
+// or all of them if relations is empty.
+func filterRelations[T Assignment](assignments []T, relations []string) []T {
+	if len(relations) == 0 {
+		return assignments
+	}
+	return slices.DeleteFunc(slices.Clone(assignments), func(a T) bool {
+		return !slices.Contains(relations, a.GetAssignment())
+	})
+}
@@ -0,0 +1,109 @@
+package permission_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+)
+
+func TestCopyWarehouseAssignments(t *testing.T) {
+	t.Parallel()
+
+	const (
+		src = "a4b2c1d0-0000-4000-8000-000000000001"
+		dst = "a4b2c1d0-0000-4000-8000-000000000002"
+	)
+
+	tests := []struct {
+		name    string
+		opts    *permissionv1.CopyOptions
+		writes  []map[string]string
+		deletes []map[string]string
+	}{
+		{
+			name:   "merge",
+			opts:   nil,
+			writes: []map[string]string{{"type": "select", "role": "analysts"}, {"type": "modify", "user": "oidc~alice"}},
+		},
+		{
+			name:    "replace",
+			opts:    &permissionv1.CopyOptions{Replace: true},
+			writes:  []map[string]string{{"type": "select", "role": "analysts"}, {"type": "modify", "user": "oidc~alice"}},
+			deletes: []map[string]string{{"type": "describe", "user": "oidc~bob"}},
+		},
+		{
+			name:   "relations",
+			opts:   &permissionv1.CopyOptions{Replace: true, Relations: []string{"select"}},
+			writes: []map[string]string{{"type": "select", "role": "analysts"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mux, client := testutil.ServerMux(t)
+
+			mux.HandleFunc("GET /management/v1/permissions/warehouse/"+src+"/assignments", func(w http.ResponseWriter, _ *http.Request) {
+				testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+					map[string]string{"type": "ownership", "user": "oidc~owner"},
+					map[string]string{"type": "select", "role": "analysts"},
+					map[string]string{"type": "modify", "user": "oidc~alice"},
+				}})
+			})
+			mux.HandleFunc("GET /management/v1/permissions/warehouse/"+dst+"/assignments", func(w http.ResponseWriter, _ *http.Request) {
+				testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+					map[string]string{"type": "ownership", "user": "oidc~owner"},
+					map[string]string{"type": "describe", "user": "oidc~bob"},
+				}})
+			})
+			mux.HandleFunc("POST /management/v1/permissions/warehouse/"+dst+"/assignments", func(w http.ResponseWriter, r *http.Request) {
+				want := map[string][]map[string]string{"writes": tt.writes}
+				if tt.deletes != nil {
+					want["deletes"] = tt.deletes
+				}
+				testutil.TestBodyJSON(t, r, want)
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			plan, err := permissionv1.CopyWarehouseAssignments(t.Context(), client.PermissionV1().WarehousePermission(), src, dst, tt.opts)
+			require.NoError(t, err)
+
+			assert.True(t, plan.Applied)
+			assert.Len(t, plan.Writes, len(tt.writes))
+			assert.Len(t, plan.Deletes, len(tt.deletes))
+		})
+	}
+}
+
+func TestCopyProjectAssignments_DryRun(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	const (
+		src = "01f2fdfc-81fc-444d-8368-5b6701566e35"
+		dst = "01f2fdfc-81fc-444d-8368-5b6701566e36"
+	)
+
+	mux.HandleFunc("GET /management/v1/permissions/project/"+src+"/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+			map[string]string{"type": "project_admin", "user": "oidc~alice"},
+		}})
+	})
+	mux.HandleFunc("GET /management/v1/permissions/project/"+dst+"/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{}})
+	})
+	mux.HandleFunc("POST /management/v1/permissions/project/"+dst+"/assignments", func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("dry run must not update assignments")
+	})
+
+	plan, err := permissionv1.CopyProjectAssignments(t.Context(), client.PermissionV1().ProjectPermission(), src, dst, &permissionv1.CopyOptions{DryRun: true})
+	require.NoError(t, err)
+
+	assert.False(t, plan.Applied)
+	assert.Equal(t, "project "+dst+": 1 to write, 0 to delete\n  + user oidc~alice project_admin\n", plan.String())
+}
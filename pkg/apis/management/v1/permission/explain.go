@@ -0,0 +1,326 @@
+package permission
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+type (
+	// ExplainOptions represents the Explain() options.
+	ExplainOptions struct {
+		// User is the ID of the user to explain the access of.
+		User string
+		// Action is the action to explain, e.g. create_namespace.
+		Action string
+		// ProjectID adds the project to the walked hierarchy.
+		ProjectID *string
+		// WarehouseID adds the warehouse to the walked hierarchy.
+		WarehouseID *string
+	}
+
+	// Explanation is the result of Explain().
+	Explanation struct {
+		User   string `json:"user"`
+		Action string `json:"action"`
+		// Allowed reports whether the action is allowed
+		// on at least one scope of the hierarchy.
+		Allowed bool `json:"allowed"`
+		// AllowedOn lists the scopes on which the action is allowed.
+		AllowedOn []Scope `json:"allowed-on"`
+		// Grants lists the assignments held by the user, directly or
+		// through role memberships, on the walked hierarchy.
+		Grants []*Grant `json:"grants"`
+	}
+
+	// Grant is an assignment the user benefits from.
+	Grant struct {
+		Scope      Scope  `json:"scope"`
+		ResourceID string `json:"resource-id,omitempty"`
+		Assignment string `json:"assignment"`
+		// PrincipalType and PrincipalID identify who holds the assignment,
+		// the user itself or one of its roles.
+		PrincipalType UserOrRoleType `json:"principal-type"`
+		PrincipalID   string         `json:"principal-id"`
+		// Via is the chain of roles from the user to the principal,
+		// empty for direct assignments.
+		Via []string `json:"via,omitempty"`
+		// Allows reports whether this grant produces the action. It is
+		// true when the role holding the assignment is allowed the action
+		// on its own, or for direct assignments when the action is allowed
+		// and no role explains it.
+		Allows bool `json:"allows"`
+	}
+)
+
+// Explain explains why a user can, or cannot, perform an action.
+//
+// It checks the access of the user on the server and, when provided, on
+// the project and the warehouse, then walks the assignments of each of
+// them, resolving the role memberships of the user with
+// RolePermission().GetAssignments().
+func Explain(ctx context.Context, s PermissionServiceInterface, opts *ExplainOptions) (*Explanation, error) {
+	if opts == nil || opts.User == "" || opts.Action == "" {
+		return nil, errors.New("user and action must be provided")
+	}
+
+	e := &explainer{
+		s:       s,
+		user:    opts.User,
+		members: map[string][]string{},
+	}
+
+	out := &Explanation{
+		User:      opts.User,
+		Action:    opts.Action,
+		AllowedOn: []Scope{},
+		Grants:    []*Grant{},
+	}
+
+	scopes := []struct {
+		scope Scope
+		id    *string
+	}{
+		{ServerScope, nil},
+		{ProjectScope, opts.ProjectID},
+		{WarehouseScope, opts.WarehouseID},
+	}
+
+	for _, sc := range scopes {
+		if sc.scope != ServerScope && sc.id == nil {
+			continue
+		}
+
+		id := ""
+		if sc.id != nil {
+			id = *sc.id
+		}
+
+		allowed, err := e.allowed(ctx, sc.scope, id, UserOrRole{UserType, opts.User}, opts.Action)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			out.Allowed = true
+			out.AllowedOn = append(out.AllowedOn, sc.scope)
+		}
+
+		assignments, err := e.assignments(ctx, sc.scope, id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range assignments {
+			via, ok, err := e.resolve(ctx, a.GetPrincipalType(), a.GetPrincipalID())
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+
+			out.Grants = append(out.Grants, &Grant{
+				Scope:         sc.scope,
+				ResourceID:    id,
+				Assignment:    a.GetAssignment(),
+				PrincipalType: a.GetPrincipalType(),
+				PrincipalID:   a.GetPrincipalID(),
+				Via:           via,
+			})
+		}
+	}
+
+	if !out.Allowed {
+		return out, nil
+	}
+
+	// find the grants held by roles allowed to perform the action
+	explained := false
+	roles := map[string]bool{}
+	for _, g := range out.Grants {
+		if g.PrincipalType != RoleType {
+			continue
+		}
+
+		allowed, ok := roles[g.PrincipalID]
+		if !ok {
+			for _, sc := range out.AllowedOn {
+				id := ""
+				switch sc {
+				case ProjectScope:
+					id = *opts.ProjectID
+				case WarehouseScope:
+					id = *opts.WarehouseID
+				}
+
+				var err error
+				allowed, err = e.allowed(ctx, sc, id, UserOrRole{RoleType, g.PrincipalID}, opts.Action)
+				if err != nil {
+					return nil, err
+				}
+				if allowed {
+					break
+				}
+			}
+			roles[g.PrincipalID] = allowed
+		}
+
+		g.Allows = allowed
+		explained = explained || allowed
+	}
+
+	if !explained {
+		for _, g := range out.Grants {
+			if g.PrincipalType == UserType {
+				g.Allows = true
+			}
+		}
+	}
+
+	return out, nil
+}
+
+type explainer struct {
+	s    PermissionServiceInterface
+	user string
+	// members caches, for each role, the chain of roles
+	// making the user a member of it. nil means not a member.
+	members map[string][]string
+}
+
+// resolve reports whether the principal is the user or a role the
+// user is a member of, returning the chain of roles in between.
+func (e *explainer) resolve(ctx context.Context, typ UserOrRoleType, id string) ([]string, bool, error) {
+	switch typ {
+	case UserType:
+		return nil, id == e.user, nil
+	case RoleType:
+		via, err := e.membership(ctx, id, nil)
+		if err != nil {
+			return nil, false, err
+		}
+		return via, via != nil, nil
+	}
+	return nil, false, nil
+}
+
+// membership returns the chain of roles, ending with role, through
+// which the user is a member of role, or nil if it is not.
+func (e *explainer) membership(ctx context.Context, role string, visiting []string) ([]string, error) {
+	if via, ok := e.members[role]; ok {
+		return via, nil
+	}
+
+	// roles can be assigned to each other, guard against cycles
+	if slices.Contains(visiting, role) {
+		return nil, nil
+	}
+	visiting = append(visiting, role)
+
+	resp, _, err := e.s.RolePermission().GetAssignments(ctx, role, &GetRoleAssignmentsOptions{
+		Relations: []RoleAssignmentType{AssigneeRoleAssignment},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var via []string
+	for _, a := range resp.Assignments {
+		if a.Assignment != AssigneeRoleAssignment {
+			continue
+		}
+
+		if a.Assignee.Type == UserType && a.Assignee.Value == e.user {
+			via = []string{role}
+			break
+		}
+
+		if a.Assignee.Type == RoleType {
+			inner, err := e.membership(ctx, a.Assignee.Value, visiting)
+			if err != nil {
+				return nil, err
+			}
+			if inner != nil {
+				via = append(slices.Clone(inner), role)
+				break
+			}
+		}
+	}
+
+	// a role skipped to break a cycle may still lead to the
+	// user through its ancestors, only cache definitive answers
+	if via != nil || len(visiting) == 1 {
+		e.members[role] = via
+	}
+	return via, nil
+}
+
+func (e *explainer) allowed(ctx context.Context, scope Scope, id string, principal UserOrRole, action string) (bool, error) {
+	var user, role *string
+	if principal.Type == UserType {
+		user = &principal.Value
+	} else {
+		role = &principal.Value
+	}
+
+	var actions []string
+	switch scope {
+	case ServerScope:
+		resp, _, err := e.s.ServerPermission().GetAccess(ctx, &GetServerAccessOptions{PrincipalUser: user, PrincipalRole: role})
+		if err != nil {
+			return false, err
+		}
+		for _, a := range resp.AllowedActions {
+			actions = append(actions, string(a))
+		}
+	case ProjectScope:
+		resp, _, err := e.s.ProjectPermission().GetAccess(ctx, id, &GetProjectAccessOptions{PrincipalUser: user, PrincipalRole: role})
+		if err != nil {
+			return false, err
+		}
+		for _, a := range resp.AllowedActions {
+			actions = append(actions, string(a))
+		}
+	case WarehouseScope:
+		allowed, err := WarehouseAccess(ctx, e.s.WarehousePermission(), id, &GetWarehouseAccessOptions{PrincipalUser: user, PrincipalRole: role})
+		if err != nil {
+			return false, err
+		}
+		for _, a := range allowed {
+			actions = append(actions, string(a))
+		}
+	}
+
+	return slices.Contains(actions, action), nil
+}
+
+func (e *explainer) assignments(ctx context.Context, scope Scope, id string) ([]Assignment, error) {
+	var out []Assignment
+	switch scope {
+	case ServerScope:
+		resp, _, err := e.s.ServerPermission().GetAssignments(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range resp.Assignments {
+			out = append(out, a)
+		}
+	case ProjectScope:
+		resp, _, err := e.s.ProjectPermission().GetAssignments(ctx, id, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range resp.Assignments {
+			out = append(out, a)
+		}
+	case WarehouseScope:
+		resp, _, err := e.s.WarehousePermission().GetAssignments(ctx, id, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range resp.Assignments {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
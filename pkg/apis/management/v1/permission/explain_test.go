@@ -0,0 +1,119 @@
+package permission_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+	"github.com/baptistegh/go-lakekeeper/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	const (
+		user      = "oidc~alice"
+		project   = "01f2fdfc-81fc-444d-8368-5b6701566e35"
+		warehouse = "a4b2c1d0-0000-4000-8000-000000000001"
+		engineers = "role-engineers"
+		data      = "role-data"
+	)
+
+	mux.HandleFunc("/management/v1/permissions/server/access", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestMethod(t, r, http.MethodGet)
+		testutil.TestParam(t, r, "principalUser", user)
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"allowed-actions": []string{}})
+	})
+	mux.HandleFunc("/management/v1/permissions/server/assignments", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestMethod(t, r, http.MethodGet)
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+			map[string]string{"type": "admin", "user": "oidc~bob"},
+		}})
+	})
+	mux.HandleFunc("/management/v1/permissions/project/"+project+"/access", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestMethod(t, r, http.MethodGet)
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"allowed-actions": []string{"list_warehouses"}})
+	})
+	mux.HandleFunc("/management/v1/permissions/project/"+project+"/assignments", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestMethod(t, r, http.MethodGet)
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+			map[string]string{"type": "describe", "user": user},
+		}})
+	})
+	mux.HandleFunc("/management/v1/permissions/warehouse/"+warehouse+"/access", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestMethod(t, r, http.MethodGet)
+		actions := []string{}
+		if r.URL.Query().Get("principalUser") == user || r.URL.Query().Get("principalRole") == data {
+			actions = append(actions, "create_namespace")
+		}
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"allowed-actions": actions})
+	})
+	mux.HandleFunc("/management/v1/permissions/warehouse/"+warehouse+"/assignments", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestMethod(t, r, http.MethodGet)
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+			map[string]string{"type": "create", "role": data},
+			map[string]string{"type": "select", "role": "role-others"},
+		}})
+	})
+	// alice is a member of engineers, which is a member of data
+	roles := map[string][]any{
+		data:          {map[string]string{"type": "assignee", "role": engineers}},
+		engineers:     {map[string]string{"type": "assignee", "user": user}, map[string]string{"type": "assignee", "role": data}},
+		"role-others": {map[string]string{"type": "assignee", "user": "oidc~bob"}},
+	}
+	for id, assignments := range roles {
+		mux.HandleFunc("/management/v1/permissions/role/"+id+"/assignments", func(w http.ResponseWriter, r *http.Request) {
+			testutil.TestMethod(t, r, http.MethodGet)
+			testutil.TestParam(t, r, "relations[]", "assignee")
+			testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": assignments})
+		})
+	}
+
+	got, err := permissionv1.Explain(t.Context(), client.PermissionV1(), &permissionv1.ExplainOptions{
+		User:        user,
+		Action:      string(permissionv1.CreateNamespace),
+		ProjectID:   core.Ptr(project),
+		WarehouseID: core.Ptr(warehouse),
+	})
+	require.NoError(t, err)
+
+	want := &permissionv1.Explanation{
+		User:      user,
+		Action:    "create_namespace",
+		Allowed:   true,
+		AllowedOn: []permissionv1.Scope{permissionv1.WarehouseScope},
+		Grants: []*permissionv1.Grant{
+			{
+				Scope:         permissionv1.ProjectScope,
+				ResourceID:    project,
+				Assignment:    "describe",
+				PrincipalType: permissionv1.UserType,
+				PrincipalID:   user,
+			},
+			{
+				Scope:         permissionv1.WarehouseScope,
+				ResourceID:    warehouse,
+				Assignment:    "create",
+				PrincipalType: permissionv1.RoleType,
+				PrincipalID:   data,
+				Via:           []string{engineers, data},
+				Allows:        true,
+			},
+		},
+	}
+
+	assert.Equal(t, want, got)
+}
+
+func TestExplain_MissingOptions(t *testing.T) {
+	t.Parallel()
+	_, client := testutil.ServerMux(t)
+
+	_, err := permissionv1.Explain(t.Context(), client.PermissionV1(), &permissionv1.ExplainOptions{User: "oidc~alice"})
+	require.Error(t, err)
+}
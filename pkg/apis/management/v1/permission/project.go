@@ -56,6 +56,29 @@ const (
 	GrantDataAdmin               ProjectAction = "grant_data_admin"
 )
 
+// ValidProjectActions lists the available actions on a project
+var ValidProjectActions = []ProjectAction{
+	CreateWarehouse,
+	DeleteProject,
+	RenameProject,
+	ProjectGetMetadata,
+	ListWarehouses,
+	ProjectIncludeInList,
+	CreateRole,
+	ListRoles,
+	SearchRoles,
+	GetProjectEndpointStatistics,
+	ReadProjectAssignments,
+	GrantProjectRoleCreator,
+	GrantProjectCreate,
+	GrantProjectDescribe,
+	GrantProjectModify,
+	GrantProjectSelet,
+	GrantProjectAdmin,
+	GrantSecurityAdmin,
+	GrantDataAdmin,
+}
+
 func NewProjectPermissionService(client core.Client) ProjectPermissionServiceInterface {
 	return &ProjectPermissionService{
 		client: client,
@@ -0,0 +1,205 @@
+package permission
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type (
+	// ReconcileOptions represents the Reconcile*() options.
+	ReconcileOptions struct {
+		// Relations limits the reconciliation to these assignment types.
+		// Current assignments of other types are left untouched.
+		// If empty, all assignments are reconciled.
+		Relations []string
+		// DryRun computes the plan without applying it.
+		DryRun bool
+	}
+
+	// Plan is the set of changes needed to reach the desired assignments.
+	Plan struct {
+		Scope      Scope        `json:"scope"`
+		ResourceID string       `json:"resource-id,omitempty"`
+		Writes     []Assignment `json:"writes"`
+		Deletes    []Assignment `json:"deletes"`
+		// Applied reports whether the changes were sent to the server.
+		Applied bool `json:"applied"`
+	}
+)
+
+// IsEmpty reports whether the current assignments already
+// match the desired ones.
+func (p *Plan) IsEmpty() bool {
+	return len(p.Writes) == 0 && len(p.Deletes) == 0
+}
+
+// String returns a human-readable description of the plan.
+func (p *Plan) String() string {
+	var b strings.Builder
+
+	b.WriteString(string(p.Scope))
+	if p.ResourceID != "" {
+		b.WriteString(" " + p.ResourceID)
+	}
+
+	if p.IsEmpty() {
+		b.WriteString(": no changes\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, ": %d to write, %d to delete\n", len(p.Writes), len(p.Deletes))
+	for _, a := range p.Writes {
+		fmt.Fprintf(&b, "  + %s %s %s\n", a.GetPrincipalType(), a.GetPrincipalID(), a.GetAssignment())
+	}
+	for _, a := range p.Deletes {
+		fmt.Fprintf(&b, "  - %s %s %s\n", a.GetPrincipalType(), a.GetPrincipalID(), a.GetAssignment())
+	}
+
+	return b.String()
+}
+
+// ReconcileServer makes the server assignments match desired.
+func ReconcileServer(ctx context.Context, s ServerPermissionServiceInterface, desired []*ServerAssignment, opts *ReconcileOptions) (*Plan, error) {
+	resp, _, err := s.GetAssignments(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return reconcile(ServerScope, "", resp.Assignments, desired, opts, false, func(writes, deletes []*ServerAssignment) error {
+		_, err := s.Update(ctx, &UpdateServerPermissionsOptions{Writes: writes, Deletes: deletes})
+		return err
+	})
+}
+
+// ReconcileProject makes the assignments of a project match desired.
+func ReconcileProject(ctx context.Context, s ProjectPermissionServiceInterface, id string, desired []*ProjectAssignment, opts *ReconcileOptions) (*Plan, error) {
+	resp, _, err := s.GetAssignments(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return reconcile(ProjectScope, id, resp.Assignments, desired, opts, false, func(writes, deletes []*ProjectAssignment) error {
+		_, err := s.Update(ctx, id, &UpdateProjectPermissionsOptions{Writes: writes, Deletes: deletes})
+		return err
+	})
+}
+
+// ReconcileRole makes the assignments of a role match desired.
+func ReconcileRole(ctx context.Context, s RolePermissionServiceInterface, id string, desired []*RoleAssignment, opts *ReconcileOptions) (*Plan, error) {
+	resp, _, err := s.GetAssignments(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return reconcile(RoleScope, id, resp.Assignments, desired, opts, false, func(writes, deletes []*RoleAssignment) error {
+		_, err := s.Update(ctx, id, &UpdateRolePermissionsOptions{Writes: writes, Deletes: deletes})
+		return err
+	})
+}
+
+// ReconcileWarehouse makes the assignments of a warehouse match desired.
+func ReconcileWarehouse(ctx context.Context, s WarehousePermissionServiceInterface, id string, desired []*WarehouseAssignment, opts *ReconcileOptions) (*Plan, error) {
+	resp, _, err := s.GetAssignments(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return reconcile(WarehouseScope, id, resp.Assignments, desired, opts, false, func(writes, deletes []*WarehouseAssignment) error {
+		_, err := s.Update(ctx, id, &UpdateWarehousePermissionsOptions{Writes: writes, Deletes: deletes})
+		return err
+	})
+}
+
+// reconcile computes the plan to go from current to desired and applies
+// it with update, unless it is empty or a dry run. When keep is true,
+// current assignments missing from desired are not deleted.
+func reconcile[T Assignment](scope Scope, id string, current, desired []T, opts *ReconcileOptions, keep bool, update func(writes, deletes []T) error) (*Plan, error) {
+	writes, deletes, err := diffAssignments(current, desired, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if keep {
+		deletes = nil
+	}
+
+	plan := newPlan(scope, id, writes, deletes)
+	if plan.IsEmpty() || (opts != nil && opts.DryRun) {
+		return plan, nil
+	}
+
+	if err := update(writes, deletes); err != nil {
+		return plan, err
+	}
+	plan.Applied = true
+
+	return plan, nil
+}
+
+func newPlan[T Assignment](scope Scope, id string, writes, deletes []T) *Plan {
+	plan := &Plan{
+		Scope:      scope,
+		ResourceID: id,
+		Writes:     make([]Assignment, 0, len(writes)),
+		Deletes:    make([]Assignment, 0, len(deletes)),
+	}
+	for _, a := range writes {
+		plan.Writes = append(plan.Writes, a)
+	}
+	for _, a := range deletes {
+		plan.Deletes = append(plan.Deletes, a)
+	}
+	return plan
+}
+
+// diffAssignments returns the desired assignments missing from current,
+// and the current assignments not desired, restricted to the relations
+// of opts. Duplicates in desired are ignored.
+func diffAssignments[T Assignment](current, desired []T, opts *ReconcileOptions) (writes, deletes []T, err error) {
+	var relations []string
+	if opts != nil {
+		relations = opts.Relations
+	}
+
+	inScope := func(a T) bool {
+		return len(relations) == 0 || slices.Contains(relations, a.GetAssignment())
+	}
+
+	want := make(map[string]bool, len(desired))
+	for _, a := range desired {
+		if !inScope(a) {
+			return nil, nil, fmt.Errorf("desired assignment %s of %s %s is not in the reconciled relations %v",
+				a.GetAssignment(), a.GetPrincipalType(), a.GetPrincipalID(), relations)
+		}
+		want[assignmentKey(a)] = true
+	}
+
+	have := make(map[string]bool, len(current))
+	for _, a := range current {
+		if !inScope(a) {
+			continue
+		}
+		have[assignmentKey(a)] = true
+		if !want[assignmentKey(a)] {
+			deletes = append(deletes, a)
+		}
+	}
+
+	for _, a := range desired {
+		k := assignmentKey(a)
+		if have[k] {
+			continue
+		}
+		// mark as present to skip duplicates
+		have[k] = true
+		writes = append(writes, a)
+	}
+
+	return writes, deletes, nil
+}
+
+func assignmentKey(a Assignment) string {
+	return string(a.GetPrincipalType()) + "|" + a.GetPrincipalID() + "|" + a.GetAssignment()
+}
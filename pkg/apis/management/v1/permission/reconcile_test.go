@@ -0,0 +1,104 @@
+package permission_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+)
+
+func TestReconcileWarehouse(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	const warehouse = "a4b2c1d0-0000-4000-8000-000000000001"
+
+	mux.HandleFunc("GET /management/v1/permissions/warehouse/"+warehouse+"/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+			map[string]string{"type": "ownership", "user": "oidc~owner"},
+			map[string]string{"type": "select", "role": "analysts"},
+			map[string]string{"type": "create", "role": "stale"},
+		}})
+	})
+	mux.HandleFunc("POST /management/v1/permissions/warehouse/"+warehouse+"/assignments", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestBodyJSON(t, r, map[string][]map[string]string{
+			"writes":  {{"type": "modify", "role": "engineers"}},
+			"deletes": {{"type": "create", "role": "stale"}},
+		})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	desired := []*permissionv1.WarehouseAssignment{
+		{Assignee: permissionv1.UserOrRole{Type: permissionv1.RoleType, Value: "analysts"}, Assignment: permissionv1.SelectWarehouseAssignment},
+		{Assignee: permissionv1.UserOrRole{Type: permissionv1.RoleType, Value: "engineers"}, Assignment: permissionv1.ModifyWarehouseAssignment},
+		{Assignee: permissionv1.UserOrRole{Type: permissionv1.RoleType, Value: "engineers"}, Assignment: permissionv1.ModifyWarehouseAssignment},
+	}
+
+	// ownership is not reconciled, the owner is kept
+	plan, err := permissionv1.ReconcileWarehouse(t.Context(), client.PermissionV1().WarehousePermission(), warehouse, desired, &permissionv1.ReconcileOptions{
+		Relations: []string{"select", "create", "modify"},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, plan.Applied)
+	assert.Len(t, plan.Writes, 1)
+	assert.Len(t, plan.Deletes, 1)
+	assert.Equal(t, "warehouse "+warehouse+": 1 to write, 1 to delete\n"+
+		"  + role engineers modify\n"+
+		"  - role stale create\n", plan.String())
+}
+
+func TestReconcileServer_DryRun(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	mux.HandleFunc("GET /management/v1/permissions/server/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+			map[string]string{"type": "admin", "user": "oidc~admin"},
+		}})
+	})
+	mux.HandleFunc("POST /management/v1/permissions/server/assignments", func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("dry run must not update assignments")
+	})
+
+	plan, err := permissionv1.ReconcileServer(t.Context(), client.PermissionV1().ServerPermission(), nil, &permissionv1.ReconcileOptions{DryRun: true})
+	require.NoError(t, err)
+
+	assert.False(t, plan.Applied)
+	assert.Empty(t, plan.Writes)
+	assert.Len(t, plan.Deletes, 1)
+}
+
+func TestReconcileProject_NoChanges(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	const project = "01f2fdfc-81fc-444d-8368-5b6701566e35"
+
+	mux.HandleFunc("GET /management/v1/permissions/project/"+project+"/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+			map[string]string{"type": "describe", "role": "readers"},
+		}})
+	})
+
+	desired := []*permissionv1.ProjectAssignment{
+		{Assignee: permissionv1.UserOrRole{Type: permissionv1.RoleType, Value: "readers"}, Assignment: permissionv1.DescribeProjectAssignment},
+	}
+
+	plan, err := permissionv1.ReconcileProject(t.Context(), client.PermissionV1().ProjectPermission(), project, desired, nil)
+	require.NoError(t, err)
+
+	assert.True(t, plan.IsEmpty())
+	assert.False(t, plan.Applied)
+	assert.Equal(t, "project "+project+": no changes\n", plan.String())
+
+	// desired assignments must belong to the reconciled relations
+	_, err = permissionv1.ReconcileProject(t.Context(), client.PermissionV1().ProjectPermission(), project, desired, &permissionv1.ReconcileOptions{
+		Relations: []string{"select"},
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,107 @@
+package permission
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// RevokeOptions represents the Revoke*() options.
+type RevokeOptions struct {
+	// Principals are the users and roles to revoke the assignments of.
+	Principals []UserOrRole
+	// Relations are the assignment types to revoke.
+	// If empty, all the assignments of the principals are revoked.
+	Relations []string
+	// DryRun computes the plan without applying it.
+	DryRun bool
+}
+
+// RevokeServer deletes the server assignments of the principals.
+// Only the assignments that exist are deleted, and listed in the plan.
+func RevokeServer(ctx context.Context, s ServerPermissionServiceInterface, opts *RevokeOptions) (*Plan, error) {
+	resp, _, err := s.GetAssignments(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return revoke(ServerScope, "", resp.Assignments, opts, func(deletes []*ServerAssignment) error {
+		_, err := s.Update(ctx, &UpdateServerPermissionsOptions{Deletes: deletes})
+		return err
+	})
+}
+
+// RevokeProject deletes the assignments of the principals on a project.
+// Only the assignments that exist are deleted, and listed in the plan.
+func RevokeProject(ctx context.Context, s ProjectPermissionServiceInterface, id string, opts *RevokeOptions) (*Plan, error) {
+	resp, _, err := s.GetAssignments(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return revoke(ProjectScope, id, resp.Assignments, opts, func(deletes []*ProjectAssignment) error {
+		_, err := s.Update(ctx, id, &UpdateProjectPermissionsOptions{Deletes: deletes})
+		return err
+	})
+}
+
+// RevokeRole deletes the assignments of the principals on a role.
+// Only the assignments that exist are deleted, and listed in the plan.
+func RevokeRole(ctx context.Context, s RolePermissionServiceInterface, id string, opts *RevokeOptions) (*Plan, error) {
+	resp, _, err := s.GetAssignments(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return revoke(RoleScope, id, resp.Assignments, opts, func(deletes []*RoleAssignment) error {
+		_, err := s.Update(ctx, id, &UpdateRolePermissionsOptions{Deletes: deletes})
+		return err
+	})
+}
+
+// RevokeWarehouse deletes the assignments of the principals on a warehouse.
+// Only the assignments that exist are deleted, and listed in the plan.
+func RevokeWarehouse(ctx context.Context, s WarehousePermissionServiceInterface, id string, opts *RevokeOptions) (*Plan, error) {
+	resp, _, err := s.GetAssignments(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return revoke(WarehouseScope, id, resp.Assignments, opts, func(deletes []*WarehouseAssignment) error {
+		_, err := s.Update(ctx, id, &UpdateWarehousePermissionsOptions{Deletes: deletes})
+		return err
+	})
+}
+
+// revoke selects the current assignments of the principals of opts
+// and deletes them with update, unless there are none or it is a dry run.
+func revoke[T Assignment](scope Scope, id string, current []T, opts *RevokeOptions, update func(deletes []T) error) (*Plan, error) {
+	if opts == nil || len(opts.Principals) == 0 {
+		return nil, errors.New("at least one principal must be provided")
+	}
+
+	var deletes []T
+	for _, a := range current {
+		if !slices.ContainsFunc(opts.Principals, func(p UserOrRole) bool {
+			return p.Type == a.GetPrincipalType() && p.Value == a.GetPrincipalID()
+		}) {
+			continue
+		}
+		if len(opts.Relations) > 0 && !slices.Contains(opts.Relations, a.GetAssignment()) {
+			continue
+		}
+		deletes = append(deletes, a)
+	}
+
+	plan := newPlan[T](scope, id, nil, deletes)
+	if plan.IsEmpty() || opts.DryRun {
+		return plan, nil
+	}
+
+	if err := update(deletes); err != nil {
+		return plan, err
+	}
+	plan.Applied = true
+
+	return plan, nil
+}
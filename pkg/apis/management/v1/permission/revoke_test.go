@@ -0,0 +1,101 @@
+package permission_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+)
+
+func TestRevokeProject(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	const project = "01f2fdfc-81fc-444d-8368-5b6701566e35"
+
+	mux.HandleFunc("GET /management/v1/permissions/project/"+project+"/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+			map[string]string{"type": "project_admin", "user": "oidc~alice"},
+			map[string]string{"type": "select", "user": "oidc~alice"},
+			map[string]string{"type": "select", "role": "analysts"},
+			map[string]string{"type": "select", "user": "oidc~bob"},
+		}})
+	})
+	mux.HandleFunc("POST /management/v1/permissions/project/"+project+"/assignments", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestBodyJSON(t, r, map[string][]map[string]string{
+			"deletes": {
+				{"type": "select", "user": "oidc~alice"},
+				{"type": "select", "role": "analysts"},
+			},
+		})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	plan, err := permissionv1.RevokeProject(t.Context(), client.PermissionV1().ProjectPermission(), project, &permissionv1.RevokeOptions{
+		Principals: []permissionv1.UserOrRole{
+			{Type: permissionv1.UserType, Value: "oidc~alice"},
+			{Type: permissionv1.RoleType, Value: "analysts"},
+		},
+		Relations: []string{"select", "modify"},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, plan.Applied)
+	assert.Empty(t, plan.Writes)
+	assert.Equal(t, "project "+project+": 0 to write, 2 to delete\n"+
+		"  - user oidc~alice select\n"+
+		"  - role analysts select\n", plan.String())
+}
+
+func TestRevokeRole_All(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	const role = "b2a4b1f0-0000-4000-8000-000000000001"
+
+	mux.HandleFunc("GET /management/v1/permissions/role/"+role+"/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+			map[string]string{"type": "ownership", "user": "oidc~alice"},
+			map[string]string{"type": "assignee", "user": "oidc~alice"},
+			map[string]string{"type": "assignee", "user": "oidc~bob"},
+		}})
+	})
+	mux.HandleFunc("POST /management/v1/permissions/role/"+role+"/assignments", func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("dry run must not update assignments")
+	})
+
+	plan, err := permissionv1.RevokeRole(t.Context(), client.PermissionV1().RolePermission(), role, &permissionv1.RevokeOptions{
+		Principals: []permissionv1.UserOrRole{{Type: permissionv1.UserType, Value: "oidc~alice"}},
+		DryRun:     true,
+	})
+	require.NoError(t, err)
+
+	assert.False(t, plan.Applied)
+	assert.Len(t, plan.Deletes, 2)
+}
+
+func TestRevokeServer_NothingToRevoke(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	mux.HandleFunc("GET /management/v1/permissions/server/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+			map[string]string{"type": "admin", "user": "oidc~admin"},
+		}})
+	})
+
+	plan, err := permissionv1.RevokeServer(t.Context(), client.PermissionV1().ServerPermission(), &permissionv1.RevokeOptions{
+		Principals: []permissionv1.UserOrRole{{Type: permissionv1.UserType, Value: "oidc~alice"}},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, plan.IsEmpty())
+	assert.False(t, plan.Applied)
+
+	_, err = permissionv1.RevokeServer(t.Context(), client.PermissionV1().ServerPermission(), &permissionv1.RevokeOptions{})
+	require.Error(t, err)
+}
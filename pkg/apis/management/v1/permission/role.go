@@ -115,6 +115,17 @@ const (
 	ReadRoleAssignments RoleAction = "read_assignments"
 )
 
+// ValidRoleActions lists the available actions on a role
+var ValidRoleActions = []RoleAction{
+	Assume,
+	CanGrantAssignee,
+	CanChangeOwnership,
+	DeleteRole,
+	UpdateRole,
+	ReadRole,
+	ReadRoleAssignments,
+}
+
 type OpenFGARoleAction string
 
 const (
@@ -200,6 +211,10 @@ func (s *RolePermissionService) Update(ctx context.Context, id string, opt *Upda
 // Lakekeeper API docs:
 // https://docs.lakekeeper.io/docs/nightly/api/management/#tag/permissions-openfga/operation/get_authorizer_role_actions
 func (s *RolePermissionService) GetAllowedAuthorizerActions(ctx context.Context, id string, opt *GetRoleAllowedAuthorizerActionsOptions, options ...core.RequestOptionFunc) (*GetRoleAllowedAuthorizerActionsResponse, *http.Response, error) {
+	if err := core.CheckCapabilities(ctx, s.client, core.RequireOpenFGA(), core.RequireVersion(core.MinVersionAllowedActions)); err != nil {
+		return nil, nil, err
+	}
+
 	req, err := s.client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("/permissions/role/%s/authorizer-actions", id), opt, options)
 	if err != nil {
 		return nil, nil, err
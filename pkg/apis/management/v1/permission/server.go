@@ -114,6 +114,17 @@ const (
 	ReadAssignments  ServerAction = "read_assignments"
 )
 
+// ValidServerActions lists the available actions on a server
+var ValidServerActions = []ServerAction{
+	CreateProject,
+	UpdateUsers,
+	DeleteUsers,
+	ListUsers,
+	ProvisionUsers,
+	GrantServerAdmin,
+	ReadAssignments,
+}
+
 // Available authorizer actions on a server
 type OpenFGAServerAction string
 
@@ -192,6 +203,10 @@ func (s *ServerPermissionService) Update(ctx context.Context, opt *UpdateServerP
 // Lakekeeper API docs:
 // https://docs.lakekeeper.io/docs/nightly/api/management/#tag/permissions-openfga/operation/get_authorizer_server_actions
 func (s *ServerPermissionService) GetAllowedAuthorizerActions(ctx context.Context, opt *GetServerAllowedAuthorizerActionsOptions, options ...core.RequestOptionFunc) (*GetServerAllowedAuthorizerActionsResponse, *http.Response, error) {
+	if err := core.CheckCapabilities(ctx, s.client, core.RequireOpenFGA(), core.RequireVersion(core.MinVersionAllowedActions)); err != nil {
+		return nil, nil, err
+	}
+
 	req, err := s.client.NewRequest(ctx, http.MethodGet, "/permissions/server/authorizer-actions", opt, options)
 	if err != nil {
 		return nil, nil, err
@@ -29,6 +29,11 @@ var (
 	_ json.Unmarshaler = (*ServerAssignment)(nil)
 	_ json.Marshaler   = (*ServerAssignment)(nil)
 
+	ValidServerAssignmentTypes = []ServerAssignmentType{
+		OperatorServerAssignment,
+		AdminServerAssignment,
+	}
+
 	_ Assignment = (*ServerAssignment)(nil)
 )
 
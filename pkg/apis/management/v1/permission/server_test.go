@@ -40,6 +40,43 @@ func TestServerPermissionService_GetAccess(t *testing.T) {
 	assert.Equal(t, want, access)
 }
 
+func TestServerPermissionService_GetAllowedAuthorizerActions_RequiresOpenFGA(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	mux.HandleFunc("/management/v1/info", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestMethod(t, r, http.MethodGet)
+		testutil.MustWriteJSONResponse(t, w, map[string]any{
+			"authz-backend": "allow-all",
+			"version":       "v0.10.0",
+		})
+	})
+
+	mux.HandleFunc("/management/v1/permissions/server/authorizer-actions", func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("request should not be sent to an unsupported server")
+	})
+
+	actions, _, err := client.PermissionV1().ServerPermission().GetAllowedAuthorizerActions(t.Context(), nil)
+	require.EqualError(t, err, "requires openfga authz, server has allow-all authz")
+	assert.Nil(t, actions)
+}
+
+func TestServerPermissionService_GetAssignments_NotGated(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	mux.HandleFunc("/management/v1/info", func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("the capabilities should not be fetched for a generic endpoint")
+	})
+
+	mux.HandleFunc("/management/v1/permissions/server/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{}})
+	})
+
+	_, _, err := client.PermissionV1().ServerPermission().GetAssignments(t.Context(), nil)
+	require.NoError(t, err)
+}
+
 func TestServerPermissionService_GetAssignments(t *testing.T) {
 	t.Parallel()
 	mux, client := testutil.ServerMux(t)
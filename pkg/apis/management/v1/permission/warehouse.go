@@ -162,6 +162,34 @@ const (
 	GetWarehouseEndpointStatistics WarehouseAction = "get_endpoint_statistics"
 )
 
+// ValidWarehouseActions lists the available actions on a warehouse
+var ValidWarehouseActions = []WarehouseAction{
+	CreateNamespace,
+	DeleteWarehouse,
+	ModifyStorage,
+	ModifyStorageCredential,
+	GetConfig,
+	GetMetadata,
+	ListNamespaces,
+	IncludeInList,
+	Deactivate,
+	Activate,
+	Rename,
+	ListDeletedTabulars,
+	ReadWarehouseAssignments,
+	GrantCreate,
+	GrantDescribe,
+	GrantModify,
+	GrantSelect,
+	GrantPassGrants,
+	GrantManageGrants,
+	ChangeOwnership,
+	GetAllTasks,
+	ControlAllTasks,
+	SetWarehouseProtection,
+	GetWarehouseEndpointStatistics,
+}
+
 // Available Authorizer Actions for a Warehouse
 type OpenFGAWarehouseAction string
 
@@ -289,6 +317,10 @@ func (s *WarehousePermissionService) SetManagedAccess(ctx context.Context, id st
 // Lakekeeper API docs:
 // https://docs.lakekeeper.io/docs/nightly/api/management/#tag/permissions/operation/get_warehouse_authorizer_actions
 func (s *WarehousePermissionService) GetAllowedAuthorizerActions(ctx context.Context, id string, opt *GetWarehouseAllowedAuthorizerActionsOptions, options ...core.RequestOptionFunc) (*GetWarehouseAllowedAuthorizerActionsResponse, *http.Response, error) {
+	if err := core.CheckCapabilities(ctx, s.client, core.RequireOpenFGA(), core.RequireVersion(core.MinVersionAllowedActions)); err != nil {
+		return nil, nil, err
+	}
+
 	path := fmt.Sprintf("/permissions/warehouse/%s/authorizer-actions", id)
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, opt, options)
@@ -304,3 +336,19 @@ func (s *WarehousePermissionService) GetAllowedAuthorizerActions(ctx context.Con
 
 	return &response, resp, nil
 }
+
+// WarehouseAccess returns the actions a user or a role can perform on a
+// warehouse.
+//
+// It calls the deprecated GetAccess(), the only endpoint listing them on
+// servers older than Lakekeeper 0.10: its replacements,
+// GetAllowedAuthorizerActions() and the GetAllowedActions() of the
+// warehouse service, require 0.10.
+func WarehouseAccess(ctx context.Context, s WarehousePermissionServiceInterface, id string, opt *GetWarehouseAccessOptions) ([]WarehouseAction, error) {
+	//nolint:staticcheck // see above, the replacements require Lakekeeper 0.10
+	resp, _, err := s.GetAccess(ctx, id, opt)
+	if err != nil {
+		return nil, err
+	}
+	return resp.AllowedActions, nil
+}
@@ -34,6 +34,16 @@ var (
 	_ json.Unmarshaler = (*WarehouseAssignment)(nil)
 	_ json.Marshaler   = (*WarehouseAssignment)(nil)
 
+	ValidWarehouseAssignmentTypes = []WarehouseAssignmentType{
+		OwnershipWarehouseAssignment,
+		PassGrantsAdminWarehouseAssignment,
+		ManageGrantsAdminWarehouseAssignment,
+		DescribeWarehouseAssignment,
+		SelectWarehouseAssignment,
+		CreateWarehouseAssignment,
+		ModifyWarehouseAssignment,
+	}
+
 	_ Assignment = (*WarehouseAssignment)(nil)
 )
 
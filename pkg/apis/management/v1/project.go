@@ -79,29 +79,40 @@ type (
 	// Lakekeeper API docs:
 	// https://docs.lakekeeper.io/docs/nightly/api/management/#tag/project/operation/get_endpoint_statistics
 	GetAPIStatisticsOptions struct {
-		RangeSpecifier *struct {
-			// type of the range specifier
-			// can be `window` or `page-token`
-			Type string `json:"type"`
-			// End timestamp of the time window Specify
-			// Required if type=window
-			End *string `json:"end,omitempty"`
-			// 	Duration/span of the time window
-			// The returned statistics will be for the time window from end - interval to end.
-			// Specify a ISO8601 duration string, e.g. PT1H for 1 hour, P1D for 1 day.
-			Interval *string `json:"interval,omitempty"`
-			// Opaque Token from previous response for paginating through time windows
-			// Use the next_page_token or previous_page_token from a previous response
-			// Required if type=page-token
-			Token *string `json:"token,omitempty"`
-		} `json:"range-specifier,omitempty"`
-		StatusCodes []int32 `json:"status-codes,omitempty"`
-		Warehouse   struct {
-			// Type can be `warehouse-id`, `unmapped` or `all`
-			Type string `json:"type"`
-			// Required if `Type=warehouse-id`
-			ID *string `json:"id,omitempty"`
-		} `json:"warehouse"`
+		RangeSpecifier *APIStatisticsRange          `json:"range-specifier,omitempty"`
+		StatusCodes    []int32                      `json:"status-codes,omitempty"`
+		Warehouse      APIStatisticsWarehouseFilter `json:"warehouse"`
+	}
+
+	// APIStatisticsRange selects the time window of GetAPIStatistics().
+	// See NewAPIStatisticsWindow and NewAPIStatisticsPageToken.
+	APIStatisticsRange struct {
+		// type of the range specifier
+		// can be `window` or `page-token`
+		Type RangeSpecifierType `json:"type"`
+		// End timestamp of the time window Specify
+		// Required if type=window
+		End *string `json:"end,omitempty"`
+		// 	Duration/span of the time window
+		// The returned statistics will be for the time window from end - interval to end.
+		// Specify a ISO8601 duration string, e.g. PT1H for 1 hour, P1D for 1 day.
+		Interval *string `json:"interval,omitempty"`
+		// Opaque Token from previous response for paginating through time windows
+		// Use the next_page_token or previous_page_token from a previous response
+		// Required if type=page-token
+		Token *string `json:"token,omitempty"`
+	}
+
+	// APIStatisticsWarehouseFilter selects the warehouses of GetAPIStatistics().
+	// See AllWarehousesFilter, UnmappedWarehousesFilter and WarehouseIDFilter.
+	//
+	// Type is a string, for the values of the unnamed struct type it replaced
+	// to stay assignable, see FilterType for the typed value.
+	APIStatisticsWarehouseFilter struct {
+		// Type can be `warehouse-id`, `unmapped` or `all`
+		Type string `json:"type"`
+		// Required if `Type=warehouse-id`
+		ID *string `json:"id,omitempty"`
 	}
 
 	// GetAPIStatisticsResponse represents GetAPIStatistics() response
@@ -153,6 +164,22 @@ type (
 		Timestamps []string `json:"timestamps"`
 	}
 
+	// EndpointStatistic is the number of calls of an endpoint, with a
+	// status code, on a warehouse, during a time slice of GetAPIStatistics().
+	//
+	// It has the underlying type of the entries of CalledEnpoints, which
+	// stay of an unnamed struct type for compatibility: convert them with
+	// EndpointStatistic(e). See CalledEnpoints for the fields.
+	EndpointStatistic struct {
+		Count         int64   `json:"count"`
+		CreatedAt     string  `json:"created-at"`
+		HTTPRoute     string  `json:"http-route"`
+		StatusCode    int32   `json:"status-code"`
+		UpdatedAt     *string `json:"updated-at,omitempty"`
+		WarehouseID   *string `json:"warehouse-id,omitempty"`
+		WarehouseName *string `json:"warehouse-name,omitempty"`
+	}
+
 	// GetProjectAllowedActionsOptions represents the GetAllowedActions() options.
 	//
 	// Only one of PrincipalUser or PrincipalRole should be set at a time.
@@ -316,6 +343,10 @@ func (p *Project) String() string {
 // Lakekeeper API docs:
 // https://docs.lakekeeper.io/docs/nightly/api/management/#tag/project/operation/get_project_actions
 func (s *ProjectService) GetAllowedActions(ctx context.Context, id string, opt *GetProjectAllowedActionsOptions, options ...core.RequestOptionFunc) (*GetProjectAllowedActionsResponse, *http.Response, error) {
+	if err := core.CheckCapabilities(ctx, s.client, core.RequireVersion(core.MinVersionAllowedActions)); err != nil {
+		return nil, nil, err
+	}
+
 	options = append(options, WithProject(id))
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, "/project/actions", opt, options)
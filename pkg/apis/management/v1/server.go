@@ -87,6 +87,14 @@ func (s *ServerInfo) String() string {
 	return string(b)
 }
 
+// Capabilities returns the capabilities advertised by the server.
+func (s *ServerInfo) Capabilities() *core.Capabilities {
+	return &core.Capabilities{
+		Version:      s.Version,
+		AuthzBackend: s.AuthzBackend,
+	}
+}
+
 // Info returns basic information about the server configuration and status.
 //
 // Lakekeeper API docs:
@@ -131,6 +139,10 @@ func (s *ServerService) Bootstrap(ctx context.Context, opts *BootstrapServerOpti
 // Lakekeeper API docs:
 // https://docs.lakekeeper.io/docs/nightly/api/management/#tag/server/operation/get_server_actions
 func (s *ServerService) GetAllowedActions(ctx context.Context, opt *GetServerAllowedActionsOptions, options ...core.RequestOptionFunc) (*GetServerAllowedActionsResponse, *http.Response, error) {
+	if err := core.CheckCapabilities(ctx, s.client, core.RequireVersion(core.MinVersionAllowedActions)); err != nil {
+		return nil, nil, err
+	}
+
 	req, err := s.client.NewRequest(ctx, http.MethodGet, "/server/actions", opt, options)
 	if err != nil {
 		return nil, nil, err
@@ -92,3 +92,22 @@ func TestServerService_GetAllowedActions(t *testing.T) {
 
 	assert.Equal(t, want, access)
 }
+
+func TestServerService_GetAllowedActions_UnsupportedVersion(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	mux.HandleFunc("/management/v1/info", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestMethod(t, r, http.MethodGet)
+		testutil.MustWriteHTTPResponse(t, w, "testdata/server_info.json")
+	})
+
+	mux.HandleFunc("/management/v1/server/actions", func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("request should not be sent to an unsupported server")
+	})
+
+	access, resp, err := client.ServerV1().GetAllowedActions(t.Context(), nil)
+	require.EqualError(t, err, "requires Lakekeeper >= 0.10.0, server has Lakekeeper v0.9.0")
+	assert.Nil(t, resp)
+	assert.Nil(t, access)
+}
@@ -0,0 +1,461 @@
+package v1
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+)
+
+// RangeSpecifierType is the type of an APIStatisticsRange.
+type RangeSpecifierType string
+
+const (
+	RangeSpecifierWindow    RangeSpecifierType = "window"
+	RangeSpecifierPageToken RangeSpecifierType = "page-token"
+)
+
+// WarehouseFilterType is the type of an APIStatisticsWarehouseFilter.
+type WarehouseFilterType string
+
+const (
+	WarehouseFilterID       WarehouseFilterType = "warehouse-id"
+	WarehouseFilterUnmapped WarehouseFilterType = "unmapped"
+	WarehouseFilterAll      WarehouseFilterType = "all"
+)
+
+// StatisticsPageDirection is the direction GetAPIStatisticsPages()
+// steps through time windows in.
+type StatisticsPageDirection int
+
+const (
+	// PreviousStatisticsPage follows PreviousPageToken, to older windows.
+	PreviousStatisticsPage StatisticsPageDirection = iota
+	// NextStatisticsPage follows NextPageToken, to newer windows.
+	NextStatisticsPage
+)
+
+type (
+	// AggregatedAPIStatistics is the result of GetAPIStatistics() pages
+	// turned into time series, see AggregateAPIStatistics.
+	AggregatedAPIStatistics struct {
+		// Start and End are the first and last time slices with calls.
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+		// Total is the number of calls, Errors the calls with a status
+		// code of 400 or more.
+		Total  int64 `json:"total"`
+		Errors int64 `json:"errors"`
+		// Series of the calls per endpoint, status code and warehouse,
+		// sorted by decreasing number of calls.
+		Endpoints   []*APIStatisticsSeries `json:"endpoints"`
+		StatusCodes []*APIStatisticsSeries `json:"status-codes"`
+		Warehouses  []*APIStatisticsSeries `json:"warehouses"`
+	}
+
+	// APIStatisticsSeries is the number of calls of an endpoint, a status
+	// code or a warehouse over time.
+	APIStatisticsSeries struct {
+		// Key is the HTTP route, the status code or the warehouse ID.
+		// It is empty for the calls not associated with a warehouse.
+		Key string `json:"key"`
+		// Name is the name of the warehouse, if any.
+		Name   string               `json:"name,omitempty"`
+		Total  int64                `json:"total"`
+		Errors int64                `json:"errors"`
+		Points []APIStatisticsPoint `json:"points"`
+	}
+
+	// APIStatisticsPoint is the number of calls during the hour ending at Time.
+	APIStatisticsPoint struct {
+		Time  time.Time `json:"time"`
+		Count int64     `json:"count"`
+	}
+)
+
+// NewAPIStatisticsWindow returns a range specifier selecting the
+// statistics from end - interval to end. A zero end is now.
+// The interval is sent as an ISO8601 duration, truncated to the second.
+func NewAPIStatisticsWindow(end time.Time, interval time.Duration) *APIStatisticsRange {
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return &APIStatisticsRange{
+		Type:     RangeSpecifierWindow,
+		End:      core.Ptr(end.UTC().Format(time.RFC3339)),
+		Interval: core.Ptr(ISO8601Duration(interval)),
+	}
+}
+
+// NewAPIStatisticsPageToken returns a range specifier selecting the window
+// of a NextPageToken or PreviousPageToken of a previous response.
+func NewAPIStatisticsPageToken(token string) *APIStatisticsRange {
+	return &APIStatisticsRange{
+		Type:  RangeSpecifierPageToken,
+		Token: &token,
+	}
+}
+
+// AllWarehousesFilter returns a filter selecting the calls of all
+// warehouses, and the calls not associated with a warehouse.
+func AllWarehousesFilter() APIStatisticsWarehouseFilter {
+	return APIStatisticsWarehouseFilter{Type: string(WarehouseFilterAll)}
+}
+
+// UnmappedWarehousesFilter returns a filter selecting the calls not
+// associated with a warehouse, e.g. project or user management.
+func UnmappedWarehousesFilter() APIStatisticsWarehouseFilter {
+	return APIStatisticsWarehouseFilter{Type: string(WarehouseFilterUnmapped)}
+}
+
+// WarehouseIDFilter returns a filter selecting the calls of a warehouse.
+func WarehouseIDFilter(id string) APIStatisticsWarehouseFilter {
+	return APIStatisticsWarehouseFilter{Type: string(WarehouseFilterID), ID: &id}
+}
+
+// FilterType returns the type of f.
+func (f APIStatisticsWarehouseFilter) FilterType() WarehouseFilterType {
+	return WarehouseFilterType(f.Type)
+}
+
+// IsError reports whether the calls of e failed, with a status code of
+// 400 or more.
+func (e EndpointStatistic) IsError() bool {
+	return e.StatusCode >= 400
+}
+
+// ISO8601Duration formats a duration as an ISO8601 duration,
+// e.g. P30D, PT1H or P1DT12H, truncated to the second.
+func ISO8601Duration(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	if d <= 0 {
+		return "PT0S"
+	}
+
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+
+	var b strings.Builder
+	b.WriteString("P")
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if hours > 0 || minutes > 0 || seconds > 0 {
+		b.WriteString("T")
+	}
+	for _, part := range []struct {
+		value time.Duration
+		unit  string
+	}{{hours, "H"}, {minutes, "M"}, {seconds, "S"}} {
+		if part.value > 0 {
+			fmt.Fprintf(&b, "%d%s", part.value, part.unit)
+		}
+	}
+	return b.String()
+}
+
+// GetAPIStatisticsPages calls GetAPIStatistics() with opt, then steps
+// through the neighbouring time windows in the given direction, returning
+// at most pages responses, in the order they were fetched.
+// It stops early when the server returns no token for the direction.
+func GetAPIStatisticsPages(ctx context.Context, s ProjectServiceInterface, id string, opt *GetAPIStatisticsOptions, direction StatisticsPageDirection, pages int, options ...core.RequestOptionFunc) ([]*GetAPIStatisticsResponse, error) {
+	if pages <= 0 {
+		return nil, errors.New("the number of pages must be positive")
+	}
+
+	var o GetAPIStatisticsOptions
+	if opt != nil {
+		o = *opt
+	}
+
+	var responses []*GetAPIStatisticsResponse
+	for len(responses) < pages {
+		resp, _, err := s.GetAPIStatistics(ctx, id, &o, options...)
+		if err != nil {
+			return responses, err
+		}
+		responses = append(responses, resp)
+
+		token := resp.PreviousPageToken
+		if direction == NextStatisticsPage {
+			token = resp.NextPageToken
+		}
+		if token == "" {
+			break
+		}
+		o.RangeSpecifier = NewAPIStatisticsPageToken(token)
+	}
+
+	return responses, nil
+}
+
+// AggregateAPIStatistics turns GetAPIStatistics() responses into time series
+// of the calls per endpoint, per status code and per warehouse.
+// Each entry of Timestamps is the time slice of the entry of CalledEnpoints
+// at the same index, the calls of a time slice found in several responses
+// are added up.
+func AggregateAPIStatistics(pages ...*GetAPIStatisticsResponse) (*AggregatedAPIStatistics, error) {
+	var (
+		agg        AggregatedAPIStatistics
+		endpoints  = map[string]*APIStatisticsSeries{}
+		statuses   = map[string]*APIStatisticsSeries{}
+		warehouses = map[string]*APIStatisticsSeries{}
+	)
+
+	add := func(series map[string]*APIStatisticsSeries, key, name string, t time.Time, count int64, failed bool) {
+		s, ok := series[key]
+		if !ok {
+			s = &APIStatisticsSeries{Key: key}
+			series[key] = s
+		}
+		if name != "" {
+			s.Name = name
+		}
+		s.Total += count
+		if failed {
+			s.Errors += count
+		}
+		if i := slices.IndexFunc(s.Points, func(p APIStatisticsPoint) bool { return p.Time.Equal(t) }); i >= 0 {
+			s.Points[i].Count += count
+			return
+		}
+		s.Points = append(s.Points, APIStatisticsPoint{Time: t, Count: count})
+	}
+
+	for _, page := range pages {
+		if page == nil {
+			continue
+		}
+		if len(page.Timestamps) != len(page.CalledEnpoints) {
+			return nil, fmt.Errorf("got %d timestamps for %d time slices of called endpoints", len(page.Timestamps), len(page.CalledEnpoints))
+		}
+
+		for i, ts := range page.Timestamps {
+			t, err := time.Parse(time.RFC3339, ts)
+			if err != nil {
+				return nil, fmt.Errorf("invalid timestamp %q: %w", ts, err)
+			}
+
+			if agg.Start.IsZero() || t.Before(agg.Start) {
+				agg.Start = t
+			}
+			if t.After(agg.End) {
+				agg.End = t
+			}
+
+			for _, c := range page.CalledEnpoints[i] {
+				e := EndpointStatistic(c)
+				failed := e.IsError()
+
+				agg.Total += e.Count
+				if failed {
+					agg.Errors += e.Count
+				}
+
+				add(endpoints, e.HTTPRoute, "", t, e.Count, failed)
+				add(statuses, strconv.Itoa(int(e.StatusCode)), "", t, e.Count, failed)
+
+				var warehouseID, warehouseName string
+				if e.WarehouseID != nil {
+					warehouseID = *e.WarehouseID
+				}
+				if e.WarehouseName != nil {
+					warehouseName = *e.WarehouseName
+				}
+				add(warehouses, warehouseID, warehouseName, t, e.Count, failed)
+			}
+		}
+	}
+
+	agg.Endpoints = sortedSeries(endpoints)
+	agg.StatusCodes = sortedSeries(statuses)
+	agg.Warehouses = sortedSeries(warehouses)
+
+	return &agg, nil
+}
+
+// ErrorRate returns the share of the calls with a status code of 400 or more.
+func (a *AggregatedAPIStatistics) ErrorRate() float64 {
+	return errorRate(a.Errors, a.Total)
+}
+
+// ErrorRate returns the share of the calls with a status code of 400 or more.
+func (s *APIStatisticsSeries) ErrorRate() float64 {
+	return errorRate(s.Errors, s.Total)
+}
+
+func errorRate(failed, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(failed) / float64(total)
+}
+
+// sortedSeries returns the series by decreasing number of calls,
+// then by key, with their points in chronological order.
+func sortedSeries(series map[string]*APIStatisticsSeries) []*APIStatisticsSeries {
+	sorted := make([]*APIStatisticsSeries, 0, len(series))
+	for _, s := range series {
+		slices.SortFunc(s.Points, func(a, b APIStatisticsPoint) int { return a.Time.Compare(b.Time) })
+		sorted = append(sorted, s)
+	}
+
+	slices.SortFunc(sorted, func(a, b *APIStatisticsSeries) int {
+		return cmp.Or(cmp.Compare(b.Total, a.Total), cmp.Compare(a.Key, b.Key))
+	})
+
+	return sorted
+}
+
+type (
+	// WarehouseStatisticsSeries is the number of tables and views of a
+	// warehouse every hour, see NewWarehouseStatisticsSeries.
+	WarehouseStatisticsSeries struct {
+		WarehouseID string                     `json:"warehouse-id"`
+		Points      []WarehouseStatisticsPoint `json:"points"`
+	}
+
+	// WarehouseStatisticsPoint is the number of tables and views of a
+	// warehouse at Time, the end of an hourly time slice.
+	WarehouseStatisticsPoint struct {
+		Time   time.Time `json:"time"`
+		Tables int64     `json:"tables"`
+		Views  int64     `json:"views"`
+		// UpdatedAt is the last change of the counts, if known.
+		UpdatedAt *time.Time `json:"updated-at,omitempty"`
+		// Filled is set when the server has no entry for the time slice,
+		// nothing changed and the counts are the ones of the previous entry.
+		Filled bool `json:"filled"`
+	}
+)
+
+// GetWarehouseStatisticsSeries calls GetStatistics() for the pages of a
+// warehouse statistics, and turns them into an hourly series, see
+// NewWarehouseStatisticsSeries. The server returns the most recent entries
+// first, the paging stops at the first entry not after since, if not zero.
+func GetWarehouseStatisticsSeries(ctx context.Context, s WarehouseServiceInterface, id string, since, until time.Time, options ...core.RequestOptionFunc) (*WarehouseStatisticsSeries, error) {
+	var (
+		opt   GetStatisticsOptions
+		pages []*GetStatisticsResponse
+	)
+
+	for {
+		resp, _, err := s.GetStatistics(ctx, id, &opt, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		pages = append(pages, resp)
+
+		if resp.NextPageToken == nil || *resp.NextPageToken == "" || len(resp.Stats) == 0 || reachesSince(resp, since) {
+			break
+		}
+		opt.PageToken = resp.NextPageToken
+	}
+
+	return NewWarehouseStatisticsSeries(since, until, pages...)
+}
+
+// reachesSince reports whether resp has an entry not after since, which
+// carries the counts at since. It is false if since is zero.
+func reachesSince(resp *GetStatisticsResponse, since time.Time) bool {
+	if since.IsZero() {
+		return false
+	}
+	for _, s := range resp.Stats {
+		if t, err := time.Parse(time.RFC3339, s.Timestamp); err == nil && !t.After(since) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewWarehouseStatisticsSeries turns GetStatistics() responses into a series
+// with a point every hour, from the first entry to the last one.
+//
+// The server only creates an entry for the hours with changes, the gaps are
+// filled with the counts of the previous entry. The series starts at the
+// first hour after since, if not zero, and is extended up to until, if
+// after the last entry. A zero until is the last entry, or the start of the
+// series if since is after the last entry.
+func NewWarehouseStatisticsSeries(since, until time.Time, pages ...*GetStatisticsResponse) (*WarehouseStatisticsSeries, error) {
+	series := &WarehouseStatisticsSeries{Points: []WarehouseStatisticsPoint{}}
+
+	var entries []WarehouseStatisticsPoint
+	for _, page := range pages {
+		if page == nil {
+			continue
+		}
+		if series.WarehouseID == "" {
+			series.WarehouseID = page.WarehouseID
+		}
+
+		for _, s := range page.Stats {
+			t, err := time.Parse(time.RFC3339, s.Timestamp)
+			if err != nil {
+				return nil, fmt.Errorf("invalid timestamp %q: %w", s.Timestamp, err)
+			}
+
+			p := WarehouseStatisticsPoint{Time: t, Tables: s.NumberOfTables, Views: s.NumberOfView}
+			if s.UpdatedAt != "" {
+				updatedAt, err := time.Parse(time.RFC3339, s.UpdatedAt)
+				if err != nil {
+					return nil, fmt.Errorf("invalid update time %q: %w", s.UpdatedAt, err)
+				}
+				p.UpdatedAt = &updatedAt
+			}
+			entries = append(entries, p)
+		}
+	}
+
+	if len(entries) == 0 {
+		return series, nil
+	}
+
+	slices.SortStableFunc(entries, func(a, b WarehouseStatisticsPoint) int { return a.Time.Compare(b.Time) })
+
+	first, last := entries[0].Time, entries[len(entries)-1].Time
+	if until.After(last) {
+		last = until
+	}
+
+	// The points follow the hours of the first entry.
+	start := first
+	if since.After(first) {
+		start = first.Add((since.Sub(first) + time.Hour - 1).Truncate(time.Hour))
+	}
+	if until.IsZero() && start.After(last) {
+		last = start
+	}
+
+	next := 0
+	var current *WarehouseStatisticsPoint
+	for t := start; !t.After(last); t = t.Add(time.Hour) {
+		filled := true
+		for next < len(entries) && !entries[next].Time.After(t) {
+			current = &entries[next]
+			if current.Time.After(t.Add(-time.Hour)) {
+				filled = false
+			}
+			next++
+		}
+
+		p := *current
+		p.Time = t
+		p.Filled = filled
+		series.Points = append(series.Points, p)
+	}
+
+	return series, nil
+}
@@ -0,0 +1,339 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+	"github.com/baptistegh/go-lakekeeper/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestISO8601Duration(t *testing.T) {
+	t.Parallel()
+
+	cases := map[time.Duration]string{
+		0:                                      "PT0S",
+		-time.Hour:                             "PT0S",
+		time.Hour:                              "PT1H",
+		30 * 24 * time.Hour:                    "P30D",
+		36 * time.Hour:                         "P1DT12H",
+		90*time.Minute + 1500*time.Millisecond: "PT1H30M1S",
+	}
+
+	for d, want := range cases {
+		assert.Equal(t, want, managementv1.ISO8601Duration(d), d.String())
+	}
+}
+
+func TestAPIStatisticsOptions_Constructors(t *testing.T) {
+	t.Parallel()
+
+	end := time.Date(2025, 8, 24, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	opt := managementv1.GetAPIStatisticsOptions{
+		RangeSpecifier: managementv1.NewAPIStatisticsWindow(end, 24*time.Hour),
+		Warehouse:      managementv1.WarehouseIDFilter("019eee1f-0cac-41a0-9932-f7e58ee24619"),
+	}
+
+	data, err := json.Marshal(&opt)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"range-specifier": {"type": "window", "end": "2025-08-24T12:00:00Z", "interval": "P1D"},
+		"warehouse": {"type": "warehouse-id", "id": "019eee1f-0cac-41a0-9932-f7e58ee24619"}
+	}`, string(data))
+
+	opt = managementv1.GetAPIStatisticsOptions{
+		RangeSpecifier: managementv1.NewAPIStatisticsPageToken("token"),
+		Warehouse:      managementv1.UnmappedWarehousesFilter(),
+	}
+
+	data, err = json.Marshal(&opt)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"range-specifier": {"type": "page-token", "token": "token"},
+		"warehouse": {"type": "unmapped"}
+	}`, string(data))
+
+	assert.Equal(t, managementv1.WarehouseFilterAll, managementv1.AllWarehousesFilter().FilterType())
+}
+
+func TestProjectService_GetAPIStatistics_TypedFilter(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	warehouse := "019eee1f-0cac-41a0-9932-f7e58ee24619"
+	filter := managementv1.WarehouseIDFilter(warehouse)
+	assert.Equal(t, managementv1.WarehouseFilterID, filter.FilterType())
+	assert.Equal(t, managementv1.WarehouseFilterUnmapped, managementv1.UnmappedWarehousesFilter().FilterType())
+
+	mux.HandleFunc("/management/v1/endpoint-statistics", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestMethod(t, r, http.MethodPost)
+		testutil.TestBodyJSON(t, r, map[string]any{
+			"range-specifier": map[string]any{"type": "page-token", "token": "token"},
+			"warehouse":       map[string]any{"type": "warehouse-id", "id": warehouse},
+		})
+		testutil.MustWriteHTTPResponse(t, w, "testdata/project_get_api_statistics.json")
+	})
+
+	resp, _, err := client.ProjectV1().GetAPIStatistics(t.Context(), "01f2fdfc-81fc-444d-8368-5b6701566e35", &managementv1.GetAPIStatisticsOptions{
+		RangeSpecifier: managementv1.NewAPIStatisticsPageToken("token"),
+		Warehouse:      filter,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.CalledEnpoints, 1)
+	require.Len(t, resp.CalledEnpoints[0], 1)
+
+	e := managementv1.EndpointStatistic(resp.CalledEnpoints[0][0])
+	assert.Equal(t, warehouse, *e.WarehouseID)
+	assert.False(t, e.IsError())
+}
+
+func TestGetAPIStatisticsPages(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	var tokens []string
+	mux.HandleFunc("/management/v1/endpoint-statistics", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestMethod(t, r, http.MethodPost)
+
+		var opt managementv1.GetAPIStatisticsOptions
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&opt))
+		assert.Equal(t, managementv1.WarehouseFilterAll, opt.Warehouse.FilterType())
+
+		var token string
+		if opt.RangeSpecifier != nil && opt.RangeSpecifier.Token != nil {
+			token = *opt.RangeSpecifier.Token
+		}
+		tokens = append(tokens, token)
+
+		previous := map[string]string{"": "page-2", "page-2": "page-3"}[token]
+		_ = json.NewEncoder(w).Encode(managementv1.GetAPIStatisticsResponse{
+			Timestamps:        []string{},
+			NextPageToken:     "next",
+			PreviousPageToken: previous,
+		})
+	})
+
+	opt := managementv1.GetAPIStatisticsOptions{Warehouse: managementv1.AllWarehousesFilter()}
+
+	pages, err := managementv1.GetAPIStatisticsPages(t.Context(), client.ProjectV1(), "01f2fdfc-81fc-444d-8368-5b6701566e35", &opt, managementv1.PreviousStatisticsPage, 2)
+	require.NoError(t, err)
+	assert.Len(t, pages, 2)
+	assert.Equal(t, []string{"", "page-2"}, tokens)
+	assert.Nil(t, opt.RangeSpecifier, "options of the caller must not be updated")
+
+	tokens = nil
+	pages, err = managementv1.GetAPIStatisticsPages(t.Context(), client.ProjectV1(), "01f2fdfc-81fc-444d-8368-5b6701566e35", &opt, managementv1.PreviousStatisticsPage, 10)
+	require.NoError(t, err)
+	assert.Len(t, pages, 3)
+	assert.Equal(t, []string{"", "page-2", "page-3"}, tokens)
+
+	_, err = managementv1.GetAPIStatisticsPages(t.Context(), client.ProjectV1(), "01f2fdfc-81fc-444d-8368-5b6701566e35", &opt, managementv1.NextStatisticsPage, 0)
+	require.Error(t, err)
+}
+
+func TestAggregateAPIStatistics(t *testing.T) {
+	t.Parallel()
+
+	warehouse := "019eee1f-0cac-41a0-9932-f7e58ee24619"
+
+	older := withCalls(&managementv1.GetAPIStatisticsResponse{Timestamps: []string{"2025-08-24T13:00:00Z"}},
+		[]managementv1.EndpointStatistic{
+			{Count: 5, HTTPRoute: "GET /catalog/v1/config", StatusCode: 200, WarehouseID: &warehouse, WarehouseName: core.Ptr("lake")},
+			{Count: 2, HTTPRoute: "POST /management/v1/warehouse", StatusCode: 400},
+		},
+	)
+	newer := withCalls(&managementv1.GetAPIStatisticsResponse{Timestamps: []string{"2025-08-24T15:00:00Z", "2025-08-24T14:00:00Z"}},
+		[]managementv1.EndpointStatistic{
+			{Count: 3, HTTPRoute: "GET /catalog/v1/config", StatusCode: 200, WarehouseID: &warehouse, WarehouseName: core.Ptr("lake")},
+			{Count: 1, HTTPRoute: "GET /catalog/v1/config", StatusCode: 404, WarehouseID: &warehouse, WarehouseName: core.Ptr("lake")},
+		},
+		[]managementv1.EndpointStatistic{
+			{Count: 4, HTTPRoute: "GET /catalog/v1/config", StatusCode: 200, WarehouseID: &warehouse, WarehouseName: core.Ptr("lake")},
+		},
+	)
+
+	agg, err := managementv1.AggregateAPIStatistics(newer, older)
+	require.NoError(t, err)
+
+	at := func(hour int) time.Time { return time.Date(2025, 8, 24, hour, 0, 0, 0, time.UTC) }
+
+	assert.Equal(t, at(13), agg.Start)
+	assert.Equal(t, at(15), agg.End)
+	assert.Equal(t, int64(15), agg.Total)
+	assert.Equal(t, int64(3), agg.Errors)
+	assert.InDelta(t, 0.2, agg.ErrorRate(), 1e-9)
+
+	require.Len(t, agg.Endpoints, 2)
+	assert.Equal(t, &managementv1.APIStatisticsSeries{
+		Key:    "GET /catalog/v1/config",
+		Total:  13,
+		Errors: 1,
+		Points: []managementv1.APIStatisticsPoint{
+			{Time: at(13), Count: 5},
+			{Time: at(14), Count: 4},
+			{Time: at(15), Count: 4},
+		},
+	}, agg.Endpoints[0])
+	assert.Equal(t, "POST /management/v1/warehouse", agg.Endpoints[1].Key)
+	assert.InDelta(t, 1, agg.Endpoints[1].ErrorRate(), 1e-9)
+
+	require.Len(t, agg.StatusCodes, 3)
+	assert.Equal(t, []string{"200", "400", "404"}, []string{agg.StatusCodes[0].Key, agg.StatusCodes[1].Key, agg.StatusCodes[2].Key})
+
+	require.Len(t, agg.Warehouses, 2)
+	assert.Equal(t, warehouse, agg.Warehouses[0].Key)
+	assert.Equal(t, "lake", agg.Warehouses[0].Name)
+	assert.Empty(t, agg.Warehouses[1].Key)
+	assert.Equal(t, int64(2), agg.Warehouses[1].Total)
+
+	_, err = managementv1.AggregateAPIStatistics(&managementv1.GetAPIStatisticsResponse{Timestamps: []string{"2025-08-24T13:00:00Z"}})
+	require.Error(t, err)
+
+	_, err = managementv1.AggregateAPIStatistics(withCalls(&managementv1.GetAPIStatisticsResponse{Timestamps: []string{"yesterday"}}, nil))
+	require.Error(t, err)
+}
+
+// withCalls appends a time slice of called endpoints to r for each calls.
+func withCalls(r *managementv1.GetAPIStatisticsResponse, calls ...[]managementv1.EndpointStatistic) *managementv1.GetAPIStatisticsResponse {
+	for _, slice := range calls {
+		r.CalledEnpoints = append(r.CalledEnpoints, nil)
+		last := len(r.CalledEnpoints) - 1
+		for _, e := range slice {
+			r.CalledEnpoints[last] = append(r.CalledEnpoints[last], e)
+		}
+	}
+	return r
+}
+
+func TestNewWarehouseStatisticsSeries(t *testing.T) {
+	t.Parallel()
+
+	at := func(hour int) time.Time { return time.Date(2025, 8, 24, hour, 0, 0, 0, time.UTC) }
+	updatedAt := time.Date(2025, 8, 24, 10, 45, 0, 0, time.UTC)
+
+	// The server returns the most recent entries first.
+	pages := []*managementv1.GetStatisticsResponse{
+		{
+			WarehouseID: "ffa0e747-387e-4f5a-a257-5f6bcf38297d",
+			Stats: []managementv1.WarehouseStatistic{
+				{NumberOfTables: 3, NumberOfView: 1, Timestamp: "2025-08-24T14:00:00Z"},
+			},
+		},
+		{
+			WarehouseID: "ffa0e747-387e-4f5a-a257-5f6bcf38297d",
+			Stats: []managementv1.WarehouseStatistic{
+				{NumberOfTables: 2, NumberOfView: 1, Timestamp: "2025-08-24T11:00:00Z", UpdatedAt: "2025-08-24T10:45:00Z"},
+				{NumberOfTables: 1, NumberOfView: 0, Timestamp: "2025-08-24T10:00:00Z"},
+			},
+		},
+	}
+
+	series, err := managementv1.NewWarehouseStatisticsSeries(time.Time{}, at(16), pages...)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ffa0e747-387e-4f5a-a257-5f6bcf38297d", series.WarehouseID)
+	assert.Equal(t, []managementv1.WarehouseStatisticsPoint{
+		{Time: at(10), Tables: 1, Views: 0},
+		{Time: at(11), Tables: 2, Views: 1, UpdatedAt: &updatedAt},
+		{Time: at(12), Tables: 2, Views: 1, UpdatedAt: &updatedAt, Filled: true},
+		{Time: at(13), Tables: 2, Views: 1, UpdatedAt: &updatedAt, Filled: true},
+		{Time: at(14), Tables: 3, Views: 1},
+		{Time: at(15), Tables: 3, Views: 1, Filled: true},
+		{Time: at(16), Tables: 3, Views: 1, Filled: true},
+	}, series.Points)
+
+	// The counts before since are carried to the first hour after it.
+	series, err = managementv1.NewWarehouseStatisticsSeries(at(12).Add(-30*time.Minute), time.Time{}, pages...)
+	require.NoError(t, err)
+
+	assert.Equal(t, []managementv1.WarehouseStatisticsPoint{
+		{Time: at(12), Tables: 2, Views: 1, UpdatedAt: &updatedAt, Filled: true},
+		{Time: at(13), Tables: 2, Views: 1, UpdatedAt: &updatedAt, Filled: true},
+		{Time: at(14), Tables: 3, Views: 1},
+	}, series.Points)
+
+	// Without changes since, the counts of the last entry are carried.
+	series, err = managementv1.NewWarehouseStatisticsSeries(at(17).Add(-30*time.Minute), time.Time{}, pages...)
+	require.NoError(t, err)
+
+	assert.Equal(t, []managementv1.WarehouseStatisticsPoint{
+		{Time: at(17), Tables: 3, Views: 1, Filled: true},
+	}, series.Points)
+
+	series, err = managementv1.NewWarehouseStatisticsSeries(time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, series.Points)
+
+	_, err = managementv1.NewWarehouseStatisticsSeries(time.Time{}, time.Time{}, &managementv1.GetStatisticsResponse{
+		Stats: []managementv1.WarehouseStatistic{{Timestamp: "yesterday"}},
+	})
+	require.Error(t, err)
+}
+
+func TestGetWarehouseStatisticsSeries(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	projectID := "01f2fdfc-81fc-444d-8368-5b6701566e35"
+	warehouseID := "a4b2c1d0-e3f4-5a6b-7c8d-9e0f1a2b3c4d"
+	otherID := "b5c3d2e1-f4a5-6b7c-8d9e-0f1a2b3c4d5e"
+
+	mux.HandleFunc("/management/v1/warehouse/"+warehouseID+"/statistics", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestMethod(t, r, http.MethodGet)
+		testutil.TestHeader(t, r, "x-project-id", projectID)
+
+		resp := managementv1.GetStatisticsResponse{WarehouseID: warehouseID}
+		switch r.URL.Query().Get("page_token") {
+		case "":
+			resp.Stats = []managementv1.WarehouseStatistic{{NumberOfTables: 2, Timestamp: "2025-08-24T12:00:00Z"}}
+			resp.NextPageToken = core.Ptr("page-2")
+		case "page-2":
+			resp.Stats = []managementv1.WarehouseStatistic{{NumberOfTables: 1, Timestamp: "2025-08-24T10:00:00Z"}}
+			resp.NextPageToken = core.Ptr("page-3")
+		default:
+			resp.Stats = []managementv1.WarehouseStatistic{}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+
+	series, err := managementv1.GetWarehouseStatisticsSeries(t.Context(), client.WarehouseV1(projectID), warehouseID, time.Time{}, time.Time{})
+	require.NoError(t, err)
+
+	assert.Equal(t, warehouseID, series.WarehouseID)
+	require.Len(t, series.Points, 3)
+	assert.Equal(t, []int64{1, 1, 2}, []int64{series.Points[0].Tables, series.Points[1].Tables, series.Points[2].Tables})
+	assert.True(t, series.Points[1].Filled)
+
+	// The pages older than since are not fetched.
+	var calls atomic.Int32
+	mux.HandleFunc("/management/v1/warehouse/"+otherID+"/statistics", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		resp := managementv1.GetStatisticsResponse{WarehouseID: otherID}
+		switch r.URL.Query().Get("page_token") {
+		case "":
+			resp.Stats = []managementv1.WarehouseStatistic{{NumberOfTables: 3, Timestamp: "2025-08-24T14:00:00Z"}}
+			resp.NextPageToken = core.Ptr("page-2")
+		case "page-2":
+			resp.Stats = []managementv1.WarehouseStatistic{{NumberOfTables: 2, Timestamp: "2025-08-24T11:00:00Z"}}
+			resp.NextPageToken = core.Ptr("page-3")
+		default:
+			t.Errorf("unexpected page %s", r.URL.Query().Get("page_token"))
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+
+	since := time.Date(2025, 8, 24, 12, 30, 0, 0, time.UTC)
+	series, err = managementv1.GetWarehouseStatisticsSeries(t.Context(), client.WarehouseV1(projectID), otherID, since, time.Time{})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, []int64{2, 3}, []int64{series.Points[0].Tables, series.Points[1].Tables})
+}
@@ -1,6 +1,9 @@
 package credential
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+)
 
 type (
 	AZSCredentialType string
@@ -71,6 +74,15 @@ func (c *AZCredentialClientCredentials) AsCredential() StorageCredential {
 	return StorageCredential{Settings: c}
 }
 
+// Validate checks the client ID, secret and tenant are set.
+func (c *AZCredentialClientCredentials) Validate() error {
+	return errors.Join(
+		validateRequired("client-id", c.ClientID),
+		validateRequired("client-secret", c.ClientSecret),
+		validateRequired("tenant-id", c.TenantID),
+	)
+}
+
 func (c AZCredentialClientCredentials) MarshalJSON() ([]byte, error) {
 	type Alias AZCredentialClientCredentials
 	aux := struct {
@@ -97,6 +109,11 @@ func (c *AZCredentialSharedAccessKey) AsCredential() StorageCredential {
 	return StorageCredential{Settings: c}
 }
 
+// Validate checks the shared key is set.
+func (c *AZCredentialSharedAccessKey) Validate() error {
+	return validateRequired("key", c.Key)
+}
+
 func (c AZCredentialSharedAccessKey) MarshalJSON() ([]byte, error) {
 	type Alias AZCredentialSharedAccessKey
 	aux := struct {
@@ -123,6 +140,11 @@ func (c *AZCredentialManagedIdentity) AsCredential() StorageCredential {
 	return StorageCredential{Settings: c}
 }
 
+// Validate always succeeds, the managed identity has no settings.
+func (*AZCredentialManagedIdentity) Validate() error {
+	return nil
+}
+
 func (c AZCredentialManagedIdentity) MarshalJSON() ([]byte, error) {
 	aux := struct {
 		Type           string `json:"type"`
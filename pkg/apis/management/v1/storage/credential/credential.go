@@ -2,7 +2,9 @@ package credential
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 )
 
 type (
@@ -12,9 +14,22 @@ type (
 
 	CredentialFamily string
 
+	// CredentialSettingsDecoder decodes the settings of a credential
+	// type from a JSON storage credential.
+	CredentialSettingsDecoder func(data []byte) (CredentialSettings, error)
+
+	// credentialKey identifies the decoder of a storage credential.
+	credentialKey struct {
+		family         CredentialFamily
+		credentialType string
+	}
+
 	CredentialSettings interface {
 		GetCredentialFamily() CredentialFamily
 		AsCredential() StorageCredential
+		// Validate checks the settings client side, the errors
+		// are *core.ValidationError carrying the invalid field.
+		Validate() error
 
 		json.Marshaler
 	}
@@ -26,6 +41,47 @@ const (
 	AZCredentialFamily  CredentialFamily = "az"
 )
 
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[credentialKey]CredentialSettingsDecoder{
+		{S3CredentialFamily, string(AccessKey)}:           decodeSettings[S3CredentialAccessKey],
+		{S3CredentialFamily, string(AWSSystemIdentity)}:   decodeSettings[S3CredentialSystemIdentity],
+		{S3CredentialFamily, string(CloudflareR2)}:        decodeSettings[CloudflareR2Credential],
+		{GCSCredentialFamily, string(ServiceAccountKey)}:  decodeSettings[GCSCredentialServiceAccountKey],
+		{GCSCredentialFamily, string(GCPSystemIdentity)}:  decodeSettings[GCSCredentialSystemIdentity],
+		{AZCredentialFamily, string(ClientCredentials)}:   decodeSettings[AZCredentialClientCredentials],
+		{AZCredentialFamily, string(SharedAccessKey)}:     decodeSettings[AZCredentialSharedAccessKey],
+		{AZCredentialFamily, string(AzureSystemIdentity)}: decodeSettings[AZCredentialManagedIdentity],
+	}
+)
+
+// RegisterCredentialType registers the decoder of the settings of a
+// credential type of a family, e.g. supported by a newer Lakekeeper
+// server, used by StorageCredential.UnmarshalJSON. It replaces the
+// decoder of a type already registered, including the built-in ones.
+func RegisterCredentialType(family CredentialFamily, credentialType string, decode CredentialSettingsDecoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+
+	decoders[credentialKey{family, credentialType}] = decode
+}
+
+// decodeSettings decodes data as the settings type T.
+func decodeSettings[T any, PT interface {
+	*T
+	CredentialSettings
+}](data []byte) (CredentialSettings, error) {
+	var cfg T
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return PT(&cfg), nil
+}
+
+// UnmarshalJSON decodes the settings with the decoder registered for
+// their type and credential type. Settings of an unregistered pair are
+// decoded as *UnknownCredentialSettings, so credentials of newer servers
+// can be read.
 func (sc *StorageCredential) UnmarshalJSON(data []byte) error {
 	var peek struct {
 		Type           string `json:"type"`
@@ -35,58 +91,24 @@ func (sc *StorageCredential) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("invalid JSON: %w", err)
 	}
 
-	switch fmt.Sprintf("%s:%s", peek.Type, peek.CredentialType) {
-	case fmt.Sprintf("%s:%s", S3CredentialFamily, AccessKey):
-		var cfg S3CredentialAccessKey
-		if err := json.Unmarshal(data, &cfg); err != nil {
-			return err
-		}
-		sc.Settings = &cfg
-	case fmt.Sprintf("%s:%s", S3CredentialFamily, AWSSystemIdentity):
-		var cfg S3CredentialSystemIdentity
-		if err := json.Unmarshal(data, &cfg); err != nil {
-			return err
-		}
-		sc.Settings = &cfg
-	case fmt.Sprintf("%s:%s", S3CredentialFamily, CloudflareR2):
-		var cfg CloudflareR2Credential
-		if err := json.Unmarshal(data, &cfg); err != nil {
-			return err
-		}
-		sc.Settings = &cfg
-	case fmt.Sprintf("%s:%s", GCSCredentialFamily, ServiceAccountKey):
-		var cfg GCSCredentialServiceAccountKey
-		if err := json.Unmarshal(data, &cfg); err != nil {
-			return err
-		}
-		sc.Settings = &cfg
-	case fmt.Sprintf("%s:%s", GCSCredentialFamily, GCPSystemIdentity):
-		var cfg GCSCredentialSystemIdentity
-		if err := json.Unmarshal(data, &cfg); err != nil {
-			return err
-		}
-		sc.Settings = &cfg
-	case fmt.Sprintf("%s:%s", AZCredentialFamily, ClientCredentials):
-		var cfg AZCredentialClientCredentials
-		if err := json.Unmarshal(data, &cfg); err != nil {
-			return err
-		}
-		sc.Settings = &cfg
-	case fmt.Sprintf("%s:%s", AZCredentialFamily, SharedAccessKey):
-		var cfg AZCredentialSharedAccessKey
-		if err := json.Unmarshal(data, &cfg); err != nil {
-			return err
-		}
-		sc.Settings = &cfg
-	case fmt.Sprintf("%s:%s", AZCredentialFamily, AzureSystemIdentity):
-		var cfg AZCredentialManagedIdentity
-		if err := json.Unmarshal(data, &cfg); err != nil {
-			return err
-		}
-		sc.Settings = &cfg
-	default:
-		return fmt.Errorf("unsupported storage credential type: %s / %s", peek.Type, peek.CredentialType)
+	if peek.Type == "" {
+		return errors.New("missing storage credential type")
+	}
+
+	decodersMu.RLock()
+	decode, ok := decoders[credentialKey{CredentialFamily(peek.Type), peek.CredentialType}]
+	decodersMu.RUnlock()
+
+	if !ok {
+		sc.Settings = NewUnknownCredentialSettings(CredentialFamily(peek.Type), peek.CredentialType, data)
+		return nil
+	}
+
+	settings, err := decode(data)
+	if err != nil {
+		return err
 	}
+	sc.Settings = settings
 	return nil
 }
 
@@ -110,3 +132,9 @@ func (sc StorageCredential) AsGCS() (GCSSCredentialSettings, bool) {
 	cfg, ok := sc.Settings.(GCSSCredentialSettings)
 	return cfg, ok
 }
+
+// AsUnknown returns the settings of a credential type unknown to the SDK.
+func (sc StorageCredential) AsUnknown() (*UnknownCredentialSettings, bool) {
+	cfg, ok := sc.Settings.(*UnknownCredentialSettings)
+	return cfg, ok
+}
@@ -0,0 +1,230 @@
+package credential
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrCredentialsNotFound is returned by the loaders when no credentials
+// are configured in the sources they read.
+var ErrCredentialsNotFound = errors.New("credentials not found")
+
+type (
+	// AWSLoaderOptions configures LoadS3CredentialAccessKey.
+	AWSLoaderOptions func(*awsLoader)
+
+	awsLoader struct {
+		profile         string
+		credentialsFile string
+		configFile      string
+	}
+)
+
+// WithAWSProfile selects the profile of the shared files to read,
+// instead of $AWS_PROFILE or default. When set, the environment
+// variables holding the access key are ignored.
+func WithAWSProfile(profile string) AWSLoaderOptions {
+	return func(l *awsLoader) {
+		l.profile = profile
+	}
+}
+
+// WithAWSSharedCredentialsFile sets the path of the shared credentials
+// file, instead of $AWS_SHARED_CREDENTIALS_FILE or ~/.aws/credentials.
+func WithAWSSharedCredentialsFile(path string) AWSLoaderOptions {
+	return func(l *awsLoader) {
+		l.credentialsFile = path
+	}
+}
+
+// WithAWSConfigFile sets the path of the shared config file,
+// instead of $AWS_CONFIG_FILE or ~/.aws/config.
+func WithAWSConfigFile(path string) AWSLoaderOptions {
+	return func(l *awsLoader) {
+		l.configFile = path
+	}
+}
+
+// LoadS3CredentialAccessKey loads an AWS access key the way the AWS CLI
+// does: from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment
+// variables, unless a profile is selected with WithAWSProfile, then from
+// the profile of the shared credentials file, then of the shared config file.
+//
+// Temporary credentials, with a session token, are rejected as they
+// cannot be used by a warehouse. ErrCredentialsNotFound is returned if
+// no access key is found.
+func LoadS3CredentialAccessKey(opts ...AWSLoaderOptions) (*S3CredentialAccessKey, error) {
+	l := awsLoader{
+		credentialsFile: os.Getenv("AWS_SHARED_CREDENTIALS_FILE"),
+		configFile:      os.Getenv("AWS_CONFIG_FILE"),
+	}
+	for _, v := range opts {
+		v(&l)
+	}
+
+	explicitProfile := l.profile != ""
+	if !explicitProfile {
+		l.profile = os.Getenv("AWS_PROFILE")
+	}
+	if l.profile == "" {
+		l.profile = "default"
+	}
+
+	if !explicitProfile {
+		id, secret := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+		if id != "" && secret != "" {
+			if os.Getenv("AWS_SESSION_TOKEN") != "" {
+				return nil, errors.New("AWS_SESSION_TOKEN is set, temporary credentials cannot be used by a warehouse")
+			}
+			return NewS3CredentialAccessKey(id, secret), nil
+		}
+	}
+
+	home, _ := os.UserHomeDir()
+	if l.credentialsFile == "" {
+		l.credentialsFile = filepath.Join(home, ".aws", "credentials")
+	}
+	if l.configFile == "" {
+		l.configFile = filepath.Join(home, ".aws", "config")
+	}
+
+	// Profiles are named "profile name" in the config file,
+	// except for the default profile.
+	configSection := "profile " + l.profile
+	if l.profile == "default" {
+		configSection = l.profile
+	}
+
+	for _, source := range []struct{ path, section string }{
+		{l.credentialsFile, l.profile},
+		{l.configFile, configSection},
+	} {
+		values, err := readINISection(source.path, source.section)
+		if err != nil {
+			return nil, err
+		}
+
+		id, secret := values["aws_access_key_id"], values["aws_secret_access_key"]
+		if id == "" && secret == "" {
+			continue
+		}
+		if id == "" || secret == "" {
+			return nil, fmt.Errorf("profile %s of %s must contain both aws_access_key_id and aws_secret_access_key", l.profile, source.path)
+		}
+		if values["aws_session_token"] != "" {
+			return nil, fmt.Errorf("profile %s of %s contains temporary credentials, which cannot be used by a warehouse", l.profile, source.path)
+		}
+
+		return NewS3CredentialAccessKey(id, secret), nil
+	}
+
+	return nil, fmt.Errorf("no access key for AWS profile %s: %w", l.profile, ErrCredentialsNotFound)
+}
+
+// readINISection returns the keys of a section of an INI file.
+// A missing file is treated as empty.
+func readINISection(path, section string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	inSection := false
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "", strings.HasPrefix(line, "#"), strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			inSection = strings.Join(strings.Fields(line[1:len(line)-1]), " ") == section
+			continue
+		case !inSection:
+			continue
+		}
+
+		if k, v, ok := strings.Cut(line, "="); ok {
+			values[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+
+	return values, nil
+}
+
+// LoadGCSServiceAccountKey reads a service account JSON key file,
+// as downloaded from the Google Cloud console. If path is empty,
+// $GOOGLE_APPLICATION_CREDENTIALS is read.
+func LoadGCSServiceAccountKey(path string) (*GCSCredentialServiceAccountKey, error) {
+	if path == "" {
+		path = os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	}
+	if path == "" {
+		return nil, fmt.Errorf("no service account key file: %w", ErrCredentialsNotFound)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ParseGCSServiceAccountKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// ParseGCSServiceAccountKey parses a service account JSON key.
+func ParseGCSServiceAccountKey(data []byte) (*GCSCredentialServiceAccountKey, error) {
+	var key GCSServiceKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("invalid service account key: %w", err)
+	}
+
+	if key.Type != "service_account" {
+		return nil, fmt.Errorf("invalid service account key: type is %q, expected service_account", key.Type)
+	}
+
+	return NewGCSCredentialServiceAccountKey(key), nil
+}
+
+// LoadAZCredentialClientCredentials reads Azure client credentials from
+// the AZURE_CLIENT_ID, AZURE_CLIENT_SECRET and AZURE_TENANT_ID environment
+// variables. ErrCredentialsNotFound is returned if none is set.
+func LoadAZCredentialClientCredentials() (*AZCredentialClientCredentials, error) {
+	vars := []string{"AZURE_CLIENT_ID", "AZURE_CLIENT_SECRET", "AZURE_TENANT_ID"}
+
+	values := make([]string, 0, len(vars))
+	var missing []string
+	for _, v := range vars {
+		value := os.Getenv(v)
+		if value == "" {
+			missing = append(missing, v)
+		}
+		values = append(values, value)
+	}
+
+	switch len(missing) {
+	case 0:
+		return NewAZCredentialClientCredentials(values[0], values[1], values[2]), nil
+	case len(vars):
+		return nil, fmt.Errorf("no Azure client credentials in the environment: %w", ErrCredentialsNotFound)
+	default:
+		return nil, fmt.Errorf("incomplete Azure client credentials, %s not set", strings.Join(missing, ", "))
+	}
+}
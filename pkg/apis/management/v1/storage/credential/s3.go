@@ -1,6 +1,9 @@
 package credential
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+)
 
 type (
 	S3CredentialType      string
@@ -102,6 +105,14 @@ func (c *S3CredentialAccessKey) AsCredential() StorageCredential {
 	return StorageCredential{Settings: c}
 }
 
+// Validate checks the access key and secret are set.
+func (c *S3CredentialAccessKey) Validate() error {
+	return errors.Join(
+		validateRequired("aws-access-key-id", c.AWSAccessKeyID),
+		validateRequired("aws-secret-access-key", c.AWSSecretAccessKey),
+	)
+}
+
 func (c S3CredentialAccessKey) MarshalJSON() ([]byte, error) {
 	type Alias S3CredentialAccessKey
 	aux := struct {
@@ -128,6 +139,11 @@ func (c *S3CredentialSystemIdentity) AsCredential() StorageCredential {
 	return StorageCredential{Settings: c}
 }
 
+// Validate checks the external ID is set.
+func (c *S3CredentialSystemIdentity) Validate() error {
+	return validateRequired("external-id", c.ExternalID)
+}
+
 func (c S3CredentialSystemIdentity) MarshalJSON() ([]byte, error) {
 	type Alias S3CredentialSystemIdentity
 	aux := struct {
@@ -154,6 +170,16 @@ func (c *CloudflareR2Credential) AsCredential() StorageCredential {
 	return StorageCredential{Settings: c}
 }
 
+// Validate checks all the Cloudflare R2 fields are set.
+func (c *CloudflareR2Credential) Validate() error {
+	return errors.Join(
+		validateRequired("access-key-id", c.AccessKeyID),
+		validateRequired("secret-access-key", c.SecretAccessKey),
+		validateRequired("account-id", c.AccountID),
+		validateRequired("token", c.Token),
+	)
+}
+
 func (c CloudflareR2Credential) MarshalJSON() ([]byte, error) {
 	type Alias CloudflareR2Credential
 	aux := struct {
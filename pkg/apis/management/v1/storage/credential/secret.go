@@ -0,0 +1,217 @@
+package credential
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+)
+
+type (
+	// SecretResolver resolves the secret references of a scheme.
+	SecretResolver interface {
+		// Resolve returns the secret referenced by ref, the reference
+		// without its scheme, e.g. AWS_SECRET_ACCESS_KEY for
+		// ${env:AWS_SECRET_ACCESS_KEY}.
+		Resolve(ctx context.Context, ref string) (string, error)
+	}
+
+	// SecretResolverFunc is a function implementing SecretResolver.
+	SecretResolverFunc func(ctx context.Context, ref string) (string, error)
+
+	// SecretResolvers maps reference schemes to their resolver.
+	SecretResolvers map[string]SecretResolver
+
+	// ExecSecretResolver resolves references by running them as shell
+	// commands, e.g. ${exec:vault kv get -field=secret secret/lakekeeper},
+	// and returns their standard output.
+	ExecSecretResolver struct {
+		// Stderr receives the standard error of the commands.
+		// It is discarded if nil.
+		Stderr io.Writer
+	}
+)
+
+// secretReferenceRegexp matches ${scheme:reference}. References
+// cannot contain a closing brace.
+var secretReferenceRegexp = regexp.MustCompile(`\$\{([a-z][a-z0-9-]*):([^}]*)\}`)
+
+var (
+	// EnvSecretResolver resolves ${env:NAME} references
+	// to the value of the environment variable NAME.
+	EnvSecretResolver = SecretResolverFunc(func(_ context.Context, ref string) (string, error) {
+		v, ok := os.LookupEnv(ref)
+		if !ok {
+			return "", errors.New("environment variable not set")
+		}
+		return v, nil
+	})
+
+	// FileSecretResolver resolves ${file:PATH} references to the
+	// content of the file at PATH, without its trailing newline.
+	FileSecretResolver = SecretResolverFunc(func(_ context.Context, ref string) (string, error) {
+		data, err := os.ReadFile(ref)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	})
+)
+
+func (f SecretResolverFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+// Resolve runs ref with sh. The output of the command is never part
+// of the returned error.
+func (r ExecSecretResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", ref)
+	cmd.Stderr = r.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("command failed: %w", err)
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+// DefaultSecretResolvers returns the env and file resolvers.
+// The exec resolver is not included, as it runs arbitrary commands,
+// and must be enabled explicitly.
+func DefaultSecretResolvers() SecretResolvers {
+	return SecretResolvers{
+		"env":  EnvSecretResolver,
+		"file": FileSecretResolver,
+	}
+}
+
+// ResolveSecretReferences replaces the secret references, e.g.
+// ${env:AWS_SECRET_ACCESS_KEY}, in the string values of the JSON
+// document data, at any depth. "$${" is kept as a literal "${".
+//
+// When a value is a single reference resolving to a JSON object,
+// e.g. ${file:/run/secrets/gcs.json}, the object replaces the string.
+//
+// References that cannot be resolved are reported as joined
+// *core.ValidationError, with the path of their field. Resolved
+// values are never part of the errors.
+func ResolveSecretReferences(ctx context.Context, data []byte, resolvers SecretResolvers) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var errs []error
+	doc = resolveValue(ctx, doc, "", resolvers, &errs)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return json.Marshal(doc)
+}
+
+// LoadStorageCredential decodes a storage credential from r,
+// resolving its secret references with resolvers.
+func LoadStorageCredential(ctx context.Context, r io.Reader, resolvers SecretResolvers) (*StorageCredential, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := ResolveSecretReferences(ctx, data, resolvers)
+	if err != nil {
+		return nil, err
+	}
+
+	var sc StorageCredential
+	if err := json.Unmarshal(resolved, &sc); err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+func resolveValue(ctx context.Context, v any, path string, resolvers SecretResolvers, errs *[]error) any {
+	switch value := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			value[k] = resolveValue(ctx, value[k], joinPath(path, k), resolvers, errs)
+		}
+		return value
+	case []any:
+		for i := range value {
+			value[i] = resolveValue(ctx, value[i], path+"["+strconv.Itoa(i)+"]", resolvers, errs)
+		}
+		return value
+	case string:
+		return resolveString(ctx, value, path, resolvers, errs)
+	default:
+		return v
+	}
+}
+
+func resolveString(ctx context.Context, s, path string, resolvers SecretResolvers, errs *[]error) any {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+
+	// Protect the escaped references from the replacement.
+	const escaped = "\x00"
+	s = strings.ReplaceAll(s, "$${", escaped)
+
+	single := secretReferenceRegexp.FindString(s) == s
+
+	resolved := secretReferenceRegexp.ReplaceAllStringFunc(s, func(ref string) string {
+		m := secretReferenceRegexp.FindStringSubmatch(ref)
+		scheme, target := m[1], m[2]
+
+		resolver, ok := resolvers[scheme]
+		if !ok {
+			*errs = append(*errs, core.NewValidationError(path, "cannot resolve %s: unknown or disabled scheme %q", ref, scheme))
+			return ""
+		}
+
+		v, err := resolver.Resolve(ctx, target)
+		if err != nil {
+			*errs = append(*errs, core.NewValidationError(path, "cannot resolve %s: %v", ref, err))
+			return ""
+		}
+		return v
+	})
+
+	resolved = strings.ReplaceAll(resolved, escaped, "${")
+
+	if single && strings.HasPrefix(strings.TrimSpace(resolved), "{") {
+		var obj map[string]any
+		if err := json.Unmarshal([]byte(resolved), &obj); err == nil {
+			return obj
+		}
+	}
+
+	return resolved
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
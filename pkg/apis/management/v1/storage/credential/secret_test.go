@@ -0,0 +1,73 @@
+package credential
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecretReferences(t *testing.T) {
+	t.Setenv("LK_TEST_SECRET", "s3cr3t")
+
+	keyFile := writeFile(t, "key.json", `{"type": "service_account", "project_id": "my-project"}`+"\n")
+
+	data, err := ResolveSecretReferences(t.Context(), []byte(`{
+		"type": "s3",
+		"aws-secret-access-key": "${env:LK_TEST_SECRET}",
+		"external-id": "id-${env:LK_TEST_SECRET}-$${env:LK_TEST_SECRET}",
+		"key": "${file:`+keyFile+`}",
+		"list": ["${env:LK_TEST_SECRET}", 1, true]
+	}`), DefaultSecretResolvers())
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"type": "s3",
+		"aws-secret-access-key": "s3cr3t",
+		"external-id": "id-s3cr3t-${env:LK_TEST_SECRET}",
+		"key": {"type": "service_account", "project_id": "my-project"},
+		"list": ["s3cr3t", 1, true]
+	}`, string(data))
+}
+
+func TestResolveSecretReferences_Errors(t *testing.T) {
+	t.Setenv("LK_TEST_SECRET", "s3cr3t")
+
+	resolvers := DefaultSecretResolvers()
+	resolvers["vault"] = SecretResolverFunc(func(_ context.Context, _ string) (string, error) {
+		return "", errors.New("permission denied")
+	})
+
+	_, err := ResolveSecretReferences(t.Context(), []byte(`{
+		"aws-access-key-id": "${env:LK_TEST_UNSET}",
+		"aws-secret-access-key": "${vault:secret/lakekeeper}",
+		"external-id": "${exec:echo ${env:LK_TEST_SECRET}}",
+		"token": "${env:LK_TEST_SECRET}"
+	}`), resolvers)
+	require.Error(t, err)
+
+	assert.Equal(t, `aws-access-key-id: cannot resolve ${env:LK_TEST_UNSET}: environment variable not set
+aws-secret-access-key: cannot resolve ${vault:secret/lakekeeper}: permission denied
+external-id: cannot resolve ${exec:echo ${env:LK_TEST_SECRET}: unknown or disabled scheme "exec"`, err.Error())
+	assert.NotContains(t, err.Error(), "s3cr3t")
+}
+
+func TestExecSecretResolver(t *testing.T) {
+	var stderr strings.Builder
+	resolvers := SecretResolvers{"exec": ExecSecretResolver{Stderr: &stderr}}
+
+	sc, err := LoadStorageCredential(t.Context(), strings.NewReader(`{
+		"type": "az",
+		"credential-type": "shared-access-key",
+		"key": "${exec:printf 'shared-key\n'}"
+	}`), resolvers)
+	require.NoError(t, err)
+	assert.Equal(t, NewAZCredentialSharedAccessKey("shared-key"), sc.Settings)
+
+	_, err = LoadStorageCredential(t.Context(), strings.NewReader(`{"key": "${exec:echo oops >&2; echo leaked; exit 3}"}`), resolvers)
+	require.EqualError(t, err, "key: cannot resolve ${exec:echo oops >&2; echo leaked; exit 3}: command failed: exit status 3")
+	assert.Equal(t, "oops\n", stderr.String())
+}
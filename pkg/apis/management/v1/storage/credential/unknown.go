@@ -0,0 +1,55 @@
+package credential
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// UnknownCredentialSettings represents the settings of a credential type
+// unknown to the SDK, e.g. returned by a newer Lakekeeper server.
+// The raw JSON is kept and marshaled unchanged, so the credential
+// can be sent back as is.
+type UnknownCredentialSettings struct {
+	// Family is the type of the storage credential.
+	Family CredentialFamily
+	// CredentialType is the credential-type of the storage credential.
+	CredentialType string
+	// Raw is the JSON storage credential, including its types.
+	Raw json.RawMessage
+}
+
+var _ CredentialSettings = (*UnknownCredentialSettings)(nil)
+
+// NewUnknownCredentialSettings creates settings of the given family and
+// credential type, keeping a copy of the JSON storage credential raw.
+func NewUnknownCredentialSettings(family CredentialFamily, credentialType string, raw []byte) *UnknownCredentialSettings {
+	return &UnknownCredentialSettings{
+		Family:         family,
+		CredentialType: credentialType,
+		Raw:            bytes.Clone(raw),
+	}
+}
+
+func (c *UnknownCredentialSettings) GetCredentialFamily() CredentialFamily {
+	return c.Family
+}
+
+func (c *UnknownCredentialSettings) AsCredential() StorageCredential {
+	return StorageCredential{c}
+}
+
+// Validate does not check the settings themselves, they are only
+// known by the server. StorageCredential.Validate rejects them.
+func (c *UnknownCredentialSettings) Validate() error {
+	return nil
+}
+
+func (c *UnknownCredentialSettings) MarshalJSON() ([]byte, error) {
+	if len(c.Raw) == 0 {
+		return json.Marshal(struct {
+			Type           CredentialFamily `json:"type"`
+			CredentialType string           `json:"credential-type"`
+		}{c.Family, c.CredentialType})
+	}
+	return c.Raw, nil
+}
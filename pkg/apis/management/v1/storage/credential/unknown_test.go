@@ -0,0 +1,60 @@
+package credential
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageCredential_UnmarshalUnknown(t *testing.T) {
+	for _, data := range []string{
+		`{"type":"s3","credential-type":"web-identity","role-arn":"arn:aws:iam::123456789012:role/lakekeeper"}`,
+		`{"type":"hdfs","credential-type":"kerberos","keytab":"c2VjcmV0"}`,
+	} {
+		var sc StorageCredential
+		require.NoError(t, json.Unmarshal([]byte(data), &sc))
+
+		unknown, ok := sc.AsUnknown()
+		require.True(t, ok)
+		assert.NoError(t, unknown.Validate())
+
+		// unknown credentials are decoded, but not accepted as user input
+		var verr *core.ValidationError
+		require.ErrorAs(t, sc.Validate(), &verr)
+		assert.Equal(t, "credential-type", verr.Field)
+
+		var peek struct {
+			Type           CredentialFamily `json:"type"`
+			CredentialType string           `json:"credential-type"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(data), &peek))
+		assert.Equal(t, peek.Type, unknown.GetCredentialFamily())
+		assert.Equal(t, peek.CredentialType, unknown.CredentialType)
+
+		b, err := json.Marshal(sc)
+		require.NoError(t, err)
+		assert.JSONEq(t, data, string(b))
+	}
+}
+
+func TestRegisterCredentialType(t *testing.T) {
+	RegisterCredentialType("test", "token", func(data []byte) (CredentialSettings, error) {
+		var peek struct {
+			Token string `json:"token"`
+		}
+		if err := json.Unmarshal(data, &peek); err != nil {
+			return nil, err
+		}
+		return NewUnknownCredentialSettings("test", peek.Token, data), nil
+	})
+
+	var sc StorageCredential
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"test","credential-type":"token","token":"secret"}`), &sc))
+
+	unknown, ok := sc.AsUnknown()
+	require.True(t, ok)
+	assert.Equal(t, "secret", unknown.CredentialType)
+}
@@ -0,0 +1,27 @@
+package credential
+
+import (
+	"strings"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+)
+
+// Validate checks the settings of the credential. Settings of a
+// credential type unknown to the SDK are rejected: they are accepted
+// when decoded from a server response, not when set by the user.
+func (sc StorageCredential) Validate() error {
+	if sc.Settings == nil {
+		return core.NewValidationError("type", "credential settings must be set")
+	}
+	if unknown, ok := sc.AsUnknown(); ok {
+		return core.NewValidationError("credential-type", "unknown %s credential type %q", unknown.Family, unknown.CredentialType)
+	}
+	return sc.Settings.Validate()
+}
+
+func validateRequired(field, v string) error {
+	if strings.TrimSpace(v) == "" {
+		return core.NewValidationError(field, "must not be empty")
+	}
+	return nil
+}
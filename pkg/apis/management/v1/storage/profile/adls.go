@@ -2,6 +2,7 @@ package profile
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/baptistegh/go-lakekeeper/pkg/core"
 )
@@ -90,6 +91,35 @@ func (sp *ADLSStorageSettings) AsProfile() StorageProfile {
 	return StorageProfile{sp}
 }
 
+// Validate checks the ADLS storage settings client side: account and
+// filesystem naming rules, authority host URL, host and key prefix.
+func (sp *ADLSStorageSettings) Validate() error {
+	var errs []error
+
+	switch {
+	case sp.AccountName == "":
+		errs = append(errs, core.NewValidationError("account-name", "must not be empty"))
+	case !adlsAccountRegexp.MatchString(sp.AccountName):
+		errs = append(errs, core.NewValidationError("account-name", "%q must be 3 to 24 lowercase letters and numbers", sp.AccountName))
+	}
+
+	switch {
+	case sp.Filesystem == "":
+		errs = append(errs, core.NewValidationError("filesystem", "must not be empty"))
+	case len(sp.Filesystem) < 3 || len(sp.Filesystem) > 63 || !adlsFilesystemRegexp.MatchString(sp.Filesystem):
+		errs = append(errs, core.NewValidationError("filesystem", "%q must be 3 to 63 lowercase letters, numbers and single hyphens, starting and ending with a letter or number", sp.Filesystem))
+	}
+
+	errs = append(errs,
+		validateURL("authority-host", sp.AuthorityHost),
+		validateHost("host", sp.Host),
+		validateKeyPrefix(sp.KeyPrefix),
+		validatePositive("sas-token-validity-seconds", sp.SASTokenValiditySeconds),
+	)
+
+	return errors.Join(errs...)
+}
+
 func (sp ADLSStorageSettings) MarshalJSON() ([]byte, error) {
 	type Alias ADLSStorageSettings
 	aux := struct {
@@ -2,6 +2,7 @@ package profile
 
 import (
 	"encoding/json"
+	"errors"
 )
 
 type (
@@ -47,6 +48,15 @@ func (sp *GCSStorageSettings) AsProfile() StorageProfile {
 	return StorageProfile{sp}
 }
 
+// Validate checks the GCS storage settings client side: bucket naming
+// rules and key prefix.
+func (sp *GCSStorageSettings) Validate() error {
+	return errors.Join(
+		validateGCSBucket(sp.Bucket),
+		validateKeyPrefix(sp.KeyPrefix),
+	)
+}
+
 func (sp GCSStorageSettings) MarshalJSON() ([]byte, error) {
 	type Alias GCSStorageSettings
 	aux := struct {
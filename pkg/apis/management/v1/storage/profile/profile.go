@@ -2,7 +2,9 @@ package profile
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 )
 
 type (
@@ -12,9 +14,16 @@ type (
 
 	StorageFamily string
 
+	// StorageSettingsDecoder decodes the settings of a storage family
+	// from a JSON storage profile.
+	StorageSettingsDecoder func(data []byte) (StorageSettings, error)
+
 	StorageSettings interface {
 		GetStorageFamily() StorageFamily
 		AsProfile() StorageProfile
+		// Validate checks the settings client side, the errors
+		// are *core.ValidationError carrying the invalid field.
+		Validate() error
 
 		json.Marshaler
 	}
@@ -31,8 +40,44 @@ var (
 	_ StorageSettings = (*ADLSStorageSettings)(nil)
 	_ StorageSettings = (*GCSStorageSettings)(nil)
 	_ StorageSettings = (*S3StorageSettings)(nil)
+	_ StorageSettings = (*UnknownStorageSettings)(nil)
+)
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[StorageFamily]StorageSettingsDecoder{
+		StorageFamilyADLS: decodeSettings[ADLSStorageSettings],
+		StorageFamilyGCS:  decodeSettings[GCSStorageSettings],
+		StorageFamilyS3:   decodeSettings[S3StorageSettings],
+	}
 )
 
+// RegisterStorageFamily registers the decoder of the settings of a
+// storage family, e.g. supported by a newer Lakekeeper server, used by
+// StorageProfile.UnmarshalJSON. It replaces the decoder of a family
+// already registered, including the built-in ones.
+func RegisterStorageFamily(family StorageFamily, decode StorageSettingsDecoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+
+	decoders[family] = decode
+}
+
+// decodeSettings decodes data as the settings type T.
+func decodeSettings[T any, PT interface {
+	*T
+	StorageSettings
+}](data []byte) (StorageSettings, error) {
+	var cfg T
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return PT(&cfg), nil
+}
+
+// UnmarshalJSON decodes the settings with the decoder registered for
+// their type. Settings of an unregistered type are decoded as
+// *UnknownStorageSettings, so profiles of newer servers can be read.
 func (sc *StorageProfile) UnmarshalJSON(data []byte) error {
 	var peek struct {
 		Type string `json:"type"`
@@ -41,28 +86,24 @@ func (sc *StorageProfile) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("invalid JSON: %w", err)
 	}
 
-	switch peek.Type {
-	case "s3":
-		var cfg S3StorageSettings
-		if err := json.Unmarshal(data, &cfg); err != nil {
-			return err
-		}
-		sc.StorageSettings = &cfg
-	case "adls":
-		var cfg ADLSStorageSettings
-		if err := json.Unmarshal(data, &cfg); err != nil {
-			return err
-		}
-		sc.StorageSettings = &cfg
-	case "gcs":
-		var cfg GCSStorageSettings
-		if err := json.Unmarshal(data, &cfg); err != nil {
-			return err
-		}
-		sc.StorageSettings = &cfg
-	default:
-		return fmt.Errorf("unsupported storage type: %s", peek.Type)
+	if peek.Type == "" {
+		return errors.New("missing storage type")
 	}
+
+	decodersMu.RLock()
+	decode, ok := decoders[StorageFamily(peek.Type)]
+	decodersMu.RUnlock()
+
+	if !ok {
+		sc.StorageSettings = NewUnknownStorageSettings(StorageFamily(peek.Type), data)
+		return nil
+	}
+
+	settings, err := decode(data)
+	if err != nil {
+		return err
+	}
+	sc.StorageSettings = settings
 	return nil
 }
 
@@ -85,3 +126,9 @@ func (sc StorageProfile) AsGCS() (*GCSStorageSettings, bool) {
 	cfg, ok := sc.StorageSettings.(*GCSStorageSettings)
 	return cfg, ok
 }
+
+// AsUnknown returns the settings of a storage family unknown to the SDK.
+func (sc StorageProfile) AsUnknown() (*UnknownStorageSettings, bool) {
+	cfg, ok := sc.StorageSettings.(*UnknownStorageSettings)
+	return cfg, ok
+}
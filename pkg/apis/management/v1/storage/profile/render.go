@@ -0,0 +1,304 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type (
+	// ProfileDiff lists the settings that differ between two
+	// storage profiles, see DiffProfiles.
+	ProfileDiff struct {
+		Changes []ProfileChange `json:"changes"`
+	}
+
+	// ProfileChange is a setting of a storage profile that changes.
+	// From and To are empty when the setting is not set.
+	ProfileChange struct {
+		Field string `json:"field"`
+		From  string `json:"from,omitempty"`
+		To    string `json:"to,omitempty"`
+		// Risk explains why the change can break existing tables,
+		// it is empty if the change is safe.
+		Risk string `json:"risk,omitempty"`
+	}
+
+	// setting is a setting of a storage profile, as rendered.
+	setting struct {
+		field string
+		value string
+	}
+)
+
+// settingOrder is the order the settings of each family are rendered in.
+// Other settings follow in alphabetical order.
+var settingOrder = map[StorageFamily][]string{
+	StorageFamilyS3: {
+		"bucket", "key-prefix", "region", "endpoint", "flavor", "path-style-access",
+		"sts-enabled", "sts-role-arn", "assume-role-arn", "sts-token-validity-seconds",
+		"aws-kms-key-arn", "remote-signing-url-style", "push-s3-delete-disabled",
+		"allow-alternative-protocols",
+	},
+	StorageFamilyADLS: {
+		"account-name", "filesystem", "key-prefix", "host", "authority-host",
+		"sas-token-validity-seconds", "allow-alternative-protocols",
+	},
+	StorageFamilyGCS: {"bucket", "key-prefix"},
+}
+
+// settingLabels are the labels of the rendered settings,
+// the JSON field name is used for the others.
+var settingLabels = map[string]string{
+	"type":                        "Type",
+	"bucket":                      "Bucket",
+	"key-prefix":                  "Key prefix",
+	"region":                      "Region",
+	"endpoint":                    "Endpoint",
+	"flavor":                      "Flavor",
+	"path-style-access":           "Path style access",
+	"sts-enabled":                 "STS enabled",
+	"sts-role-arn":                "STS role ARN",
+	"assume-role-arn":             "Assume role ARN",
+	"sts-token-validity-seconds":  "STS token validity (s)",
+	"aws-kms-key-arn":             "KMS key ARN",
+	"remote-signing-url-style":    "Remote signing URL style",
+	"push-s3-delete-disabled":     "Push S3 delete disabled",
+	"allow-alternative-protocols": "Alternative protocols",
+	"account-name":                "Account name",
+	"filesystem":                  "Filesystem",
+	"host":                        "Host",
+	"authority-host":              "Authority host",
+	"sas-token-validity-seconds":  "SAS token validity (s)",
+}
+
+const (
+	orphanRisk       = "existing tables stay in the previous location and are orphaned"
+	reachabilityRisk = "existing tables must be reachable with the new setting"
+)
+
+// String returns the settings of the profile as a human-readable block,
+// one setting per line, including the defaults applied by the SDK.
+func (sc StorageProfile) String() string {
+	settings, err := profileSettings(sc)
+	if err != nil {
+		return fmt.Sprintf("invalid storage profile: %v\n", err)
+	}
+	if settings == nil {
+		return "No storage profile\n"
+	}
+
+	width := 0
+	for _, s := range settings {
+		width = max(width, len(label(s.field)))
+	}
+
+	var b strings.Builder
+	for _, s := range settings {
+		fmt.Fprintf(&b, "%-*s  %s\n", width+1, label(s.field)+":", s.value)
+	}
+	return b.String()
+}
+
+// DiffProfiles compares the settings of two storage profiles, including
+// the defaults applied by the SDK. Changes that can break existing
+// tables, like a bucket or key prefix change orphaning them, carry a risk.
+//
+// The settings missing from a profile, e.g. decoded from a file, take
+// their default value, so that a profile returned by the server, with
+// its defaults filled, and the same profile without them do not differ.
+func DiffProfiles(from, to StorageProfile) (*ProfileDiff, error) {
+	fromSettings, err := profileSettings(from)
+	if err != nil {
+		return nil, err
+	}
+	toSettings, err := profileSettings(to)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &ProfileDiff{Changes: []ProfileChange{}}
+
+	values := func(sc StorageProfile, settings []setting) map[string]string {
+		m := defaultValues(sc)
+		for _, s := range settings {
+			m[s.field] = s.value
+		}
+		return m
+	}
+	fromValues, toValues := values(from, fromSettings), values(to, toSettings)
+
+	// Follow the order of the new profile, then of the removed settings.
+	var fields []string
+	for _, s := range slices.Concat(toSettings, fromSettings) {
+		if !slices.Contains(fields, s.field) {
+			fields = append(fields, s.field)
+		}
+	}
+
+	for _, f := range fields {
+		old, updated := fromValues[f], toValues[f]
+		if f == "key-prefix" && NormalizeKeyPrefix(old) == NormalizeKeyPrefix(updated) {
+			continue
+		}
+		if old == updated {
+			continue
+		}
+		d.Changes = append(d.Changes, ProfileChange{
+			Field: f,
+			From:  old,
+			To:    updated,
+			Risk:  risk(f, old, updated),
+		})
+	}
+
+	return d, nil
+}
+
+// defaultValues returns the default settings of the storage family
+// of a profile, as set by its constructor.
+func defaultValues(sc StorageProfile) map[string]string {
+	m := map[string]string{}
+	if sc.StorageSettings == nil {
+		return m
+	}
+
+	var defaults StorageSettings
+	switch sc.StorageSettings.GetStorageFamily() {
+	case StorageFamilyS3:
+		defaults = NewS3StorageSettings("", "")
+	case StorageFamilyADLS:
+		defaults = NewADLSStorageSettings("", "")
+	default:
+		return m
+	}
+
+	settings, _ := profileSettings(defaults.AsProfile())
+	for _, s := range settings {
+		if s.value != "" {
+			m[s.field] = s.value
+		}
+	}
+	return m
+}
+
+// risk returns why changing a setting can break existing tables.
+func risk(field, from, to string) string {
+	switch field {
+	case "type":
+		return "the storage family changes, " + orphanRisk
+	case "bucket", "filesystem", "account-name", "key-prefix":
+		return orphanRisk
+	case "region", "endpoint", "host", "flavor":
+		return reachabilityRisk
+	case "sts-enabled":
+		if from == "true" && to != "true" {
+			return "clients relying on vended credentials lose access"
+		}
+	}
+	return ""
+}
+
+// IsEmpty reports whether the profiles are the same.
+func (d *ProfileDiff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// Risky reports whether any change can break existing tables.
+func (d *ProfileDiff) Risky() bool {
+	return slices.ContainsFunc(d.Changes, func(c ProfileChange) bool { return c.Risk != "" })
+}
+
+// String returns a human-readable description of the changes,
+// with the risky ones flagged.
+func (d *ProfileDiff) String() string {
+	if d.IsEmpty() {
+		return "No changes to the storage profile\n"
+	}
+
+	var b strings.Builder
+	for _, c := range d.Changes {
+		switch {
+		case c.From == "":
+			fmt.Fprintf(&b, "+ %s: %s\n", label(c.Field), c.To)
+		case c.To == "":
+			fmt.Fprintf(&b, "- %s: %s\n", label(c.Field), c.From)
+		default:
+			fmt.Fprintf(&b, "~ %s: %s -> %s\n", label(c.Field), c.From, c.To)
+		}
+		if c.Risk != "" {
+			fmt.Fprintf(&b, "  WARNING: %s\n", c.Risk)
+		}
+	}
+	return b.String()
+}
+
+func label(field string) string {
+	if l, ok := settingLabels[field]; ok {
+		return l
+	}
+	return field
+}
+
+// profileSettings returns the settings of a profile from its JSON
+// document, the type first, in the order of settingOrder.
+func profileSettings(sc StorageProfile) ([]setting, error) {
+	if sc.StorageSettings == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(sc.StorageSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc map[string]any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	delete(doc, "type")
+
+	fields := make([]string, 0, len(doc))
+	for f := range doc {
+		fields = append(fields, f)
+	}
+
+	order := settingOrder[sc.StorageSettings.GetStorageFamily()]
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := slices.Index(order, fields[i]), slices.Index(order, fields[j])
+		switch {
+		case a >= 0 && b >= 0:
+			return a < b
+		case a >= 0 || b >= 0:
+			return a >= 0
+		default:
+			return fields[i] < fields[j]
+		}
+	})
+
+	settings := []setting{{field: "type", value: string(sc.StorageSettings.GetStorageFamily())}}
+	for _, f := range fields {
+		settings = append(settings, setting{field: f, value: formatValue(doc[f])})
+	}
+	return settings, nil
+}
+
+func formatValue(v any) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	default:
+		data, _ := json.Marshal(value)
+		return string(data)
+	}
+}
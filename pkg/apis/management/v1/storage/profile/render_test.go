@@ -0,0 +1,111 @@
+package profile
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageProfile_String(t *testing.T) {
+	sp := NewS3StorageSettings("lake", "eu-west-1",
+		WithEndpoint("http://minio:9000"),
+		WithFlavor(S3CompatFlavor),
+		WithS3KeyPrefix("warehouse"),
+	).AsProfile()
+
+	expected := `Type:                      s3
+Bucket:                    lake
+Key prefix:                warehouse
+Region:                    eu-west-1
+Endpoint:                  http://minio:9000
+Flavor:                    s3-compat
+STS enabled:               false
+STS token validity (s):    3600
+Remote signing URL style:  auto
+Push S3 delete disabled:   true
+`
+	assert.Equal(t, expected, sp.String())
+
+	adls := NewADLSStorageSettings("account", "lake", WithHost("dfs.example.com")).AsProfile()
+	assert.Contains(t, adls.String(), "Host:                    dfs.example.com\n")
+
+	unknown := NewUnknownStorageSettings("hdfs", []byte(`{"type":"hdfs","namenode":"hdfs://namenode:8020","replication":3}`)).AsProfile()
+	assert.Equal(t, "Type:         hdfs\nnamenode:     hdfs://namenode:8020\nreplication:  3\n", unknown.String())
+
+	assert.Equal(t, "No storage profile\n", StorageProfile{}.String())
+}
+
+func TestDiffProfiles(t *testing.T) {
+	from := NewS3StorageSettings("lake", "eu-west-1",
+		WithS3KeyPrefix("/warehouse/"),
+		WithSTSEnabled(),
+		WithSTSRoleARN("arn:aws:iam::123456789012:role/lakekeeper"),
+	).AsProfile()
+
+	t.Run("safe", func(t *testing.T) {
+		to := NewS3StorageSettings("lake", "eu-west-1",
+			WithS3KeyPrefix("warehouse"),
+			WithSTSEnabled(),
+			WithSTSRoleARN("arn:aws:iam::123456789012:role/lakekeeper"),
+			WithSTSTokenValiditySeconds(7200),
+		).AsProfile()
+
+		d, err := DiffProfiles(from, to)
+		require.NoError(t, err)
+		assert.Equal(t, []ProfileChange{
+			{Field: "sts-token-validity-seconds", From: "3600", To: "7200"},
+		}, d.Changes)
+		assert.False(t, d.Risky())
+		assert.Equal(t, "~ STS token validity (s): 3600 -> 7200\n", d.String())
+	})
+
+	t.Run("risky", func(t *testing.T) {
+		to := NewS3StorageSettings("lake-v2", "eu-west-1",
+			WithS3KeyPrefix("tables"),
+			WithEndpoint("http://minio:9000"),
+		).AsProfile()
+
+		d, err := DiffProfiles(from, to)
+		require.NoError(t, err)
+		assert.True(t, d.Risky())
+		assert.Equal(t, `~ Bucket: lake -> lake-v2
+  WARNING: existing tables stay in the previous location and are orphaned
+~ Key prefix: /warehouse/ -> tables
+  WARNING: existing tables stay in the previous location and are orphaned
++ Endpoint: http://minio:9000
+  WARNING: existing tables must be reachable with the new setting
+~ STS enabled: true -> false
+  WARNING: clients relying on vended credentials lose access
+- STS role ARN: arn:aws:iam::123456789012:role/lakekeeper
+`, d.String())
+	})
+
+	t.Run("family", func(t *testing.T) {
+		d, err := DiffProfiles(from, NewGCSStorageSettings("lake").AsProfile())
+		require.NoError(t, err)
+		require.NotEmpty(t, d.Changes)
+		assert.Equal(t, ProfileChange{Field: "type", From: "s3", To: "gcs", Risk: "the storage family changes, " + orphanRisk}, d.Changes[0])
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		// as returned by the server, and as read from a file
+		var server, file StorageProfile
+		require.NoError(t, json.Unmarshal([]byte(`{"type":"s3","bucket":"lake","region":"eu-west-1","sts-enabled":false,"flavor":"aws","sts-token-validity-seconds":3600,"push-s3-delete-disabled":true,"remote-signing-url-style":"auto"}`), &server))
+		require.NoError(t, json.Unmarshal([]byte(`{"type":"s3","bucket":"lake","region":"eu-west-1","sts-enabled":false,"flavor":"s3-compat"}`), &file))
+
+		d, err := DiffProfiles(server, file)
+		require.NoError(t, err)
+		assert.Equal(t, []ProfileChange{
+			{Field: "flavor", From: "aws", To: "s3-compat", Risk: reachabilityRisk},
+		}, d.Changes)
+	})
+
+	t.Run("same", func(t *testing.T) {
+		d, err := DiffProfiles(from, from)
+		require.NoError(t, err)
+		assert.True(t, d.IsEmpty())
+		assert.Equal(t, "No changes to the storage profile\n", d.String())
+	})
+}
@@ -2,6 +2,7 @@ package profile
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/baptistegh/go-lakekeeper/pkg/core"
 )
@@ -187,6 +188,43 @@ func (sp *S3StorageSettings) AsProfile() StorageProfile {
 	return StorageProfile{StorageSettings: sp}
 }
 
+// Validate checks the S3 storage settings client side: bucket naming
+// rules, region format, ARN syntax, endpoint URL and key prefix.
+// The role ARNs and the region are only checked for the AWS flavor.
+func (sp *S3StorageSettings) Validate() error {
+	errs := []error{
+		validateS3Bucket(sp.Bucket),
+		validateURL("endpoint", sp.Endpoint),
+		validateKeyPrefix(sp.KeyPrefix),
+		validatePositive("sts-token-validity-seconds", sp.STSTokenValiditySeconds),
+	}
+
+	aws := sp.Flavor == nil || *sp.Flavor == AWSFlavor
+	if sp.Flavor != nil && *sp.Flavor != AWSFlavor && *sp.Flavor != S3CompatFlavor {
+		errs = append(errs, core.NewValidationError("flavor", "unknown flavor %q, must be %s or %s", *sp.Flavor, AWSFlavor, S3CompatFlavor))
+	}
+
+	switch {
+	case sp.Region == "":
+		errs = append(errs, core.NewValidationError("region", "must not be empty"))
+	case aws && sp.Endpoint == nil && !awsRegionRegexp.MatchString(sp.Region):
+		errs = append(errs, core.NewValidationError("region", "%q is not a valid AWS region, e.g. eu-central-1", sp.Region))
+	}
+
+	if aws {
+		errs = append(errs,
+			validateARN("assume-role-arn", sp.AssumeRoleARN, iamRoleARNRegexp),
+			validateARN("sts-role-arn", sp.STSRoleARN, iamRoleARNRegexp),
+		)
+		if sp.STSEnabled && sp.AssumeRoleARN == nil && sp.STSRoleARN == nil {
+			errs = append(errs, core.NewValidationError("sts-enabled", "requires assume-role-arn or sts-role-arn to be set"))
+		}
+	}
+	errs = append(errs, validateARN("aws-kms-key-arn", sp.AWSKMSKeyARN, kmsKeyARNRegexp))
+
+	return errors.Join(errs...)
+}
+
 func (sp S3StorageSettings) MarshalJSON() ([]byte, error) {
 	type Alias S3StorageSettings
 	aux := struct {
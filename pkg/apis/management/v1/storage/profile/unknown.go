@@ -0,0 +1,49 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// UnknownStorageSettings represents the settings of a storage family
+// unknown to the SDK, e.g. returned by a newer Lakekeeper server.
+// The raw JSON is kept and marshaled unchanged, so the profile
+// can be sent back as is.
+type UnknownStorageSettings struct {
+	// Family is the type of the storage profile.
+	Family StorageFamily
+	// Raw is the JSON storage profile, including its type.
+	Raw json.RawMessage
+}
+
+// NewUnknownStorageSettings creates settings of the given family,
+// keeping a copy of the JSON storage profile raw.
+func NewUnknownStorageSettings(family StorageFamily, raw []byte) *UnknownStorageSettings {
+	return &UnknownStorageSettings{
+		Family: family,
+		Raw:    bytes.Clone(raw),
+	}
+}
+
+func (sp *UnknownStorageSettings) GetStorageFamily() StorageFamily {
+	return sp.Family
+}
+
+func (sp *UnknownStorageSettings) AsProfile() StorageProfile {
+	return StorageProfile{sp}
+}
+
+// Validate does not check the settings themselves, they are only
+// known by the server. StorageProfile.Validate rejects them.
+func (sp *UnknownStorageSettings) Validate() error {
+	return nil
+}
+
+func (sp *UnknownStorageSettings) MarshalJSON() ([]byte, error) {
+	if len(sp.Raw) == 0 {
+		return json.Marshal(struct {
+			Type StorageFamily `json:"type"`
+		}{sp.Family})
+	}
+	return sp.Raw, nil
+}
@@ -0,0 +1,69 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageProfile_UnmarshalUnknown(t *testing.T) {
+	data := `{"type":"hdfs","namenode":"hdfs://namenode:8020","key-prefix":"warehouse"}`
+
+	var sp StorageProfile
+	require.NoError(t, json.Unmarshal([]byte(data), &sp))
+
+	unknown, ok := sp.AsUnknown()
+	require.True(t, ok)
+	assert.Equal(t, StorageFamily("hdfs"), sp.StorageSettings.GetStorageFamily())
+	assert.NoError(t, sp.StorageSettings.Validate())
+	// unknown profiles are decoded, but not accepted as user input
+	assert.EqualError(t, sp.Validate(), `type: unknown storage family "hdfs", must be one of s3, adls or gcs`)
+
+	b, err := json.Marshal(sp)
+	require.NoError(t, err)
+	assert.JSONEq(t, data, string(b))
+	assert.JSONEq(t, data, string(unknown.Raw))
+}
+
+func TestStorageProfile_UnmarshalMissingType(t *testing.T) {
+	var sp StorageProfile
+	require.EqualError(t, json.Unmarshal([]byte(`{"bucket":"lake"}`), &sp), "missing storage type")
+}
+
+func TestUnknownStorageSettings_Marshal(t *testing.T) {
+	b, err := json.Marshal(NewUnknownStorageSettings("hdfs", nil).AsProfile())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"hdfs"}`, string(b))
+}
+
+type testStorageSettings struct {
+	UnknownStorageSettings
+	Namenode string `json:"namenode"`
+}
+
+func TestRegisterStorageFamily(t *testing.T) {
+	RegisterStorageFamily("test-registered", func(data []byte) (StorageSettings, error) {
+		var cfg testStorageSettings
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, err
+		}
+		cfg.Family = "test-registered"
+		cfg.Raw = data
+		return &cfg, nil
+	})
+	RegisterStorageFamily("test-failing", func([]byte) (StorageSettings, error) {
+		return nil, errors.New("invalid settings")
+	})
+
+	var sp StorageProfile
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"test-registered","namenode":"hdfs://namenode:8020"}`), &sp))
+
+	settings, ok := sp.StorageSettings.(*testStorageSettings)
+	require.True(t, ok)
+	assert.Equal(t, "hdfs://namenode:8020", settings.Namenode)
+
+	require.EqualError(t, json.Unmarshal([]byte(`{"type":"test-failing"}`), &sp), "invalid settings")
+}
@@ -0,0 +1,151 @@
+package profile
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+)
+
+var (
+	s3BucketRegexp   = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	awsRegionRegexp  = regexp.MustCompile(`^[a-z]{2}(-gov|-iso[a-z]*)?-[a-z]+-\d+$`)
+	iamRoleARNRegexp = regexp.MustCompile(`^arn:aws[a-z-]*:iam::\d{12}:role/[\w+=,.@/-]{1,512}$`)
+	kmsKeyARNRegexp  = regexp.MustCompile(`^arn:aws[a-z-]*:kms:[a-z0-9-]+:\d{12}:(key/[\w-]+|alias/[\w/-]+)$`)
+
+	gcsBucketRegexp      = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{1,220}[a-z0-9]$`)
+	adlsAccountRegexp    = regexp.MustCompile(`^[a-z0-9]{3,24}$`)
+	adlsFilesystemRegexp = regexp.MustCompile(`^[a-z0-9](-?[a-z0-9])*$`)
+	hostRegexp           = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*(:\d+)?$`)
+)
+
+// Validate checks the storage settings of the profile. Settings of a
+// storage family unknown to the SDK are rejected: they are accepted
+// when decoded from a server response, not when set by the user.
+func (sc StorageProfile) Validate() error {
+	if sc.StorageSettings == nil {
+		return core.NewValidationError("type", "storage settings must be set")
+	}
+	if unknown, ok := sc.AsUnknown(); ok {
+		return core.NewValidationError("type", "unknown storage family %q, must be one of %s, %s or %s", unknown.Family, StorageFamilyS3, StorageFamilyADLS, StorageFamilyGCS)
+	}
+	return sc.StorageSettings.Validate()
+}
+
+// NormalizeKeyPrefix returns prefix without leading, trailing
+// or duplicated slashes, nor "." segments.
+func NormalizeKeyPrefix(prefix string) string {
+	var segments []string
+	for s := range strings.SplitSeq(prefix, "/") {
+		if s == "" || s == "." {
+			continue
+		}
+		segments = append(segments, s)
+	}
+	return strings.Join(segments, "/")
+}
+
+func validateKeyPrefix(prefix *string) error {
+	if prefix == nil {
+		return nil
+	}
+
+	if slices.Contains(strings.Split(*prefix, "/"), "..") {
+		return core.NewValidationError("key-prefix", "must not contain '..' segments")
+	}
+
+	if n := NormalizeKeyPrefix(*prefix); n != *prefix {
+		return core.NewValidationError("key-prefix", "must be normalized, use %q instead of %q", n, *prefix)
+	}
+	return nil
+}
+
+func validateS3Bucket(bucket string) error {
+	switch {
+	case bucket == "":
+		return core.NewValidationError("bucket", "must not be empty")
+	case !s3BucketRegexp.MatchString(bucket):
+		return core.NewValidationError("bucket", "%q must be 3 to 63 lowercase letters, numbers, dots and hyphens, starting and ending with a letter or number", bucket)
+	case strings.Contains(bucket, ".."):
+		return core.NewValidationError("bucket", "%q must not contain two adjacent periods", bucket)
+	case net.ParseIP(bucket) != nil:
+		return core.NewValidationError("bucket", "%q must not be formatted as an IP address", bucket)
+	case strings.HasPrefix(bucket, "xn--"), strings.HasPrefix(bucket, "sthree-"):
+		return core.NewValidationError("bucket", "%q uses a reserved prefix", bucket)
+	case strings.HasSuffix(bucket, "-s3alias"), strings.HasSuffix(bucket, "--ol-s3"):
+		return core.NewValidationError("bucket", "%q uses a reserved suffix", bucket)
+	}
+	return nil
+}
+
+func validateGCSBucket(bucket string) error {
+	switch {
+	case bucket == "":
+		return core.NewValidationError("bucket", "must not be empty")
+	case !gcsBucketRegexp.MatchString(bucket):
+		return core.NewValidationError("bucket", "%q must be 3 to 222 lowercase letters, numbers, dots, hyphens and underscores, starting and ending with a letter or number", bucket)
+	case !strings.Contains(bucket, ".") && len(bucket) > 63:
+		return core.NewValidationError("bucket", "%q must not be longer than 63 characters", bucket)
+	case net.ParseIP(bucket) != nil:
+		return core.NewValidationError("bucket", "%q must not be formatted as an IP address", bucket)
+	case strings.HasPrefix(bucket, "goog"), strings.Contains(bucket, "google"):
+		return core.NewValidationError("bucket", "%q must not begin with goog nor contain google", bucket)
+	}
+
+	for c := range strings.SplitSeq(bucket, ".") {
+		if c == "" || len(c) > 63 {
+			return core.NewValidationError("bucket", "%q dot-separated components must be 1 to 63 characters", bucket)
+		}
+	}
+	return nil
+}
+
+func validateARN(field string, arn *string, re *regexp.Regexp) error {
+	if arn == nil {
+		return nil
+	}
+	if !re.MatchString(*arn) {
+		return core.NewValidationError(field, "%q is not a valid ARN", *arn)
+	}
+	return nil
+}
+
+// validateURL checks an endpoint is an absolute http(s) URL.
+func validateURL(field string, v *string) error {
+	if v == nil {
+		return nil
+	}
+
+	u, err := url.Parse(*v)
+	switch {
+	case err != nil:
+		return core.NewValidationError(field, "%q is not a valid URL", *v)
+	case u.Scheme != "http" && u.Scheme != "https":
+		return core.NewValidationError(field, "%q must use the http or https scheme", *v)
+	case u.Host == "":
+		return core.NewValidationError(field, "%q must contain a host", *v)
+	case u.RawQuery != "" || u.Fragment != "":
+		return core.NewValidationError(field, "%q must not contain a query or a fragment", *v)
+	}
+	return nil
+}
+
+func validateHost(field string, v *string) error {
+	if v == nil {
+		return nil
+	}
+	if !hostRegexp.MatchString(*v) {
+		return core.NewValidationError(field, "%q is not a valid host name, it must not contain a scheme nor a path", *v)
+	}
+	return nil
+}
+
+func validatePositive(field string, v *int64) error {
+	if v != nil && *v <= 0 {
+		return core.NewValidationError(field, "must be positive, got %d", *v)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package profile
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// invalidFields returns the field paths of the validation errors of err.
+func invalidFields(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+
+	fields := make([]string, 0, len(errs))
+	for _, e := range errs {
+		var verr *core.ValidationError
+		require.True(t, errors.As(e, &verr), "unexpected error %v", e)
+		fields = append(fields, verr.Field)
+	}
+	return fields
+}
+
+func TestS3StorageSettings_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings *S3StorageSettings
+		want     []string
+	}{
+		{
+			name:     "valid aws",
+			settings: NewS3StorageSettings("my-bucket", "eu-central-1", WithSTSEnabled(), WithAssumeRoleARN("arn:aws:iam::123456789012:role/lakekeeper"), WithAWSKMSKeyARN("arn:aws:kms:eu-central-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"), WithS3KeyPrefix("warehouse/prod")),
+		},
+		{
+			name:     "valid s3-compat",
+			settings: NewS3StorageSettings("bucket1", "local-01", WithFlavor(S3CompatFlavor), WithEndpoint("http://minio:9000/"), WithSTSEnabled()),
+		},
+		{
+			name:     "invalid bucket",
+			settings: NewS3StorageSettings("My_Bucket", "eu-central-1"),
+			want:     []string{"bucket"},
+		},
+		{
+			name:     "bucket as ip",
+			settings: NewS3StorageSettings("192.168.1.1", "eu-central-1"),
+			want:     []string{"bucket"},
+		},
+		{
+			name:     "invalid region",
+			settings: NewS3StorageSettings("bucket1", "europe"),
+			want:     []string{"region"},
+		},
+		{
+			name:     "sts without role",
+			settings: NewS3StorageSettings("bucket1", "us-east-1", WithSTSEnabled()),
+			want:     []string{"sts-enabled"},
+		},
+		{
+			name:     "invalid arns",
+			settings: NewS3StorageSettings("bucket1", "us-east-1", WithSTSEnabled(), WithSTSRoleARN("role-arn"), WithAWSKMSKeyARN("kms")),
+			want:     []string{"sts-role-arn", "aws-kms-key-arn"},
+		},
+		{
+			name:     "invalid endpoint and prefix",
+			settings: NewS3StorageSettings("bucket1", "us-east-1", WithEndpoint("minio:9000"), WithS3KeyPrefix("/warehouse//prod/")),
+			want:     []string{"endpoint", "key-prefix"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, invalidFields(t, tt.settings.Validate()))
+		})
+	}
+}
+
+func TestADLSStorageSettings_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, NewADLSStorageSettings("myaccount", "my-filesystem", WithADLSKeyPrefix("lake")).Validate())
+
+	err := NewADLSStorageSettings("My-Account", "fs--1",
+		WithAuthorityHost("login.microsoftonline.com"),
+		WithHost("https://dfs.core.windows.net"),
+		WithSASTokenValiditySeconds(0),
+	).Validate()
+	assert.Equal(t, []string{"account-name", "filesystem", "authority-host", "host", "sas-token-validity-seconds"}, invalidFields(t, err))
+}
+
+func TestGCSStorageSettings_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, NewGCSStorageSettings("my_bucket.example.com").Validate())
+
+	assert.Equal(t, []string{"bucket"}, invalidFields(t, NewGCSStorageSettings("google-data").Validate()))
+	assert.Equal(t, []string{"bucket", "key-prefix"}, invalidFields(t, NewGCSStorageSettings("", WithGCSKeyPrefix("a/../b")).Validate()))
+}
+
+func TestStorageProfile_Validate(t *testing.T) {
+	t.Parallel()
+
+	var sp StorageProfile
+	assert.Equal(t, []string{"type"}, invalidFields(t, sp.Validate()))
+}
+
+func TestNormalizeKeyPrefix(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "a/b/c", NormalizeKeyPrefix("/a//b/./c/"))
+	assert.Empty(t, NormalizeKeyPrefix("/"))
+}
@@ -2,8 +2,10 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
@@ -243,10 +245,13 @@ type (
 	// Lakekeeper API docs:
 	// https://docs.lakekeeper.io/docs/nightly/api/management/#tag/warehouse/operation/list_deleted_tabulars
 	UndropTabularOptions struct {
-		Targets []struct {
-			ID   string      `json:"id"`
-			Type TabularType `json:"type"`
-		} `json:"targets"`
+		Targets []UndropTarget `json:"targets"`
+	}
+
+	// UndropTarget is a table or view to restore with UndropTabular().
+	UndropTarget = struct {
+		ID   string      `json:"id"`
+		Type TabularType `json:"type"`
 	}
 
 	// SetProtectionOptions represents protection-related methods options
@@ -282,23 +287,27 @@ type (
 		// ID of the warehouse for which the stats were collected.
 		WarehouseID string `json:"warehouse-ident"`
 		// Ordered list of warehouse statistics.
-		Stats []struct {
-			// Number of tables in the warehouse.
-			NumberOfTables int64 `json:"number-of-tables"`
-			// Number of views in the warehouse.
-			NumberOfView int64 `json:"number-of-views"`
-			// Timestamp of when these statistics are valid until.
-			// We lazily create a new statistics entry every hour, in between hours, the existing entry is being updated.
-			// If there's a change at created_at + 1 hour, a new entry is created.
-			// If there's no change, no new entry is created.
-			Timestamp string `json:"timestamp"`
-			// Timestamp of when these statistics were last updated.
-			UpdatedAt string `json:"updated-at"`
-		} `json:"stats"`
+		Stats []WarehouseStatistic `json:"stats"`
 
 		ListResponse `json:",inline"`
 	}
 
+	// WarehouseStatistic is the number of tables and views of a warehouse
+	// during a time slice of GetStatistics(), see WarehouseStatisticsSeries.
+	WarehouseStatistic = struct {
+		// Number of tables in the warehouse.
+		NumberOfTables int64 `json:"number-of-tables"`
+		// Number of views in the warehouse.
+		NumberOfView int64 `json:"number-of-views"`
+		// Timestamp of when these statistics are valid until.
+		// We lazily create a new statistics entry every hour, in between hours, the existing entry is being updated.
+		// If there's a change at created_at + 1 hour, a new entry is created.
+		// If there's no change, no new entry is created.
+		Timestamp string `json:"timestamp"`
+		// Timestamp of when these statistics were last updated.
+		UpdatedAt string `json:"updated-at"`
+	}
+
 	// GetWarehouseAllowedActionsOptions represents the GetAllowedActions() options.
 	//
 	// Only one of PrincipalUser or PrincipalRole should be set at a time.
@@ -335,6 +344,126 @@ func (w *Warehouse) IsActive() bool {
 	return w.Status == WarehouseStatusActive
 }
 
+// credentialFamilies maps a storage family to the family of
+// the credentials it accepts.
+var credentialFamilies = map[profile.StorageFamily]credential.CredentialFamily{
+	profile.StorageFamilyS3:   credential.S3CredentialFamily,
+	profile.StorageFamilyADLS: credential.AZCredentialFamily,
+	profile.StorageFamilyGCS:  credential.GCSCredentialFamily,
+}
+
+// CredentialFamilyOf returns the family of the credentials accepted
+// by a storage family, or an empty family if it is unknown.
+func CredentialFamilyOf(f profile.StorageFamily) credential.CredentialFamily {
+	return credentialFamilies[f]
+}
+
+// Validate checks the options client side, before sending them.
+// The errors are *core.ValidationError, joined, reporting the path
+// of the invalid fields, e.g. storage-profile.bucket.
+func (o *CreateWarehouseOptions) Validate() error {
+	var errs []error
+
+	if o.Name == "" {
+		errs = append(errs, core.NewValidationError("warehouse-name", "must not be empty"))
+	}
+
+	errs = append(errs, validateStorage(o.StorageProfile, &o.StorageCredential))
+
+	if o.DeleteProfile != nil {
+		errs = append(errs, core.PrefixValidationErrors("delete-profile", validateDeleteProfile(o.DeleteProfile)))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate checks the options client side, before sending them.
+// See CreateWarehouseOptions.Validate.
+func (o *UpdateStorageProfileOptions) Validate() error {
+	return validateStorage(o.StorageProfile, o.StorageCredential)
+}
+
+// validateStorage checks the storage profile, the credential, if set,
+// and that the credential matches the storage family.
+func validateStorage(sp profile.StorageProfile, sc *credential.StorageCredential) error {
+	errs := []error{core.PrefixValidationErrors("storage-profile", sp.Validate())}
+
+	if sc == nil || sc.Settings == nil {
+		return errors.Join(errs...)
+	}
+
+	errs = append(errs, core.PrefixValidationErrors("storage-credential", sc.Validate()))
+
+	if sp.StorageSettings != nil {
+		storage, cred := sp.StorageSettings.GetStorageFamily(), sc.Settings.GetCredentialFamily()
+		// Credentials of storage families registered with
+		// profile.RegisterStorageFamily are only checked by the server.
+		if want := CredentialFamilyOf(storage); want != "" && want != cred {
+			errs = append(errs, core.NewValidationError("storage-credential.type", "%s credentials cannot be used with a %s storage profile, use %s credentials", cred, storage, want))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// LoadCreateWarehouseOptions decodes a CreateWarehouseOptions JSON document
+// from r, resolving the secret references of its storage credential, e.g.
+// ${env:AWS_SECRET_ACCESS_KEY}, with resolvers.
+// See credential.ResolveSecretReferences.
+func LoadCreateWarehouseOptions(ctx context.Context, r io.Reader, resolvers credential.SecretResolvers) (*CreateWarehouseOptions, error) {
+	var opt CreateWarehouseOptions
+	if err := loadWithSecrets(ctx, r, resolvers, &opt); err != nil {
+		return nil, err
+	}
+	return &opt, nil
+}
+
+// LoadUpdateStorageProfileOptions decodes an UpdateStorageProfileOptions
+// JSON document from r, resolving the secret references of its storage
+// credential with resolvers. See credential.ResolveSecretReferences.
+func LoadUpdateStorageProfileOptions(ctx context.Context, r io.Reader, resolvers credential.SecretResolvers) (*UpdateStorageProfileOptions, error) {
+	var opt UpdateStorageProfileOptions
+	if err := loadWithSecrets(ctx, r, resolvers, &opt); err != nil {
+		return nil, err
+	}
+	return &opt, nil
+}
+
+// loadWithSecrets decodes the document of r into v, after resolving
+// the secret references of its storage-credential field.
+func loadWithSecrets(ctx context.Context, r io.Reader, resolvers credential.SecretResolvers, v any) error {
+	var doc map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return err
+	}
+
+	if raw, ok := doc["storage-credential"]; ok {
+		resolved, err := credential.ResolveSecretReferences(ctx, raw, resolvers)
+		if err != nil {
+			return core.PrefixValidationErrors("storage-credential", err)
+		}
+		doc["storage-credential"] = resolved
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func validateDeleteProfile(dp *profile.DeleteProfile) error {
+	switch d := dp.DeleteProfileSettings.(type) {
+	case nil:
+		return core.NewValidationError("type", "delete profile settings must be set")
+	case *profile.TabularDeleteProfileSoft:
+		if d.ExpirationSeconds <= 0 {
+			return core.NewValidationError("expiration-seconds", "must be positive, got %d", d.ExpirationSeconds)
+		}
+	}
+	return nil
+}
+
 func NewWarehouseService(client core.Client, projectID string) WarehouseServiceInterface {
 	return &WarehouseService{
 		projectID: projectID,
@@ -842,6 +971,10 @@ func (s *WarehouseService) GetStatistics(ctx context.Context, id string, opt *Ge
 // Lakekeeper API docs:
 // https://docs.lakekeeper.io/docs/nightly/api/management/#tag/warehouse/operation/get_warehouse_actions
 func (s *WarehouseService) GetAllowedActions(ctx context.Context, id string, opt *GetWarehouseAllowedActionsOptions, options ...core.RequestOptionFunc) (*GetWarehouseAllowedActionsResponse, *http.Response, error) {
+	if err := core.CheckCapabilities(ctx, s.client, core.RequireVersion(core.MinVersionAllowedActions)); err != nil {
+		return nil, nil, err
+	}
+
 	options = append(options, WithProject(s.projectID))
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("/warehouse/%s/actions", id), opt, options)
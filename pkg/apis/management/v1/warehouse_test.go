@@ -1,7 +1,10 @@
 package v1_test
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
@@ -86,6 +89,36 @@ func TestWarehouseService_List(t *testing.T) {
 	assert.Equal(t, want, warehouses)
 }
 
+func TestWarehouseService_ListUnknownStorage(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	projectID := "01f2fdfc-81fc-444d-8368-5b6701566e35"
+	unknown := `{"type":"hdfs","namenode":"hdfs://namenode:8020"}`
+
+	mux.HandleFunc("GET /management/v1/warehouse", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"warehouses":[
+			{"id":"w1","project-id":%[1]q,"name":"lake","status":"active","storage-profile":{"type":"gcs","bucket":"lake"}},
+			{"id":"w2","project-id":%[1]q,"name":"legacy","status":"active","storage-profile":%[2]s}
+		]}`, projectID, unknown)
+	})
+
+	warehouses, _, err := client.WarehouseV1(projectID).List(t.Context(), nil)
+	require.NoError(t, err)
+	require.Len(t, warehouses.Warehouses, 2)
+
+	_, ok := warehouses.Warehouses[0].StorageProfile.AsGCS()
+	assert.True(t, ok)
+
+	sp, ok := warehouses.Warehouses[1].StorageProfile.AsUnknown()
+	require.True(t, ok)
+	assert.Equal(t, profile.StorageFamily("hdfs"), sp.GetStorageFamily())
+
+	b, err := json.Marshal(warehouses.Warehouses[1].StorageProfile)
+	require.NoError(t, err)
+	assert.JSONEq(t, unknown, string(b))
+}
+
 func TestWarehouseService_Create(t *testing.T) {
 	t.Parallel()
 	mux, client := testutil.ServerMux(t)
@@ -622,3 +655,70 @@ func TestWarehouseService_GetAllowedActions(t *testing.T) {
 
 	assert.Equal(t, want, access)
 }
+
+func TestCreateWarehouseOptions_Validate(t *testing.T) {
+	t.Parallel()
+
+	opt := managementv1.CreateWarehouseOptions{
+		Name:              "test-warehouse",
+		StorageProfile:    profile.NewS3StorageSettings("bucket-name", "eu-central-1").AsProfile(),
+		StorageCredential: credential.NewS3CredentialAccessKey("access-key", "secret-key").AsCredential(),
+		DeleteProfile:     profile.NewTabularDeleteProfileSoft(3600).AsProfile(),
+	}
+	require.NoError(t, opt.Validate())
+
+	opt = managementv1.CreateWarehouseOptions{
+		StorageProfile:    profile.NewS3StorageSettings("Bucket", "eu-central-1", profile.WithS3KeyPrefix("/prefix")).AsProfile(),
+		StorageCredential: credential.NewAZCredentialSharedAccessKey("").AsCredential(),
+		DeleteProfile:     profile.NewTabularDeleteProfileSoft(0).AsProfile(),
+	}
+
+	err := opt.Validate()
+	require.Error(t, err)
+	assert.Equal(t, "warehouse-name: must not be empty\n"+
+		"storage-profile.bucket: \"Bucket\" must be 3 to 63 lowercase letters, numbers, dots and hyphens, starting and ending with a letter or number\n"+
+		"storage-profile.key-prefix: must be normalized, use \"prefix\" instead of \"/prefix\"\n"+
+		"storage-credential.key: must not be empty\n"+
+		"storage-credential.type: az credentials cannot be used with a s3 storage profile, use s3 credentials\n"+
+		"delete-profile.expiration-seconds: must be positive, got 0", err.Error())
+
+	var verr *core.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "warehouse-name", verr.Field)
+
+	// a typo in the storage type of a config file is not sent to the server
+	var typo managementv1.CreateWarehouseOptions
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"warehouse-name": "test-warehouse",
+		"storage-profile": {"type": "s4", "bucket": "bucket-name"},
+		"storage-credential": {"type": "s3", "credential-type": "acess-key"}
+	}`), &typo))
+	assert.EqualError(t, typo.Validate(), "storage-profile.type: unknown storage family \"s4\", must be one of s3, adls or gcs\n"+
+		"storage-credential.credential-type: unknown s3 credential type \"acess-key\"")
+}
+
+func TestLoadCreateWarehouseOptions(t *testing.T) {
+	t.Setenv("LK_TEST_SECRET_KEY", "secret-key")
+
+	opt, err := managementv1.LoadCreateWarehouseOptions(t.Context(), strings.NewReader(`{
+		"warehouse-name": "${env:LK_TEST_NOT_A_CREDENTIAL}",
+		"storage-profile": {"type": "s3", "bucket": "bucket-name", "region": "eu-central-1", "sts-enabled": false},
+		"storage-credential": {
+			"type": "s3",
+			"credential-type": "access-key",
+			"aws-access-key-id": "access-key",
+			"aws-secret-access-key": "${env:LK_TEST_SECRET_KEY}"
+		}
+	}`), credential.DefaultSecretResolvers())
+	require.NoError(t, err)
+
+	// only the storage credential is resolved
+	assert.Equal(t, "${env:LK_TEST_NOT_A_CREDENTIAL}", opt.Name)
+	assert.Equal(t, credential.NewS3CredentialAccessKey("access-key", "secret-key"), opt.StorageCredential.Settings)
+
+	_, err = managementv1.LoadUpdateStorageProfileOptions(t.Context(), strings.NewReader(`{
+		"storage-profile": {"type": "gcs", "bucket": "bucket-name"},
+		"storage-credential": {"type": "gcs", "credential-type": "service-account-key", "key": "${file:/does/not/exist}"}
+	}`), credential.DefaultSecretResolvers())
+	require.EqualError(t, err, "storage-credential.key: cannot resolve ${file:/does/not/exist}: open /does/not/exist: no such file or directory")
+}
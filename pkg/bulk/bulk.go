@@ -0,0 +1,182 @@
+// Package bulk runs many API calls with bounded concurrency and collects
+// the result of each of them.
+//
+// It is meant for operations like provisioning hundreds of users or
+// granting the same assignment on every warehouse:
+//
+//	results := bulk.Run(ctx, users, func(ctx context.Context, opt *managementv1.ProvisionUserOptions) (*managementv1.User, error) {
+//		u, _, err := c.UserV1().Provision(ctx, opt)
+//		return u, err
+//	}, bulk.WithConcurrency(8))
+//
+//	for _, r := range results.Failed() {
+//		log.Printf("could not provision %s, %v", *r.Item.ID, r.Err)
+//	}
+//
+// Requests still go through the client, so they are subject to its
+// rate limiter, see client.WithRateLimit.
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultConcurrency is the number of calls running at the same time
+// when WithConcurrency is not used.
+const DefaultConcurrency = 4
+
+type (
+	// Result is the outcome of the call made for one item.
+	Result[T, R any] struct {
+		// Index is the position of the item in the input slice.
+		Index int
+		Item  T
+		Value R
+		Err   error
+	}
+
+	// Results holds one Result per item, in the input order.
+	Results[T, R any] []Result[T, R]
+
+	// OptionFunc can be used to customize a bulk run.
+	OptionFunc func(*options)
+
+	options struct {
+		concurrency int
+		stopOnError bool
+	}
+)
+
+// WithConcurrency sets the maximum number of calls running at the same time.
+// Values lower than 1 are ignored.
+func WithConcurrency(n int) OptionFunc {
+	return func(o *options) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// WithStopOnError cancels the remaining calls after the first failure.
+// Items not processed are reported with the context error.
+func WithStopOnError() OptionFunc {
+	return func(o *options) {
+		o.stopOnError = true
+	}
+}
+
+// Run calls fn for every item, with at most the configured number of
+// calls running at the same time. It returns when every item has been
+// processed or skipped.
+//
+// When ctx is canceled, items not started yet are reported with the
+// context error.
+func Run[T, R any](ctx context.Context, items []T, fn func(context.Context, T) (R, error), opts ...OptionFunc) Results[T, R] {
+	o := options{concurrency: DefaultConcurrency}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(Results[T, R], len(items))
+	sem := make(chan struct{}, o.concurrency)
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		results[i] = Result[T, R]{Index: i, Item: item}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		// the context may be canceled while waiting for a slot
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			<-sem
+			continue
+		}
+
+		wg.Add(1)
+		go func(r *Result[T, R]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			defer func() {
+				if p := recover(); p != nil {
+					r.Err = fmt.Errorf("panic: %v", p)
+				}
+				if r.Err != nil && o.stopOnError {
+					cancel()
+				}
+			}()
+
+			r.Value, r.Err = fn(ctx, r.Item)
+		}(&results[i])
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// Do is like Run for calls returning only an error.
+func Do[T any](ctx context.Context, items []T, fn func(context.Context, T) error, opts ...OptionFunc) Results[T, struct{}] {
+	return Run(ctx, items, func(ctx context.Context, item T) (struct{}, error) {
+		return struct{}{}, fn(ctx, item)
+	}, opts...)
+}
+
+// Succeeded returns the results without error.
+func (rs Results[T, R]) Succeeded() Results[T, R] {
+	var out Results[T, R]
+	for _, r := range rs {
+		if r.Err == nil {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Failed returns the results with an error.
+func (rs Results[T, R]) Failed() Results[T, R] {
+	var out Results[T, R]
+	for _, r := range rs {
+		if r.Err != nil {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Values returns the values of the successful calls.
+func (rs Results[T, R]) Values() []R {
+	var out []R
+	for _, r := range rs {
+		if r.Err == nil {
+			out = append(out, r.Value)
+		}
+	}
+	return out
+}
+
+// Err returns all errors joined, or nil when every call succeeded.
+// Each error is prefixed with the index of its item.
+func (rs Results[T, R]) Err() error {
+	var errs []error
+	for _, r := range rs {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("item %d: %w", r.Index, r.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
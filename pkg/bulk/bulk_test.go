@@ -0,0 +1,102 @@
+package bulk_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/bulk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	var running, maxRunning atomic.Int32
+	results := bulk.Run(t.Context(), items, func(_ context.Context, i int) (int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if i%4 == 0 {
+			return 0, errors.New("multiple of 4")
+		}
+		return i * 10, nil
+	}, bulk.WithConcurrency(2))
+
+	require.Len(t, results, len(items))
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+
+	for i, r := range results {
+		assert.Equal(t, i, r.Index)
+		assert.Equal(t, items[i], r.Item)
+	}
+
+	assert.Equal(t, []int{10, 20, 30, 50, 60, 70}, results.Values())
+	assert.Len(t, results.Succeeded(), 6)
+
+	failed := results.Failed()
+	require.Len(t, failed, 2)
+	assert.Equal(t, 4, failed[0].Item)
+	assert.Equal(t, 8, failed[1].Item)
+
+	require.EqualError(t, results.Err(), "item 3: multiple of 4\nitem 7: multiple of 4")
+}
+
+func TestRun_StopOnError(t *testing.T) {
+	t.Parallel()
+
+	items := []int{1, 2, 3, 4, 5}
+
+	var calls atomic.Int32
+	results := bulk.Do(t.Context(), items, func(_ context.Context, i int) error {
+		calls.Add(1)
+		if i == 2 {
+			return errors.New("boom")
+		}
+		return nil
+	}, bulk.WithConcurrency(1), bulk.WithStopOnError())
+
+	assert.Equal(t, int32(2), calls.Load())
+	require.NoError(t, results[0].Err)
+	require.EqualError(t, results[1].Err, "boom")
+	for _, r := range results[2:] {
+		require.ErrorIs(t, r.Err, context.Canceled)
+	}
+}
+
+func TestRun_CanceledContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	results := bulk.Do(ctx, []string{"a", "b"}, func(context.Context, string) error {
+		t.Error("no call expected on a canceled context")
+		return nil
+	})
+
+	require.Len(t, results.Failed(), 2)
+	require.ErrorIs(t, results.Err(), context.Canceled)
+}
+
+func TestRun_Panic(t *testing.T) {
+	t.Parallel()
+
+	results := bulk.Do(t.Context(), []int{1}, func(context.Context, int) error {
+		panic("unexpected")
+	})
+
+	require.EqualError(t, results[0].Err, "panic: unexpected")
+}
@@ -0,0 +1,197 @@
+// Package cache provides the response cache used by the client for
+// read requests, see client.WithCache.
+//
+// Cached responses are stored in a Backend. The in-memory backend
+// returned by NewMemory is enough for a single process; implement
+// Backend on top of a shared store to share a cache across replicas.
+//
+// Responses are cached per URL and project, not per principal: a cache
+// must not be shared between clients using different identities.
+package cache
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultTTL is the time a response is served from the cache without
+// revalidation, for resources without a specific TTL.
+const DefaultTTL = 30 * time.Second
+
+// Resource is the kind of resource a request is about. It is the first
+// segment of the request path, e.g. "warehouse" for /warehouse/{id}/statistics.
+type Resource string
+
+const (
+	ResourceInfo        Resource = "info"
+	ResourceProject     Resource = "project"
+	ResourceUser        Resource = "user"
+	ResourceRole        Resource = "role"
+	ResourceWarehouse   Resource = "warehouse"
+	ResourcePermissions Resource = "permissions"
+	ResourceWhoami      Resource = "whoami"
+	ResourceServer      Resource = "server"
+)
+
+type (
+	// Entry is a cached response.
+	Entry struct {
+		StatusCode int         `json:"status-code"`
+		Header     http.Header `json:"header"`
+		Body       []byte      `json:"body"`
+		// ETag is the entity tag of the response, used to revalidate
+		// the entry once expired.
+		ETag string `json:"etag,omitempty"`
+		// ExpiresAt is the time until which the entry is served
+		// without contacting the server.
+		ExpiresAt time.Time `json:"expires-at"`
+	}
+
+	// Backend stores cache entries.
+	//
+	// Entries must be kept after they expire, so they can be revalidated
+	// with their ETag. Implementations must be safe for concurrent use.
+	Backend interface {
+		// Get returns the entry stored for key, or nil if there is none.
+		Get(ctx context.Context, key string) (*Entry, error)
+		// Set stores the entry for key.
+		Set(ctx context.Context, key string, entry *Entry) error
+		// DeletePrefix removes all entries whose key starts with prefix.
+		DeletePrefix(ctx context.Context, prefix string) error
+	}
+
+	// Policy controls which responses are cached and for how long.
+	Policy struct {
+		defaultTTL time.Duration
+		ttls       map[Resource]time.Duration
+		keyPrefix  string
+	}
+
+	// OptionFunc can be used to customize a Policy.
+	OptionFunc func(*Policy)
+)
+
+// IsFresh reports whether the entry can be served without revalidation.
+func (e *Entry) IsFresh(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+// WithDefaultTTL sets the TTL of resources without a specific TTL.
+// A zero TTL disables caching for them.
+func WithDefaultTTL(ttl time.Duration) OptionFunc {
+	return func(p *Policy) {
+		p.defaultTTL = ttl
+	}
+}
+
+// WithTTL sets the TTL of a resource. A zero TTL disables caching
+// for the resource.
+func WithTTL(resource Resource, ttl time.Duration) OptionFunc {
+	return func(p *Policy) {
+		p.ttls[resource] = ttl
+	}
+}
+
+// WithKeyPrefix prefixes every cache key, to isolate clients sharing
+// the same backend.
+func WithKeyPrefix(prefix string) OptionFunc {
+	return func(p *Policy) {
+		p.keyPrefix = prefix
+	}
+}
+
+// NewPolicy returns a policy caching every resource for DefaultTTL,
+// unless configured otherwise.
+//
+// ResourcePermissions is not cached by default, so that an access check
+// following a grant sees it. Use WithTTL to cache it.
+func NewPolicy(opts ...OptionFunc) *Policy {
+	p := &Policy{
+		defaultTTL: DefaultTTL,
+		ttls: map[Resource]time.Duration{
+			ResourcePermissions: 0,
+		},
+	}
+
+	for _, fn := range opts {
+		if fn != nil {
+			fn(p)
+		}
+	}
+
+	return p
+}
+
+// TTL returns the TTL of a resource.
+func (p *Policy) TTL(resource Resource) time.Duration {
+	if ttl, ok := p.ttls[resource]; ok {
+		return ttl
+	}
+	return p.defaultTTL
+}
+
+// Key returns the cache key of a request on a resource. Keys of the
+// same resource share the prefix returned by ResourcePrefix.
+func (p *Policy) Key(resource Resource, project, url string) string {
+	return p.ResourcePrefix(resource) + project + "|" + url
+}
+
+// ResourcePrefix returns the prefix of all cache keys of a resource.
+func (p *Policy) ResourcePrefix(resource Resource) string {
+	return p.keyPrefix + string(resource) + "|"
+}
+
+// ResourceFromPath returns the resource of a request path, relative
+// to the management API base path.
+//
+// Search and list endpoints are attached to the resource they return,
+// e.g. /search/user is a ResourceUser request.
+func ResourceFromPath(path string) Resource {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case segments[0] == "search" && len(segments) > 1:
+		return Resource(segments[1])
+	case segments[0] == "project-list":
+		return ResourceProject
+	case segments[0] == "endpoint-statistics":
+		return ResourceProject
+	}
+
+	return Resource(segments[0])
+}
+
+// IsMutation reports whether a request may modify the resource of its
+// path, and must invalidate the cached entries of that resource.
+// Search and statistics queries sent with POST are not mutations.
+func IsMutation(method, path string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch segments[0] {
+	case "search", "endpoint-statistics":
+		return false
+	}
+
+	return true
+}
+
+// Invalidated returns the resources whose cached entries must be
+// invalidated by a mutation of resource: the resource itself and the
+// resources depending on it, e.g. the permissions on a deleted
+// warehouse.
+func Invalidated(resource Resource) []Resource {
+	switch resource {
+	case ResourceProject:
+		return []Resource{ResourceProject, ResourceWarehouse, ResourceRole, ResourcePermissions}
+	case ResourceWarehouse, ResourceRole, ResourceUser:
+		return []Resource{resource, ResourcePermissions}
+	}
+
+	return []Resource{resource}
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceFromPath(t *testing.T) {
+	t.Parallel()
+
+	for path, want := range map[string]Resource{
+		"/warehouse":                     ResourceWarehouse,
+		"/warehouse/abc/statistics":      ResourceWarehouse,
+		"/role/abc":                      ResourceRole,
+		"/user/oidc~abc":                 ResourceUser,
+		"/search/user":                   ResourceUser,
+		"/project-list":                  ResourceProject,
+		"/permissions/role/abc/access":   ResourcePermissions,
+		"/info":                          ResourceInfo,
+		"whoami":                         ResourceWhoami,
+		"/permissions/server/assignment": ResourcePermissions,
+	} {
+		assert.Equal(t, want, ResourceFromPath(path), path)
+	}
+}
+
+func TestIsMutation(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, IsMutation(http.MethodGet, "/warehouse"))
+	assert.False(t, IsMutation(http.MethodPost, "/search/role"))
+	assert.False(t, IsMutation(http.MethodPost, "/endpoint-statistics"))
+	assert.True(t, IsMutation(http.MethodPost, "/warehouse/abc/rename"))
+	assert.True(t, IsMutation(http.MethodDelete, "/user/abc"))
+}
+
+func TestPolicy(t *testing.T) {
+	t.Parallel()
+
+	p := NewPolicy(
+		WithDefaultTTL(time.Minute),
+		WithTTL(ResourcePermissions, 0),
+		WithTTL(ResourceWarehouse, time.Hour),
+		WithKeyPrefix("replica/"),
+	)
+
+	assert.Equal(t, time.Minute, p.TTL(ResourceRole))
+	assert.Equal(t, time.Hour, p.TTL(ResourceWarehouse))
+	assert.Zero(t, p.TTL(ResourcePermissions))
+
+	key := p.Key(ResourceWarehouse, "project", "http://localhost/management/v1/warehouse/abc")
+	assert.Equal(t, "replica/warehouse|project|http://localhost/management/v1/warehouse/abc", key)
+	assert.True(t, len(key) > len(p.ResourcePrefix(ResourceWarehouse)))
+
+	assert.Equal(t, DefaultTTL, NewPolicy().TTL(ResourceUser))
+	assert.Zero(t, NewPolicy().TTL(ResourcePermissions))
+	assert.Equal(t, time.Minute, NewPolicy(WithTTL(ResourcePermissions, time.Minute)).TTL(ResourcePermissions))
+}
+
+func TestInvalidated(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []Resource{ResourceWarehouse, ResourcePermissions}, Invalidated(ResourceWarehouse))
+	assert.Equal(t, []Resource{ResourceProject, ResourceWarehouse, ResourceRole, ResourcePermissions}, Invalidated(ResourceProject))
+	assert.Equal(t, []Resource{ResourcePermissions}, Invalidated(ResourcePermissions))
+}
+
+func TestMemory(t *testing.T) {
+	t.Parallel()
+
+	m := NewMemory()
+
+	e, err := m.Get(t.Context(), "role|a")
+	require.NoError(t, err)
+	assert.Nil(t, e)
+
+	require.NoError(t, m.Set(t.Context(), "role|a", &Entry{Body: []byte("a"), ETag: "1"}))
+	require.NoError(t, m.Set(t.Context(), "role|b", &Entry{Body: []byte("b")}))
+	require.NoError(t, m.Set(t.Context(), "user|a", &Entry{Body: []byte("c")}))
+
+	e, err = m.Get(t.Context(), "role|a")
+	require.NoError(t, err)
+	assert.Equal(t, "1", e.ETag)
+
+	require.NoError(t, m.DeletePrefix(t.Context(), "role|"))
+	assert.Equal(t, 1, m.Len())
+}
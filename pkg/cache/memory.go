@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Memory is an in-memory Backend.
+type Memory struct {
+	mu      sync.RWMutex
+	entries map[string]*Entry
+}
+
+var _ Backend = (*Memory)(nil)
+
+// NewMemory returns an empty in-memory backend.
+func NewMemory() *Memory {
+	return &Memory{
+		entries: map[string]*Entry{},
+	}
+}
+
+func (m *Memory) Get(_ context.Context, key string) (*Entry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, nil
+	}
+
+	// return a copy, callers may update the entry
+	c := *e
+	return &c, nil
+}
+
+func (m *Memory) Set(_ context.Context, key string, entry *Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c := *entry
+	m.entries[key] = &c
+
+	return nil
+}
+
+func (m *Memory) DeletePrefix(_ context.Context, prefix string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for k := range m.entries {
+		if strings.HasPrefix(k, prefix) {
+			delete(m.entries, k)
+		}
+	}
+
+	return nil
+}
+
+// Len returns the number of entries.
+func (m *Memory) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.entries)
+}
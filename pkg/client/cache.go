@@ -0,0 +1,120 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	"github.com/baptistegh/go-lakekeeper/pkg/cache"
+)
+
+// cacheTransport serves GET responses from the cache, revalidates
+// expired entries with their ETag and invalidates the cached entries
+// of the resources modified by a request.
+//
+// The 304 responses to the revalidations are replaced by the cached
+// response. Conditional requests of the caller, already carrying an
+// If-None-Match header, bypass the cache and get the server response.
+type cacheTransport struct {
+	backend  cache.Backend
+	policy   *cache.Policy
+	basePath string
+	base     http.RoundTripper
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	path, ok := strings.CutPrefix(req.URL.Path, t.basePath)
+	if !ok {
+		return base.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	resource := cache.ResourceFromPath(path)
+
+	if cache.IsMutation(req.Method, path) {
+		resp, err := base.RoundTrip(req)
+
+		// whatever the outcome, a failure only means the
+		// entries will expire by themselves
+		for _, r := range cache.Invalidated(resource) {
+			_ = t.backend.DeletePrefix(ctx, t.policy.ResourcePrefix(r))
+		}
+
+		return resp, err
+	}
+
+	ttl := t.policy.TTL(resource)
+	if req.Method != http.MethodGet || ttl <= 0 || req.Header.Get("If-None-Match") != "" {
+		return base.RoundTrip(req)
+	}
+
+	key := t.policy.Key(resource, req.Header.Get(managementv1.ProjectIDHeader), req.URL.String())
+
+	// a backend failure is handled as a cache miss
+	entry, _ := t.backend.Get(ctx, key)
+	if entry != nil && entry.IsFresh(time.Now()) {
+		return cachedResponse(req, entry), nil
+	}
+
+	if entry != nil && entry.ETag != "" {
+		req = req.Clone(ctx)
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		entry.ExpiresAt = time.Now().Add(ttl)
+		_ = t.backend.Set(ctx, key, entry)
+
+		return cachedResponse(req, entry), nil
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		_ = t.backend.Set(ctx, key, &cache.Entry{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       body,
+			ETag:       resp.Header.Get("ETag"),
+			ExpiresAt:  time.Now().Add(ttl),
+		})
+	}
+
+	return resp, nil
+}
+
+// cachedResponse returns the response stored in a cache entry.
+func cachedResponse(req *http.Request, entry *cache.Entry) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.StatusCode, http.StatusText(entry.StatusCode)),
+		StatusCode:    entry.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       req,
+	}
+}
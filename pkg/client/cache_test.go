@@ -0,0 +1,156 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/cache"
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCache(t *testing.T) {
+	t.Parallel()
+
+	var gets, notModified, deletes atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /management/v1/role/{id}", func(w http.ResponseWriter, r *http.Request) {
+		gets.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"id":"` + r.PathValue("id") + `","name":"role"}`))
+	})
+	mux.HandleFunc("DELETE /management/v1/role/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		deletes.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	backend := cache.NewMemory()
+	c, err := NewClient(t.Context(), "", server.URL, WithCache(backend, cache.WithTTL(cache.ResourceRole, 50*time.Millisecond)))
+	require.NoError(t, err)
+
+	// first call reaches the server, the second one is served from the cache
+	for range 2 {
+		role, _, err := c.RoleV1("project").Get(t.Context(), "abc")
+		require.NoError(t, err)
+		assert.Equal(t, "role", role.Name)
+	}
+	assert.Equal(t, int32(1), gets.Load())
+
+	// once expired, the entry is revalidated with its ETag
+	time.Sleep(60 * time.Millisecond)
+
+	role, _, err := c.RoleV1("project").Get(t.Context(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "role", role.Name)
+	assert.Equal(t, int32(2), gets.Load())
+	assert.Equal(t, int32(1), notModified.Load())
+
+	// mutations invalidate the cached entries of the resource
+	_, err = c.RoleV1("project").Delete(t.Context(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), deletes.Load())
+	assert.Zero(t, backend.Len())
+
+	_, _, err = c.RoleV1("project").Get(t.Context(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), gets.Load())
+	assert.Equal(t, int32(1), notModified.Load())
+}
+
+func TestClientCache_Disabled(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewClient(t.Context(), "", "http://localhost:8181", WithCache(nil))
+	require.Error(t, err)
+}
+
+func TestClientCache_Permissions(t *testing.T) {
+	t.Parallel()
+
+	var assignments atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /management/v1/permissions/warehouse/{id}/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		assignments.Add(1)
+		_, _ = w.Write([]byte(`{"assignments":[]}`))
+	})
+	mux.HandleFunc("DELETE /management/v1/warehouse/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Run("Not Cached By Default", func(t *testing.T) {
+		c, err := NewClient(t.Context(), "", server.URL, WithCache(cache.NewMemory()))
+		require.NoError(t, err)
+
+		before := assignments.Load()
+		for range 2 {
+			_, _, err := c.PermissionV1().WarehousePermission().GetAssignments(t.Context(), "abc", nil)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, before+2, assignments.Load())
+	})
+
+	t.Run("Invalidated By Related Mutations", func(t *testing.T) {
+		backend := cache.NewMemory()
+		c, err := NewClient(t.Context(), "", server.URL, WithCache(backend, cache.WithTTL(cache.ResourcePermissions, time.Minute)))
+		require.NoError(t, err)
+
+		_, _, err = c.PermissionV1().WarehousePermission().GetAssignments(t.Context(), "abc", nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, backend.Len())
+
+		// deleting the warehouse removes its assignments
+		_, err = c.WarehouseV1("project").Delete(t.Context(), "abc", nil)
+		require.NoError(t, err)
+		assert.Zero(t, backend.Len())
+	})
+}
+
+func TestClientCache_CallerConditionalRequest(t *testing.T) {
+	t.Parallel()
+
+	var gets atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /management/v1/role/{id}", func(w http.ResponseWriter, r *http.Request) {
+		gets.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"id":"abc","name":"role"}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(t.Context(), "", server.URL, WithCache(cache.NewMemory()))
+	require.NoError(t, err)
+
+	// fill the cache
+	_, _, err = c.RoleV1("project").Get(t.Context(), "abc")
+	require.NoError(t, err)
+
+	// the 304 of a conditional request of the caller is returned as is
+	req, err := c.NewRequest(t.Context(), http.MethodGet, "/role/abc", nil, []core.RequestOptionFunc{core.WithHeader("If-None-Match", `"v1"`)})
+	require.NoError(t, err)
+
+	resp, apiErr := c.Do(req, nil)
+	require.Nil(t, apiErr)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, int32(2), gets.Load())
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/apache/iceberg-go/catalog/rest"
 	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
 	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+	"github.com/baptistegh/go-lakekeeper/pkg/cache"
 	"github.com/baptistegh/go-lakekeeper/pkg/core"
 	"github.com/baptistegh/go-lakekeeper/pkg/version"
 	"github.com/google/go-querystring/query"
@@ -36,6 +37,15 @@ type Client struct {
 	// disableRetries is used to disable the default retry logic.
 	disableRetries bool
 
+	// cache is used to cache read requests, nil if disabled.
+	cache cache.Backend
+
+	// cachePolicy controls which responses are cached.
+	cachePolicy *cache.Policy
+
+	// limiter is used to limit the rate of API requests.
+	limiter RateLimiter
+
 	// authSource is used to obtain authentication headers.
 	authSource core.AuthSource
 
@@ -63,9 +73,25 @@ type Client struct {
 	// bootstrapInit is used to ensure that the bootstrap flow
 	// is executed once
 	bootstrapInit sync.Once
+
+	// disableCapabilityChecks is used to skip server capability
+	// checks made by services before calling the API.
+	disableCapabilityChecks bool
+
+	// capabilities of the server, fetched on first use.
+	capabilities   *core.Capabilities
+	capabilitiesMu sync.Mutex
+}
+
+// RateLimiter describes the interface that all (custom) rate limiters must implement.
+type RateLimiter interface {
+	Wait(context.Context) error
 }
 
-var _ core.Client = (*Client)(nil)
+var (
+	_ core.Client               = (*Client)(nil)
+	_ core.CapabilitiesProvider = (*Client)(nil)
+)
 
 // ServerV1 return a new ServerService for servers v1 management
 func (c *Client) ServerV1() managementv1.ServerServiceInterface {
@@ -114,6 +140,33 @@ func (c *Client) CatalogV1(ctx context.Context, projectID, warehouse string, opt
 	return rest.NewCatalog(ctx, "rest", baseURL.String(), opts...)
 }
 
+// ServerCapabilities returns the capabilities of the server.
+// They are fetched from the server info endpoint on first use and cached
+// for the lifetime of the client. Errors are not cached, the next call
+// fetches them again.
+//
+// It returns nil when capability checks are disabled.
+func (c *Client) ServerCapabilities(ctx context.Context) (*core.Capabilities, error) {
+	if c.disableCapabilityChecks {
+		return nil, nil
+	}
+
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+
+	if c.capabilities != nil {
+		return c.capabilities, nil
+	}
+
+	info, _, err := c.ServerV1().Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch server capabilities, %w", err)
+	}
+	c.capabilities = info.Capabilities()
+
+	return c.capabilities, nil
+}
+
 // NewClient returns a new Lakekeeper API client.
 // You must provide a valid access token.
 func NewClient(ctx context.Context, token, baseURL string, options ...ClientOptionFunc) (*Client, error) {
@@ -159,6 +212,27 @@ func NewAuthSourceClient(ctx context.Context, as core.AuthSource, baseURL string
 		}
 	}
 
+	// Limit the rate at the transport level, so that retries
+	// are limited as well.
+	if c.limiter != nil {
+		httpClient := *c.client.HTTPClient
+		httpClient.Transport = &rateLimitTransport{limiter: c.limiter, base: httpClient.Transport}
+		c.client.HTTPClient = &httpClient
+	}
+
+	// Cache at the transport level as well, in front of the rate
+	// limiter, so that responses served from the cache are not limited.
+	if c.cache != nil {
+		httpClient := *c.client.HTTPClient
+		httpClient.Transport = &cacheTransport{
+			backend:  c.cache,
+			policy:   c.cachePolicy,
+			basePath: c.baseURL.Path,
+			base:     httpClient.Transport,
+		}
+		c.client.HTTPClient = &httpClient
+	}
+
 	c.bootstrapInit.Do(func() {
 		if !c.bootstrap {
 			return
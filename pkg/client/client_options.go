@@ -1,11 +1,14 @@
 package client
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
+	"github.com/baptistegh/go-lakekeeper/pkg/cache"
 	"github.com/baptistegh/go-lakekeeper/pkg/core"
 	"github.com/hashicorp/go-retryablehttp"
+	"golang.org/x/time/rate"
 
 	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
 )
@@ -37,6 +40,47 @@ func WithCustomRetryMax(retryMax int) ClientOptionFunc {
 	}
 }
 
+// WithCustomLimiter injects a custom rate limiter to the client.
+func WithCustomLimiter(limiter RateLimiter) ClientOptionFunc {
+	return func(c *Client) error {
+		c.limiter = limiter
+		return nil
+	}
+}
+
+// WithRateLimit limits the client to requestsPerSecond requests per second,
+// with bursts of at most burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOptionFunc {
+	return func(c *Client) error {
+		if requestsPerSecond <= 0 || burst <= 0 {
+			return errors.New("rate limit and burst must be greater than zero")
+		}
+		c.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+		return nil
+	}
+}
+
+// WithCache enables the response cache for read requests.
+//
+// GET responses are served from backend until their TTL expires, then
+// revalidated with If-None-Match when the server returned an ETag.
+// Requests modifying a resource invalidate its cached responses, and
+// those of the resources depending on it. Permissions are not cached
+// unless enabled with cache.WithTTL.
+//
+// The cache is keyed by URL and project, not by principal: do not share
+// a backend between clients using different identities.
+func WithCache(backend cache.Backend, opts ...cache.OptionFunc) ClientOptionFunc {
+	return func(c *Client) error {
+		if backend == nil {
+			return errors.New("cache backend must be provided")
+		}
+		c.cache = backend
+		c.cachePolicy = cache.NewPolicy(opts...)
+		return nil
+	}
+}
+
 // WithErrorHandler can be used to configure a custom error handler.
 func WithErrorHandler(handler retryablehttp.ErrorHandler) ClientOptionFunc {
 	return func(c *Client) error {
@@ -87,6 +131,28 @@ func WithHTTPClient(httpClient *http.Client) ClientOptionFunc {
 	}
 }
 
+// WithHTTPTransport can be used to configure a custom HTTP transport,
+// for example a recorder.Recorder to record or replay interactions.
+// The HTTP client is copied, one set with WithHTTPClient is not modified.
+func WithHTTPTransport(transport http.RoundTripper) ClientOptionFunc {
+	return func(c *Client) error {
+		httpClient := *c.client.HTTPClient
+		httpClient.Transport = transport
+		c.client.HTTPClient = &httpClient
+		return nil
+	}
+}
+
+// WithoutCapabilityChecks disables the server capability checks made
+// before calls requiring a minimum Lakekeeper version or a specific
+// authorization backend. Calls are then always sent to the server.
+func WithoutCapabilityChecks() ClientOptionFunc {
+	return func(c *Client) error {
+		c.disableCapabilityChecks = true
+		return nil
+	}
+}
+
 // WithInitialBootstrapV1Enabled enables automatic server
 // bootstrap on client startup.
 //
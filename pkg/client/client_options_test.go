@@ -67,6 +67,54 @@ func TestCustomHOptions(t *testing.T) {
 		assert.Equal(t, customHTTPClient, c.client.HTTPClient)
 	})
 
+	t.Run("CustomHTTPTransport", func(t *testing.T) {
+		t.Parallel()
+		// Arrange
+		customTransport := &http.Transport{}
+
+		// Act
+		opt := WithHTTPTransport(customTransport)
+
+		c, err := NewClient(t.Context(), "", "http://localhost:8080")
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+
+		if err := opt(c); err != nil {
+			t.Fatalf("Failed to add custom http transport, %v", err)
+		}
+
+		assert.Equal(t, customTransport, c.client.HTTPClient.Transport)
+	})
+
+	t.Run("CustomHTTPTransportWithCustomHTTPClient", func(t *testing.T) {
+		t.Parallel()
+		customHTTPClient := &http.Client{Timeout: time.Minute}
+		customTransport := &http.Transport{}
+
+		c, err := NewClient(t.Context(), "", "http://localhost:8080", WithHTTPClient(customHTTPClient), WithHTTPTransport(customTransport))
+		require.NoError(t, err)
+
+		assert.Equal(t, customTransport, c.client.HTTPClient.Transport)
+		assert.Equal(t, time.Minute, c.client.HTTPClient.Timeout)
+		// The client of the caller is not modified.
+		assert.Nil(t, customHTTPClient.Transport)
+	})
+
+	t.Run("RateLimit", func(t *testing.T) {
+		t.Parallel()
+		c, err := NewClient(t.Context(), "", "http://localhost:8080", WithRateLimit(10, 1))
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+
+		assert.NotNil(t, c.limiter)
+		assert.IsType(t, &rateLimitTransport{}, c.client.HTTPClient.Transport)
+
+		_, err = NewClient(t.Context(), "", "http://localhost:8080", WithRateLimit(0, 1))
+		require.Error(t, err)
+	})
+
 	t.Run("CustomRetryWaitMinMax", func(t *testing.T) {
 		t.Parallel()
 		// Act
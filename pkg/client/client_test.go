@@ -4,8 +4,11 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
 	"github.com/baptistegh/go-lakekeeper/pkg/core"
@@ -170,3 +173,102 @@ func TestRequestWithContext(t *testing.T) {
 		t.Fatal("Context was not set correctly")
 	}
 }
+
+func TestServerCapabilities(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/management/v1/info", func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"authz-backend":"openfga","version":"v0.10.1"}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Run("Fetched Once", func(t *testing.T) {
+		c, err := NewClient(t.Context(), "", server.URL)
+		require.NoError(t, err)
+
+		for range 3 {
+			caps, err := c.ServerCapabilities(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, &core.Capabilities{Version: "v0.10.1", AuthzBackend: "openfga"}, caps)
+		}
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("Errors Not Cached", func(t *testing.T) {
+		var failures atomic.Int32
+		failures.Store(1)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/management/v1/info", func(w http.ResponseWriter, _ *http.Request) {
+			if failures.Add(-1) >= 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"authz-backend":"allowall","version":"v0.9.0"}`))
+		})
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+
+		c, err := NewClient(t.Context(), "", server.URL, WithoutRetries())
+		require.NoError(t, err)
+
+		_, err = c.ServerCapabilities(t.Context())
+		require.Error(t, err)
+
+		caps, err := c.ServerCapabilities(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, &core.Capabilities{Version: "v0.9.0", AuthzBackend: "allowall"}, caps)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		c, err := NewClient(t.Context(), "", server.URL, WithoutCapabilityChecks())
+		require.NoError(t, err)
+
+		caps, err := c.ServerCapabilities(t.Context())
+		require.NoError(t, err)
+		assert.Nil(t, caps)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+type countingLimiter struct {
+	waits atomic.Int32
+}
+
+func (l *countingLimiter) Wait(context.Context) error {
+	l.waits.Add(1)
+	return nil
+}
+
+func TestRateLimit_Retries(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/management/v1/info", func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"version":"v0.10.1"}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	limiter := &countingLimiter{}
+
+	c, err := NewClient(t.Context(), "", server.URL, WithCustomLimiter(limiter), WithCustomRetryWaitMinMax(time.Millisecond, time.Millisecond))
+	require.NoError(t, err)
+
+	_, _, err = c.ServerV1().Info(t.Context())
+	require.NoError(t, err)
+
+	// every attempt waits for the limiter, not only the first one
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, int32(3), limiter.waits.Load())
+}
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"context"
+
+	"github.com/apache/iceberg-go/catalog/rest"
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+)
+
+// Interface is the set of services exposed by a Lakekeeper client.
+//
+// Consumers can depend on it instead of the concrete *Client, and use
+// the fakes of the mocks package in their tests.
+type Interface interface {
+	ServerV1() managementv1.ServerServiceInterface
+	ProjectV1() managementv1.ProjectServiceInterface
+	UserV1() managementv1.UserServiceInterface
+	RoleV1(projectID string) managementv1.RoleServiceInterface
+	WarehouseV1(projectID string) managementv1.WarehouseServiceInterface
+	PermissionV1() permissionv1.PermissionServiceInterface
+	CatalogV1(ctx context.Context, projectID, warehouse string, opts ...rest.Option) (*rest.Catalog, error)
+}
+
+var _ Interface = (*Client)(nil)
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"net/http"
+)
+
+// rateLimitTransport waits for the rate limiter before sending
+// each request, including the retries of the HTTP client.
+type rateLimitTransport struct {
+	limiter RateLimiter
+	base    http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return base.RoundTrip(req)
+}
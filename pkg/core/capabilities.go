@@ -0,0 +1,172 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// OpenFGAAuthzBackend is the authorization backend name reported
+	// by servers using OpenFGA.
+	OpenFGAAuthzBackend = "openfga"
+	// AllowAllAuthzBackend is the authorization backend name reported
+	// by servers without authorization.
+	AllowAllAuthzBackend = "allow-all"
+
+	// MinVersionAllowedActions is the first Lakekeeper version exposing
+	// the actions and authorizer-actions endpoints.
+	MinVersionAllowedActions = "0.10.0"
+)
+
+type (
+	// Capabilities describes what a Lakekeeper server supports.
+	Capabilities struct {
+		// Version is the server version as reported by the server, e.g. "v0.9.0".
+		Version string `json:"version"`
+		// AuthzBackend is the authorization backend used by the server.
+		AuthzBackend string `json:"authz-backend"`
+	}
+
+	// CapabilitiesProvider is implemented by clients able to report the
+	// capabilities of the server they talk to.
+	CapabilitiesProvider interface {
+		ServerCapabilities(ctx context.Context) (*Capabilities, error)
+	}
+
+	// Requirement checks that the server capabilities allow a call.
+	// It returns a *CapabilityError when they do not.
+	Requirement func(*Capabilities) error
+
+	// CapabilityError is returned when a call is not supported by the server.
+	CapabilityError struct {
+		// Requirement is a human readable description of what is needed.
+		Requirement string
+		// Actual is what the server reported.
+		Actual string
+	}
+)
+
+func (e *CapabilityError) Error() string {
+	return fmt.Sprintf("requires %s, server has %s", e.Requirement, e.Actual)
+}
+
+// RequireVersion requires a server version greater or equal to min.
+// Servers reporting a version that cannot be parsed, such as development
+// builds, are assumed to satisfy the requirement.
+func RequireVersion(minVersion string) Requirement {
+	return func(c *Capabilities) error {
+		if c.AtLeast(minVersion) {
+			return nil
+		}
+		return &CapabilityError{
+			Requirement: "Lakekeeper >= " + minVersion,
+			Actual:      "Lakekeeper " + c.Version,
+		}
+	}
+}
+
+// RequireAuthzBackend requires the server to use the given
+// authorization backend.
+func RequireAuthzBackend(backend string) Requirement {
+	return func(c *Capabilities) error {
+		if c.AuthzBackend == "" || strings.EqualFold(c.AuthzBackend, backend) {
+			return nil
+		}
+		return &CapabilityError{
+			Requirement: backend + " authz",
+			Actual:      c.AuthzBackend + " authz",
+		}
+	}
+}
+
+// RequireOpenFGA requires the server to use OpenFGA authorization.
+func RequireOpenFGA() Requirement {
+	return RequireAuthzBackend(OpenFGAAuthzBackend)
+}
+
+// Check returns the first requirement not satisfied by the capabilities.
+func (c *Capabilities) Check(reqs ...Requirement) error {
+	for _, req := range reqs {
+		if err := req(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AtLeast reports whether the server version is greater or equal to
+// minVersion. Unknown versions are considered recent enough.
+func (c *Capabilities) AtLeast(minVersion string) bool {
+	have, ok := parseVersion(c.Version)
+	if !ok {
+		return true
+	}
+
+	want, ok := parseVersion(minVersion)
+	if !ok {
+		return true
+	}
+
+	for i := range have {
+		if have[i] != want[i] {
+			return have[i] > want[i]
+		}
+	}
+
+	return true
+}
+
+// IsOpenFGA reports whether the server uses OpenFGA authorization.
+func (c *Capabilities) IsOpenFGA() bool {
+	return strings.EqualFold(c.AuthzBackend, OpenFGAAuthzBackend)
+}
+
+// CheckCapabilities verifies that the server behind client satisfies all
+// requirements. It returns nil when the client cannot report capabilities,
+// or when they could not be fetched, letting the server decide.
+func CheckCapabilities(ctx context.Context, client Client, reqs ...Requirement) error {
+	p, ok := client.(CapabilitiesProvider)
+	if !ok {
+		return nil
+	}
+
+	caps, err := p.ServerCapabilities(ctx)
+	if err != nil || caps == nil {
+		return nil
+	}
+
+	if err := caps.Check(reqs...); err != nil {
+		return APIErrorFromError(err)
+	}
+
+	return nil
+}
+
+// parseVersion parses versions like "v0.9.1" or "0.10.0-rc.1" into
+// their major, minor and patch numbers. Pre-release and build
+// metadata are ignored.
+func parseVersion(v string) ([3]int, bool) {
+	var out [3]int
+
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return out, false
+	}
+
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return out, false
+		}
+		out[i] = n
+	}
+
+	return out, true
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilities_AtLeast(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version string
+		min     string
+		want    bool
+	}{
+		{"v0.9.0", "0.10.0", false},
+		{"v0.10.0", "0.10.0", true},
+		{"0.10.1", "0.10.0", true},
+		{"v1.0.0", "0.10.0", true},
+		{"0.10.0-rc.1", "0.10.0", true},
+		{"0.9", "0.9.1", false},
+		{"latest-main", "0.10.0", true},
+		{"", "0.10.0", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+">="+tt.min, func(t *testing.T) {
+			t.Parallel()
+			c := &Capabilities{Version: tt.version}
+			assert.Equal(t, tt.want, c.AtLeast(tt.min))
+		})
+	}
+}
+
+func TestCapabilities_Check(t *testing.T) {
+	t.Parallel()
+
+	c := &Capabilities{Version: "v0.9.0", AuthzBackend: AllowAllAuthzBackend}
+
+	err := c.Check(RequireVersion("0.10.0"))
+	require.EqualError(t, err, "requires Lakekeeper >= 0.10.0, server has Lakekeeper v0.9.0")
+
+	err = c.Check(RequireVersion("0.9.0"), RequireOpenFGA())
+	require.EqualError(t, err, "requires openfga authz, server has allow-all authz")
+
+	c.AuthzBackend = OpenFGAAuthzBackend
+	require.NoError(t, c.Check(RequireVersion("0.9.0"), RequireOpenFGA()))
+	assert.True(t, c.IsOpenFGA())
+}
+
+type capabilitiesClient struct {
+	Client
+	caps *Capabilities
+}
+
+func (c *capabilitiesClient) ServerCapabilities(_ context.Context) (*Capabilities, error) {
+	return c.caps, nil
+}
+
+func TestCheckCapabilities(t *testing.T) {
+	t.Parallel()
+
+	c := &capabilitiesClient{caps: &Capabilities{Version: "v0.9.0"}}
+
+	err := CheckCapabilities(t.Context(), c, RequireVersion("0.10.0"))
+	require.Error(t, err)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.True(t, apiErr.IsCapabilityError())
+	assert.Equal(t, "requires Lakekeeper >= 0.10.0, server has Lakekeeper v0.9.0", apiErr.Error())
+
+	require.NoError(t, CheckCapabilities(t.Context(), c, RequireVersion("0.9.0")))
+	require.NoError(t, CheckCapabilities(t.Context(), nil, RequireVersion("0.10.0")))
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -26,6 +27,11 @@ type (
 )
 
 func (e *APIError) Error() string {
+	var capErr *CapabilityError
+	if e.Response == nil && errors.As(e.Cause, &capErr) {
+		return capErr.Error()
+	}
+
 	if e.Response == nil {
 		errMsg := "unexpected error response"
 		if e.Message != "" {
@@ -39,6 +45,18 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("api error, code=%d message=%s type=%s", e.Response.Code, e.Response.Message, e.Response.Type)
 }
 
+// Unwrap returns the cause of the error, if any.
+func (e *APIError) Unwrap() error {
+	return e.Cause
+}
+
+// IsCapabilityError reports whether the call was rejected client side
+// because the server does not support it.
+func (e *APIError) IsCapabilityError() bool {
+	var capErr *CapabilityError
+	return errors.As(e.Cause, &capErr)
+}
+
 func (e *APIError) Type() string {
 	if e.Response == nil {
 		return "Unknown"
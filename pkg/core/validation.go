@@ -0,0 +1,52 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ValidationError is returned when a request is rejected client side,
+// before being sent, because one of its fields is invalid.
+type ValidationError struct {
+	// Field is the path of the invalid field, using the JSON names
+	// of the request, e.g. storage-profile.bucket.
+	Field string
+	// Reason describes why the value is invalid.
+	Reason string
+}
+
+// NewValidationError creates a ValidationError for field.
+func NewValidationError(field, format string, a ...any) *ValidationError {
+	return &ValidationError{
+		Field:  field,
+		Reason: fmt.Sprintf(format, a...),
+	}
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Reason)
+}
+
+// PrefixValidationErrors prepends prefix to the field path of the
+// validation errors contained in err, which may be joined errors.
+// It returns nil if err is nil.
+func PrefixValidationErrors(prefix string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if verr, ok := err.(*ValidationError); ok {
+		return &ValidationError{Field: prefix + "." + verr.Field, Reason: verr.Reason}
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs := joined.Unwrap()
+		prefixed := make([]error, 0, len(errs))
+		for _, e := range errs {
+			prefixed = append(prefixed, PrefixValidationErrors(prefix, e))
+		}
+		return errors.Join(prefixed...)
+	}
+
+	return fmt.Errorf("%s: %w", prefix, err)
+}
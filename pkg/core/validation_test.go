@@ -0,0 +1,30 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, PrefixValidationErrors("storage-profile", nil))
+
+	err := PrefixValidationErrors("storage-profile", errors.Join(
+		NewValidationError("bucket", "must not be empty"),
+		NewValidationError("region", "invalid region %q", "eu"),
+		errors.New("unexpected"),
+	))
+	require.Error(t, err)
+
+	assert.Equal(t, "storage-profile.bucket: must not be empty\n"+
+		"storage-profile.region: invalid region \"eu\"\n"+
+		"storage-profile: unexpected", err.Error())
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "storage-profile.bucket", verr.Field)
+}
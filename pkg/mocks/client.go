@@ -0,0 +1,198 @@
+// Code generated by internal/mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/apache/iceberg-go/catalog/rest"
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+	"github.com/baptistegh/go-lakekeeper/pkg/client"
+)
+
+// Client is a call-recording fake of client.Interface.
+//
+// Set the <Method>Func fields to stub methods, methods without a stub
+// return zero values. Calls are recorded in the <Method>Calls fields.
+type Client struct {
+	mu sync.Mutex
+
+	CatalogV1Func  func(ctx context.Context, projectID string, warehouse string, opts ...rest.Option) (*rest.Catalog, error)
+	CatalogV1Calls []ClientCatalogV1Call
+
+	PermissionV1Func  func() permissionv1.PermissionServiceInterface
+	PermissionV1Calls []ClientPermissionV1Call
+
+	ProjectV1Func  func() managementv1.ProjectServiceInterface
+	ProjectV1Calls []ClientProjectV1Call
+
+	RoleV1Func  func(projectID string) managementv1.RoleServiceInterface
+	RoleV1Calls []ClientRoleV1Call
+
+	ServerV1Func  func() managementv1.ServerServiceInterface
+	ServerV1Calls []ClientServerV1Call
+
+	UserV1Func  func() managementv1.UserServiceInterface
+	UserV1Calls []ClientUserV1Call
+
+	WarehouseV1Func  func(projectID string) managementv1.WarehouseServiceInterface
+	WarehouseV1Calls []ClientWarehouseV1Call
+}
+
+var _ client.Interface = (*Client)(nil)
+
+// ClientCatalogV1Call records a call to Client.CatalogV1.
+type ClientCatalogV1Call struct {
+	Ctx       context.Context
+	ProjectID string
+	Warehouse string
+	Opts      []rest.Option
+}
+
+// CatalogV1 implements client.Interface.
+func (mock *Client) CatalogV1(ctx context.Context, projectID string, warehouse string, opts ...rest.Option) (*rest.Catalog, error) {
+	mock.mu.Lock()
+	mock.CatalogV1Calls = append(mock.CatalogV1Calls, ClientCatalogV1Call{Ctx: ctx, ProjectID: projectID, Warehouse: warehouse, Opts: opts})
+	fn := mock.CatalogV1Func
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *rest.Catalog
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, projectID, warehouse, opts...)
+}
+
+// ClientPermissionV1Call records a call to Client.PermissionV1.
+type ClientPermissionV1Call struct {
+}
+
+// PermissionV1 implements client.Interface.
+func (mock *Client) PermissionV1() permissionv1.PermissionServiceInterface {
+	mock.mu.Lock()
+	mock.PermissionV1Calls = append(mock.PermissionV1Calls, ClientPermissionV1Call{})
+	fn := mock.PermissionV1Func
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 permissionv1.PermissionServiceInterface
+		)
+		return r0
+	}
+
+	return fn()
+}
+
+// ClientProjectV1Call records a call to Client.ProjectV1.
+type ClientProjectV1Call struct {
+}
+
+// ProjectV1 implements client.Interface.
+func (mock *Client) ProjectV1() managementv1.ProjectServiceInterface {
+	mock.mu.Lock()
+	mock.ProjectV1Calls = append(mock.ProjectV1Calls, ClientProjectV1Call{})
+	fn := mock.ProjectV1Func
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 managementv1.ProjectServiceInterface
+		)
+		return r0
+	}
+
+	return fn()
+}
+
+// ClientRoleV1Call records a call to Client.RoleV1.
+type ClientRoleV1Call struct {
+	ProjectID string
+}
+
+// RoleV1 implements client.Interface.
+func (mock *Client) RoleV1(projectID string) managementv1.RoleServiceInterface {
+	mock.mu.Lock()
+	mock.RoleV1Calls = append(mock.RoleV1Calls, ClientRoleV1Call{ProjectID: projectID})
+	fn := mock.RoleV1Func
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 managementv1.RoleServiceInterface
+		)
+		return r0
+	}
+
+	return fn(projectID)
+}
+
+// ClientServerV1Call records a call to Client.ServerV1.
+type ClientServerV1Call struct {
+}
+
+// ServerV1 implements client.Interface.
+func (mock *Client) ServerV1() managementv1.ServerServiceInterface {
+	mock.mu.Lock()
+	mock.ServerV1Calls = append(mock.ServerV1Calls, ClientServerV1Call{})
+	fn := mock.ServerV1Func
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 managementv1.ServerServiceInterface
+		)
+		return r0
+	}
+
+	return fn()
+}
+
+// ClientUserV1Call records a call to Client.UserV1.
+type ClientUserV1Call struct {
+}
+
+// UserV1 implements client.Interface.
+func (mock *Client) UserV1() managementv1.UserServiceInterface {
+	mock.mu.Lock()
+	mock.UserV1Calls = append(mock.UserV1Calls, ClientUserV1Call{})
+	fn := mock.UserV1Func
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 managementv1.UserServiceInterface
+		)
+		return r0
+	}
+
+	return fn()
+}
+
+// ClientWarehouseV1Call records a call to Client.WarehouseV1.
+type ClientWarehouseV1Call struct {
+	ProjectID string
+}
+
+// WarehouseV1 implements client.Interface.
+func (mock *Client) WarehouseV1(projectID string) managementv1.WarehouseServiceInterface {
+	mock.mu.Lock()
+	mock.WarehouseV1Calls = append(mock.WarehouseV1Calls, ClientWarehouseV1Call{ProjectID: projectID})
+	fn := mock.WarehouseV1Func
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 managementv1.WarehouseServiceInterface
+		)
+		return r0
+	}
+
+	return fn(projectID)
+}
@@ -0,0 +1,23 @@
+// Package mocks provides call-recording fakes of the client and service
+// interfaces, to be used in tests of code depending on this module.
+//
+//	server := &mocks.ServerService{
+//		InfoFunc: func(context.Context, ...core.RequestOptionFunc) (*managementv1.ServerInfo, *http.Response, error) {
+//			return &managementv1.ServerInfo{Version: "v0.9.0"}, nil, nil
+//		},
+//	}
+//
+//	c := &mocks.Client{
+//		ServerV1Func: func() managementv1.ServerServiceInterface { return server },
+//	}
+//
+//	// ... run the code under test with c ...
+//
+//	if len(server.InfoCalls) != 1 {
+//		t.Fatal("expected one call to Info")
+//	}
+//
+// The fakes are generated, run go generate after changing an interface.
+package mocks
+
+//go:generate go run ../../internal/mockgen -dir .
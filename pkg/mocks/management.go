@@ -0,0 +1,1346 @@
+// Code generated by internal/mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+)
+
+// ServerService is a call-recording fake of managementv1.ServerServiceInterface.
+//
+// Set the <Method>Func fields to stub methods, methods without a stub
+// return zero values. Calls are recorded in the <Method>Calls fields.
+type ServerService struct {
+	mu sync.Mutex
+
+	BootstrapFunc  func(ctx context.Context, opts *managementv1.BootstrapServerOptions, options ...core.RequestOptionFunc) (*http.Response, error)
+	BootstrapCalls []ServerServiceBootstrapCall
+
+	GetAllowedActionsFunc  func(ctx context.Context, opts *managementv1.GetServerAllowedActionsOptions, options ...core.RequestOptionFunc) (*managementv1.GetServerAllowedActionsResponse, *http.Response, error)
+	GetAllowedActionsCalls []ServerServiceGetAllowedActionsCall
+
+	InfoFunc  func(ctx context.Context, options ...core.RequestOptionFunc) (*managementv1.ServerInfo, *http.Response, error)
+	InfoCalls []ServerServiceInfoCall
+}
+
+var _ managementv1.ServerServiceInterface = (*ServerService)(nil)
+
+// ServerServiceBootstrapCall records a call to ServerService.Bootstrap.
+type ServerServiceBootstrapCall struct {
+	Ctx     context.Context
+	Opts    *managementv1.BootstrapServerOptions
+	Options []core.RequestOptionFunc
+}
+
+// Bootstrap implements managementv1.ServerServiceInterface.
+func (mock *ServerService) Bootstrap(ctx context.Context, opts *managementv1.BootstrapServerOptions, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.BootstrapCalls = append(mock.BootstrapCalls, ServerServiceBootstrapCall{Ctx: ctx, Opts: opts, Options: options})
+	fn := mock.BootstrapFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, opts, options...)
+}
+
+// ServerServiceGetAllowedActionsCall records a call to ServerService.GetAllowedActions.
+type ServerServiceGetAllowedActionsCall struct {
+	Ctx     context.Context
+	Opts    *managementv1.GetServerAllowedActionsOptions
+	Options []core.RequestOptionFunc
+}
+
+// GetAllowedActions implements managementv1.ServerServiceInterface.
+func (mock *ServerService) GetAllowedActions(ctx context.Context, opts *managementv1.GetServerAllowedActionsOptions, options ...core.RequestOptionFunc) (*managementv1.GetServerAllowedActionsResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAllowedActionsCalls = append(mock.GetAllowedActionsCalls, ServerServiceGetAllowedActionsCall{Ctx: ctx, Opts: opts, Options: options})
+	fn := mock.GetAllowedActionsFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.GetServerAllowedActionsResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, opts, options...)
+}
+
+// ServerServiceInfoCall records a call to ServerService.Info.
+type ServerServiceInfoCall struct {
+	Ctx     context.Context
+	Options []core.RequestOptionFunc
+}
+
+// Info implements managementv1.ServerServiceInterface.
+func (mock *ServerService) Info(ctx context.Context, options ...core.RequestOptionFunc) (*managementv1.ServerInfo, *http.Response, error) {
+	mock.mu.Lock()
+	mock.InfoCalls = append(mock.InfoCalls, ServerServiceInfoCall{Ctx: ctx, Options: options})
+	fn := mock.InfoFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.ServerInfo
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, options...)
+}
+
+// ProjectService is a call-recording fake of managementv1.ProjectServiceInterface.
+//
+// Set the <Method>Func fields to stub methods, methods without a stub
+// return zero values. Calls are recorded in the <Method>Calls fields.
+type ProjectService struct {
+	mu sync.Mutex
+
+	CreateFunc  func(ctx context.Context, opts *managementv1.CreateProjectOptions, options ...core.RequestOptionFunc) (*managementv1.CreateProjectResponse, *http.Response, error)
+	CreateCalls []ProjectServiceCreateCall
+
+	DeleteFunc  func(ctx context.Context, id string, options ...core.RequestOptionFunc) (*http.Response, error)
+	DeleteCalls []ProjectServiceDeleteCall
+
+	GetFunc  func(ctx context.Context, id string, options ...core.RequestOptionFunc) (*managementv1.Project, *http.Response, error)
+	GetCalls []ProjectServiceGetCall
+
+	GetAPIStatisticsFunc  func(ctx context.Context, id string, opt *managementv1.GetAPIStatisticsOptions, options ...core.RequestOptionFunc) (*managementv1.GetAPIStatisticsResponse, *http.Response, error)
+	GetAPIStatisticsCalls []ProjectServiceGetAPIStatisticsCall
+
+	GetAllowedActionsFunc  func(ctx context.Context, id string, opt *managementv1.GetProjectAllowedActionsOptions, options ...core.RequestOptionFunc) (*managementv1.GetProjectAllowedActionsResponse, *http.Response, error)
+	GetAllowedActionsCalls []ProjectServiceGetAllowedActionsCall
+
+	ListFunc  func(ctx context.Context, options ...core.RequestOptionFunc) (*managementv1.ListProjectsResponse, *http.Response, error)
+	ListCalls []ProjectServiceListCall
+
+	RenameFunc  func(ctx context.Context, id string, opts *managementv1.RenameProjectOptions, options ...core.RequestOptionFunc) (*http.Response, error)
+	RenameCalls []ProjectServiceRenameCall
+}
+
+var _ managementv1.ProjectServiceInterface = (*ProjectService)(nil)
+
+// ProjectServiceCreateCall records a call to ProjectService.Create.
+type ProjectServiceCreateCall struct {
+	Ctx     context.Context
+	Opts    *managementv1.CreateProjectOptions
+	Options []core.RequestOptionFunc
+}
+
+// Create implements managementv1.ProjectServiceInterface.
+func (mock *ProjectService) Create(ctx context.Context, opts *managementv1.CreateProjectOptions, options ...core.RequestOptionFunc) (*managementv1.CreateProjectResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.CreateCalls = append(mock.CreateCalls, ProjectServiceCreateCall{Ctx: ctx, Opts: opts, Options: options})
+	fn := mock.CreateFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.CreateProjectResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, opts, options...)
+}
+
+// ProjectServiceDeleteCall records a call to ProjectService.Delete.
+type ProjectServiceDeleteCall struct {
+	Ctx     context.Context
+	ID      string
+	Options []core.RequestOptionFunc
+}
+
+// Delete implements managementv1.ProjectServiceInterface.
+func (mock *ProjectService) Delete(ctx context.Context, id string, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.DeleteCalls = append(mock.DeleteCalls, ProjectServiceDeleteCall{Ctx: ctx, ID: id, Options: options})
+	fn := mock.DeleteFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, options...)
+}
+
+// ProjectServiceGetCall records a call to ProjectService.Get.
+type ProjectServiceGetCall struct {
+	Ctx     context.Context
+	ID      string
+	Options []core.RequestOptionFunc
+}
+
+// Get implements managementv1.ProjectServiceInterface.
+func (mock *ProjectService) Get(ctx context.Context, id string, options ...core.RequestOptionFunc) (*managementv1.Project, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetCalls = append(mock.GetCalls, ProjectServiceGetCall{Ctx: ctx, ID: id, Options: options})
+	fn := mock.GetFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.Project
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, options...)
+}
+
+// ProjectServiceGetAPIStatisticsCall records a call to ProjectService.GetAPIStatistics.
+type ProjectServiceGetAPIStatisticsCall struct {
+	Ctx     context.Context
+	ID      string
+	Opt     *managementv1.GetAPIStatisticsOptions
+	Options []core.RequestOptionFunc
+}
+
+// GetAPIStatistics implements managementv1.ProjectServiceInterface.
+func (mock *ProjectService) GetAPIStatistics(ctx context.Context, id string, opt *managementv1.GetAPIStatisticsOptions, options ...core.RequestOptionFunc) (*managementv1.GetAPIStatisticsResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAPIStatisticsCalls = append(mock.GetAPIStatisticsCalls, ProjectServiceGetAPIStatisticsCall{Ctx: ctx, ID: id, Opt: opt, Options: options})
+	fn := mock.GetAPIStatisticsFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.GetAPIStatisticsResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, opt, options...)
+}
+
+// ProjectServiceGetAllowedActionsCall records a call to ProjectService.GetAllowedActions.
+type ProjectServiceGetAllowedActionsCall struct {
+	Ctx     context.Context
+	ID      string
+	Opt     *managementv1.GetProjectAllowedActionsOptions
+	Options []core.RequestOptionFunc
+}
+
+// GetAllowedActions implements managementv1.ProjectServiceInterface.
+func (mock *ProjectService) GetAllowedActions(ctx context.Context, id string, opt *managementv1.GetProjectAllowedActionsOptions, options ...core.RequestOptionFunc) (*managementv1.GetProjectAllowedActionsResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAllowedActionsCalls = append(mock.GetAllowedActionsCalls, ProjectServiceGetAllowedActionsCall{Ctx: ctx, ID: id, Opt: opt, Options: options})
+	fn := mock.GetAllowedActionsFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.GetProjectAllowedActionsResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, opt, options...)
+}
+
+// ProjectServiceListCall records a call to ProjectService.List.
+type ProjectServiceListCall struct {
+	Ctx     context.Context
+	Options []core.RequestOptionFunc
+}
+
+// List implements managementv1.ProjectServiceInterface.
+func (mock *ProjectService) List(ctx context.Context, options ...core.RequestOptionFunc) (*managementv1.ListProjectsResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.ListCalls = append(mock.ListCalls, ProjectServiceListCall{Ctx: ctx, Options: options})
+	fn := mock.ListFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.ListProjectsResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, options...)
+}
+
+// ProjectServiceRenameCall records a call to ProjectService.Rename.
+type ProjectServiceRenameCall struct {
+	Ctx     context.Context
+	ID      string
+	Opts    *managementv1.RenameProjectOptions
+	Options []core.RequestOptionFunc
+}
+
+// Rename implements managementv1.ProjectServiceInterface.
+func (mock *ProjectService) Rename(ctx context.Context, id string, opts *managementv1.RenameProjectOptions, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.RenameCalls = append(mock.RenameCalls, ProjectServiceRenameCall{Ctx: ctx, ID: id, Opts: opts, Options: options})
+	fn := mock.RenameFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, opts, options...)
+}
+
+// UserService is a call-recording fake of managementv1.UserServiceInterface.
+//
+// Set the <Method>Func fields to stub methods, methods without a stub
+// return zero values. Calls are recorded in the <Method>Calls fields.
+type UserService struct {
+	mu sync.Mutex
+
+	DeleteFunc  func(ctx context.Context, id string, options ...core.RequestOptionFunc) (*http.Response, error)
+	DeleteCalls []UserServiceDeleteCall
+
+	GetFunc  func(ctx context.Context, id string, options ...core.RequestOptionFunc) (*managementv1.User, *http.Response, error)
+	GetCalls []UserServiceGetCall
+
+	ListFunc  func(ctx context.Context, opt *managementv1.ListUsersOptions, options ...core.RequestOptionFunc) (*managementv1.ListUsersResponse, *http.Response, error)
+	ListCalls []UserServiceListCall
+
+	ProvisionFunc  func(ctx context.Context, opts *managementv1.ProvisionUserOptions, options ...core.RequestOptionFunc) (*managementv1.User, *http.Response, error)
+	ProvisionCalls []UserServiceProvisionCall
+
+	SearchFunc  func(ctx context.Context, opt *managementv1.SearchUserOptions, options ...core.RequestOptionFunc) (*managementv1.SearchUserResponse, *http.Response, error)
+	SearchCalls []UserServiceSearchCall
+
+	WhoamiFunc  func(ctx context.Context, options ...core.RequestOptionFunc) (*managementv1.User, *http.Response, error)
+	WhoamiCalls []UserServiceWhoamiCall
+}
+
+var _ managementv1.UserServiceInterface = (*UserService)(nil)
+
+// UserServiceDeleteCall records a call to UserService.Delete.
+type UserServiceDeleteCall struct {
+	Ctx     context.Context
+	ID      string
+	Options []core.RequestOptionFunc
+}
+
+// Delete implements managementv1.UserServiceInterface.
+func (mock *UserService) Delete(ctx context.Context, id string, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.DeleteCalls = append(mock.DeleteCalls, UserServiceDeleteCall{Ctx: ctx, ID: id, Options: options})
+	fn := mock.DeleteFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, options...)
+}
+
+// UserServiceGetCall records a call to UserService.Get.
+type UserServiceGetCall struct {
+	Ctx     context.Context
+	ID      string
+	Options []core.RequestOptionFunc
+}
+
+// Get implements managementv1.UserServiceInterface.
+func (mock *UserService) Get(ctx context.Context, id string, options ...core.RequestOptionFunc) (*managementv1.User, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetCalls = append(mock.GetCalls, UserServiceGetCall{Ctx: ctx, ID: id, Options: options})
+	fn := mock.GetFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.User
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, options...)
+}
+
+// UserServiceListCall records a call to UserService.List.
+type UserServiceListCall struct {
+	Ctx     context.Context
+	Opt     *managementv1.ListUsersOptions
+	Options []core.RequestOptionFunc
+}
+
+// List implements managementv1.UserServiceInterface.
+func (mock *UserService) List(ctx context.Context, opt *managementv1.ListUsersOptions, options ...core.RequestOptionFunc) (*managementv1.ListUsersResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.ListCalls = append(mock.ListCalls, UserServiceListCall{Ctx: ctx, Opt: opt, Options: options})
+	fn := mock.ListFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.ListUsersResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, opt, options...)
+}
+
+// UserServiceProvisionCall records a call to UserService.Provision.
+type UserServiceProvisionCall struct {
+	Ctx     context.Context
+	Opts    *managementv1.ProvisionUserOptions
+	Options []core.RequestOptionFunc
+}
+
+// Provision implements managementv1.UserServiceInterface.
+func (mock *UserService) Provision(ctx context.Context, opts *managementv1.ProvisionUserOptions, options ...core.RequestOptionFunc) (*managementv1.User, *http.Response, error) {
+	mock.mu.Lock()
+	mock.ProvisionCalls = append(mock.ProvisionCalls, UserServiceProvisionCall{Ctx: ctx, Opts: opts, Options: options})
+	fn := mock.ProvisionFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.User
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, opts, options...)
+}
+
+// UserServiceSearchCall records a call to UserService.Search.
+type UserServiceSearchCall struct {
+	Ctx     context.Context
+	Opt     *managementv1.SearchUserOptions
+	Options []core.RequestOptionFunc
+}
+
+// Search implements managementv1.UserServiceInterface.
+func (mock *UserService) Search(ctx context.Context, opt *managementv1.SearchUserOptions, options ...core.RequestOptionFunc) (*managementv1.SearchUserResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.SearchCalls = append(mock.SearchCalls, UserServiceSearchCall{Ctx: ctx, Opt: opt, Options: options})
+	fn := mock.SearchFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.SearchUserResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, opt, options...)
+}
+
+// UserServiceWhoamiCall records a call to UserService.Whoami.
+type UserServiceWhoamiCall struct {
+	Ctx     context.Context
+	Options []core.RequestOptionFunc
+}
+
+// Whoami implements managementv1.UserServiceInterface.
+func (mock *UserService) Whoami(ctx context.Context, options ...core.RequestOptionFunc) (*managementv1.User, *http.Response, error) {
+	mock.mu.Lock()
+	mock.WhoamiCalls = append(mock.WhoamiCalls, UserServiceWhoamiCall{Ctx: ctx, Options: options})
+	fn := mock.WhoamiFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.User
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, options...)
+}
+
+// RoleService is a call-recording fake of managementv1.RoleServiceInterface.
+//
+// Set the <Method>Func fields to stub methods, methods without a stub
+// return zero values. Calls are recorded in the <Method>Calls fields.
+type RoleService struct {
+	mu sync.Mutex
+
+	CreateFunc  func(ctx context.Context, opts *managementv1.CreateRoleOptions, options ...core.RequestOptionFunc) (*managementv1.Role, *http.Response, error)
+	CreateCalls []RoleServiceCreateCall
+
+	DeleteFunc  func(ctx context.Context, id string, options ...core.RequestOptionFunc) (*http.Response, error)
+	DeleteCalls []RoleServiceDeleteCall
+
+	GetFunc  func(ctx context.Context, id string, options ...core.RequestOptionFunc) (*managementv1.Role, *http.Response, error)
+	GetCalls []RoleServiceGetCall
+
+	ListFunc  func(ctx context.Context, opts *managementv1.ListRolesOptions, options ...core.RequestOptionFunc) (*managementv1.ListRolesResponse, *http.Response, error)
+	ListCalls []RoleServiceListCall
+
+	SearchFunc  func(ctx context.Context, opts *managementv1.SearchRoleOptions, options ...core.RequestOptionFunc) (*managementv1.SearchRoleResponse, *http.Response, error)
+	SearchCalls []RoleServiceSearchCall
+
+	UpdateFunc  func(ctx context.Context, id string, opts *managementv1.UpdateRoleOptions, options ...core.RequestOptionFunc) (*managementv1.Role, *http.Response, error)
+	UpdateCalls []RoleServiceUpdateCall
+}
+
+var _ managementv1.RoleServiceInterface = (*RoleService)(nil)
+
+// RoleServiceCreateCall records a call to RoleService.Create.
+type RoleServiceCreateCall struct {
+	Ctx     context.Context
+	Opts    *managementv1.CreateRoleOptions
+	Options []core.RequestOptionFunc
+}
+
+// Create implements managementv1.RoleServiceInterface.
+func (mock *RoleService) Create(ctx context.Context, opts *managementv1.CreateRoleOptions, options ...core.RequestOptionFunc) (*managementv1.Role, *http.Response, error) {
+	mock.mu.Lock()
+	mock.CreateCalls = append(mock.CreateCalls, RoleServiceCreateCall{Ctx: ctx, Opts: opts, Options: options})
+	fn := mock.CreateFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.Role
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, opts, options...)
+}
+
+// RoleServiceDeleteCall records a call to RoleService.Delete.
+type RoleServiceDeleteCall struct {
+	Ctx     context.Context
+	ID      string
+	Options []core.RequestOptionFunc
+}
+
+// Delete implements managementv1.RoleServiceInterface.
+func (mock *RoleService) Delete(ctx context.Context, id string, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.DeleteCalls = append(mock.DeleteCalls, RoleServiceDeleteCall{Ctx: ctx, ID: id, Options: options})
+	fn := mock.DeleteFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, options...)
+}
+
+// RoleServiceGetCall records a call to RoleService.Get.
+type RoleServiceGetCall struct {
+	Ctx     context.Context
+	ID      string
+	Options []core.RequestOptionFunc
+}
+
+// Get implements managementv1.RoleServiceInterface.
+func (mock *RoleService) Get(ctx context.Context, id string, options ...core.RequestOptionFunc) (*managementv1.Role, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetCalls = append(mock.GetCalls, RoleServiceGetCall{Ctx: ctx, ID: id, Options: options})
+	fn := mock.GetFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.Role
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, options...)
+}
+
+// RoleServiceListCall records a call to RoleService.List.
+type RoleServiceListCall struct {
+	Ctx     context.Context
+	Opts    *managementv1.ListRolesOptions
+	Options []core.RequestOptionFunc
+}
+
+// List implements managementv1.RoleServiceInterface.
+func (mock *RoleService) List(ctx context.Context, opts *managementv1.ListRolesOptions, options ...core.RequestOptionFunc) (*managementv1.ListRolesResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.ListCalls = append(mock.ListCalls, RoleServiceListCall{Ctx: ctx, Opts: opts, Options: options})
+	fn := mock.ListFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.ListRolesResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, opts, options...)
+}
+
+// RoleServiceSearchCall records a call to RoleService.Search.
+type RoleServiceSearchCall struct {
+	Ctx     context.Context
+	Opts    *managementv1.SearchRoleOptions
+	Options []core.RequestOptionFunc
+}
+
+// Search implements managementv1.RoleServiceInterface.
+func (mock *RoleService) Search(ctx context.Context, opts *managementv1.SearchRoleOptions, options ...core.RequestOptionFunc) (*managementv1.SearchRoleResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.SearchCalls = append(mock.SearchCalls, RoleServiceSearchCall{Ctx: ctx, Opts: opts, Options: options})
+	fn := mock.SearchFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.SearchRoleResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, opts, options...)
+}
+
+// RoleServiceUpdateCall records a call to RoleService.Update.
+type RoleServiceUpdateCall struct {
+	Ctx     context.Context
+	ID      string
+	Opts    *managementv1.UpdateRoleOptions
+	Options []core.RequestOptionFunc
+}
+
+// Update implements managementv1.RoleServiceInterface.
+func (mock *RoleService) Update(ctx context.Context, id string, opts *managementv1.UpdateRoleOptions, options ...core.RequestOptionFunc) (*managementv1.Role, *http.Response, error) {
+	mock.mu.Lock()
+	mock.UpdateCalls = append(mock.UpdateCalls, RoleServiceUpdateCall{Ctx: ctx, ID: id, Opts: opts, Options: options})
+	fn := mock.UpdateFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.Role
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, opts, options...)
+}
+
+// WarehouseService is a call-recording fake of managementv1.WarehouseServiceInterface.
+//
+// Set the <Method>Func fields to stub methods, methods without a stub
+// return zero values. Calls are recorded in the <Method>Calls fields.
+type WarehouseService struct {
+	mu sync.Mutex
+
+	ActivateFunc  func(ctx context.Context, id string, options ...core.RequestOptionFunc) (*http.Response, error)
+	ActivateCalls []WarehouseServiceActivateCall
+
+	CreateFunc  func(ctx context.Context, opt *managementv1.CreateWarehouseOptions, options ...core.RequestOptionFunc) (*managementv1.CreateWarehouseResponse, *http.Response, error)
+	CreateCalls []WarehouseServiceCreateCall
+
+	DeactivateFunc  func(ctx context.Context, id string, options ...core.RequestOptionFunc) (*http.Response, error)
+	DeactivateCalls []WarehouseServiceDeactivateCall
+
+	DeleteFunc  func(ctx context.Context, id string, opt *managementv1.DeleteWarehouseOptions, options ...core.RequestOptionFunc) (*http.Response, error)
+	DeleteCalls []WarehouseServiceDeleteCall
+
+	GetFunc  func(ctx context.Context, id string, options ...core.RequestOptionFunc) (*managementv1.Warehouse, *http.Response, error)
+	GetCalls []WarehouseServiceGetCall
+
+	GetAllowedActionsFunc  func(ctx context.Context, warehouseID string, opt *managementv1.GetWarehouseAllowedActionsOptions, options ...core.RequestOptionFunc) (*managementv1.GetWarehouseAllowedActionsResponse, *http.Response, error)
+	GetAllowedActionsCalls []WarehouseServiceGetAllowedActionsCall
+
+	GetNamespaceProtectionFunc  func(ctx context.Context, warehouseID string, namespaceID string, options ...core.RequestOptionFunc) (*managementv1.GetProtectionResponse, *http.Response, error)
+	GetNamespaceProtectionCalls []WarehouseServiceGetNamespaceProtectionCall
+
+	GetStatisticsFunc  func(ctx context.Context, id string, opt *managementv1.GetStatisticsOptions, options ...core.RequestOptionFunc) (*managementv1.GetStatisticsResponse, *http.Response, error)
+	GetStatisticsCalls []WarehouseServiceGetStatisticsCall
+
+	GetTableProtectionFunc  func(ctx context.Context, warehouseID string, tableID string, options ...core.RequestOptionFunc) (*managementv1.GetProtectionResponse, *http.Response, error)
+	GetTableProtectionCalls []WarehouseServiceGetTableProtectionCall
+
+	GetViewProtectionFunc  func(ctx context.Context, warehouseID string, viewID string, options ...core.RequestOptionFunc) (*managementv1.GetProtectionResponse, *http.Response, error)
+	GetViewProtectionCalls []WarehouseServiceGetViewProtectionCall
+
+	ListFunc  func(ctx context.Context, opt *managementv1.ListWarehouseOptions, options ...core.RequestOptionFunc) (*managementv1.ListWarehouseResponse, *http.Response, error)
+	ListCalls []WarehouseServiceListCall
+
+	ListSoftDeletedTabularsFunc  func(ctx context.Context, id string, opt *managementv1.ListSoftDeletedTabularsOptions, options ...core.RequestOptionFunc) (*managementv1.ListSoftDeletedTabularsResponse, *http.Response, error)
+	ListSoftDeletedTabularsCalls []WarehouseServiceListSoftDeletedTabularsCall
+
+	RenameFunc  func(ctx context.Context, id string, opt *managementv1.RenameWarehouseOptions, options ...core.RequestOptionFunc) (*http.Response, error)
+	RenameCalls []WarehouseServiceRenameCall
+
+	SetNamespaceProtectionFunc  func(ctx context.Context, warehouseID string, namespaceID string, opt *managementv1.SetProtectionOptions, options ...core.RequestOptionFunc) (*managementv1.GetProtectionResponse, *http.Response, error)
+	SetNamespaceProtectionCalls []WarehouseServiceSetNamespaceProtectionCall
+
+	SetProtectionFunc  func(ctx context.Context, id string, protected bool, options ...core.RequestOptionFunc) (*managementv1.SetProtectionResponse, *http.Response, error)
+	SetProtectionCalls []WarehouseServiceSetProtectionCall
+
+	SetTableProtectionFunc  func(ctx context.Context, warehouseID string, tableID string, opt *managementv1.SetProtectionOptions, options ...core.RequestOptionFunc) (*managementv1.GetProtectionResponse, *http.Response, error)
+	SetTableProtectionCalls []WarehouseServiceSetTableProtectionCall
+
+	SetViewProtectionFunc  func(ctx context.Context, warehouseID string, viewID string, opt *managementv1.SetProtectionOptions, options ...core.RequestOptionFunc) (*managementv1.GetProtectionResponse, *http.Response, error)
+	SetViewProtectionCalls []WarehouseServiceSetViewProtectionCall
+
+	SetWarehouseProtectionFunc  func(ctx context.Context, id string, opt *managementv1.SetProtectionOptions, options ...core.RequestOptionFunc) (*managementv1.GetProtectionResponse, *http.Response, error)
+	SetWarehouseProtectionCalls []WarehouseServiceSetWarehouseProtectionCall
+
+	UndropTabularFunc  func(ctx context.Context, id string, opt *managementv1.UndropTabularOptions, options ...core.RequestOptionFunc) (*http.Response, error)
+	UndropTabularCalls []WarehouseServiceUndropTabularCall
+
+	UpdateDeleteProfileFunc  func(ctx context.Context, id string, opt *managementv1.UpdateDeleteProfileOptions, options ...core.RequestOptionFunc) (*http.Response, error)
+	UpdateDeleteProfileCalls []WarehouseServiceUpdateDeleteProfileCall
+
+	UpdateStorageCredentialFunc  func(ctx context.Context, id string, opt *managementv1.UpdateStorageCredentialOptions, options ...core.RequestOptionFunc) (*http.Response, error)
+	UpdateStorageCredentialCalls []WarehouseServiceUpdateStorageCredentialCall
+
+	UpdateStorageProfileFunc  func(ctx context.Context, id string, opt *managementv1.UpdateStorageProfileOptions, options ...core.RequestOptionFunc) (*http.Response, error)
+	UpdateStorageProfileCalls []WarehouseServiceUpdateStorageProfileCall
+}
+
+var _ managementv1.WarehouseServiceInterface = (*WarehouseService)(nil)
+
+// WarehouseServiceActivateCall records a call to WarehouseService.Activate.
+type WarehouseServiceActivateCall struct {
+	Ctx     context.Context
+	ID      string
+	Options []core.RequestOptionFunc
+}
+
+// Activate implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) Activate(ctx context.Context, id string, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.ActivateCalls = append(mock.ActivateCalls, WarehouseServiceActivateCall{Ctx: ctx, ID: id, Options: options})
+	fn := mock.ActivateFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, options...)
+}
+
+// WarehouseServiceCreateCall records a call to WarehouseService.Create.
+type WarehouseServiceCreateCall struct {
+	Ctx     context.Context
+	Opt     *managementv1.CreateWarehouseOptions
+	Options []core.RequestOptionFunc
+}
+
+// Create implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) Create(ctx context.Context, opt *managementv1.CreateWarehouseOptions, options ...core.RequestOptionFunc) (*managementv1.CreateWarehouseResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.CreateCalls = append(mock.CreateCalls, WarehouseServiceCreateCall{Ctx: ctx, Opt: opt, Options: options})
+	fn := mock.CreateFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.CreateWarehouseResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, opt, options...)
+}
+
+// WarehouseServiceDeactivateCall records a call to WarehouseService.Deactivate.
+type WarehouseServiceDeactivateCall struct {
+	Ctx     context.Context
+	ID      string
+	Options []core.RequestOptionFunc
+}
+
+// Deactivate implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) Deactivate(ctx context.Context, id string, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.DeactivateCalls = append(mock.DeactivateCalls, WarehouseServiceDeactivateCall{Ctx: ctx, ID: id, Options: options})
+	fn := mock.DeactivateFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, options...)
+}
+
+// WarehouseServiceDeleteCall records a call to WarehouseService.Delete.
+type WarehouseServiceDeleteCall struct {
+	Ctx     context.Context
+	ID      string
+	Opt     *managementv1.DeleteWarehouseOptions
+	Options []core.RequestOptionFunc
+}
+
+// Delete implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) Delete(ctx context.Context, id string, opt *managementv1.DeleteWarehouseOptions, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.DeleteCalls = append(mock.DeleteCalls, WarehouseServiceDeleteCall{Ctx: ctx, ID: id, Opt: opt, Options: options})
+	fn := mock.DeleteFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, opt, options...)
+}
+
+// WarehouseServiceGetCall records a call to WarehouseService.Get.
+type WarehouseServiceGetCall struct {
+	Ctx     context.Context
+	ID      string
+	Options []core.RequestOptionFunc
+}
+
+// Get implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) Get(ctx context.Context, id string, options ...core.RequestOptionFunc) (*managementv1.Warehouse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetCalls = append(mock.GetCalls, WarehouseServiceGetCall{Ctx: ctx, ID: id, Options: options})
+	fn := mock.GetFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.Warehouse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, options...)
+}
+
+// WarehouseServiceGetAllowedActionsCall records a call to WarehouseService.GetAllowedActions.
+type WarehouseServiceGetAllowedActionsCall struct {
+	Ctx         context.Context
+	WarehouseID string
+	Opt         *managementv1.GetWarehouseAllowedActionsOptions
+	Options     []core.RequestOptionFunc
+}
+
+// GetAllowedActions implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) GetAllowedActions(ctx context.Context, warehouseID string, opt *managementv1.GetWarehouseAllowedActionsOptions, options ...core.RequestOptionFunc) (*managementv1.GetWarehouseAllowedActionsResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAllowedActionsCalls = append(mock.GetAllowedActionsCalls, WarehouseServiceGetAllowedActionsCall{Ctx: ctx, WarehouseID: warehouseID, Opt: opt, Options: options})
+	fn := mock.GetAllowedActionsFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.GetWarehouseAllowedActionsResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, warehouseID, opt, options...)
+}
+
+// WarehouseServiceGetNamespaceProtectionCall records a call to WarehouseService.GetNamespaceProtection.
+type WarehouseServiceGetNamespaceProtectionCall struct {
+	Ctx         context.Context
+	WarehouseID string
+	NamespaceID string
+	Options     []core.RequestOptionFunc
+}
+
+// GetNamespaceProtection implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) GetNamespaceProtection(ctx context.Context, warehouseID string, namespaceID string, options ...core.RequestOptionFunc) (*managementv1.GetProtectionResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetNamespaceProtectionCalls = append(mock.GetNamespaceProtectionCalls, WarehouseServiceGetNamespaceProtectionCall{Ctx: ctx, WarehouseID: warehouseID, NamespaceID: namespaceID, Options: options})
+	fn := mock.GetNamespaceProtectionFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.GetProtectionResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, warehouseID, namespaceID, options...)
+}
+
+// WarehouseServiceGetStatisticsCall records a call to WarehouseService.GetStatistics.
+type WarehouseServiceGetStatisticsCall struct {
+	Ctx     context.Context
+	ID      string
+	Opt     *managementv1.GetStatisticsOptions
+	Options []core.RequestOptionFunc
+}
+
+// GetStatistics implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) GetStatistics(ctx context.Context, id string, opt *managementv1.GetStatisticsOptions, options ...core.RequestOptionFunc) (*managementv1.GetStatisticsResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetStatisticsCalls = append(mock.GetStatisticsCalls, WarehouseServiceGetStatisticsCall{Ctx: ctx, ID: id, Opt: opt, Options: options})
+	fn := mock.GetStatisticsFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.GetStatisticsResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, opt, options...)
+}
+
+// WarehouseServiceGetTableProtectionCall records a call to WarehouseService.GetTableProtection.
+type WarehouseServiceGetTableProtectionCall struct {
+	Ctx         context.Context
+	WarehouseID string
+	TableID     string
+	Options     []core.RequestOptionFunc
+}
+
+// GetTableProtection implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) GetTableProtection(ctx context.Context, warehouseID string, tableID string, options ...core.RequestOptionFunc) (*managementv1.GetProtectionResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetTableProtectionCalls = append(mock.GetTableProtectionCalls, WarehouseServiceGetTableProtectionCall{Ctx: ctx, WarehouseID: warehouseID, TableID: tableID, Options: options})
+	fn := mock.GetTableProtectionFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.GetProtectionResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, warehouseID, tableID, options...)
+}
+
+// WarehouseServiceGetViewProtectionCall records a call to WarehouseService.GetViewProtection.
+type WarehouseServiceGetViewProtectionCall struct {
+	Ctx         context.Context
+	WarehouseID string
+	ViewID      string
+	Options     []core.RequestOptionFunc
+}
+
+// GetViewProtection implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) GetViewProtection(ctx context.Context, warehouseID string, viewID string, options ...core.RequestOptionFunc) (*managementv1.GetProtectionResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetViewProtectionCalls = append(mock.GetViewProtectionCalls, WarehouseServiceGetViewProtectionCall{Ctx: ctx, WarehouseID: warehouseID, ViewID: viewID, Options: options})
+	fn := mock.GetViewProtectionFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.GetProtectionResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, warehouseID, viewID, options...)
+}
+
+// WarehouseServiceListCall records a call to WarehouseService.List.
+type WarehouseServiceListCall struct {
+	Ctx     context.Context
+	Opt     *managementv1.ListWarehouseOptions
+	Options []core.RequestOptionFunc
+}
+
+// List implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) List(ctx context.Context, opt *managementv1.ListWarehouseOptions, options ...core.RequestOptionFunc) (*managementv1.ListWarehouseResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.ListCalls = append(mock.ListCalls, WarehouseServiceListCall{Ctx: ctx, Opt: opt, Options: options})
+	fn := mock.ListFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.ListWarehouseResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, opt, options...)
+}
+
+// WarehouseServiceListSoftDeletedTabularsCall records a call to WarehouseService.ListSoftDeletedTabulars.
+type WarehouseServiceListSoftDeletedTabularsCall struct {
+	Ctx     context.Context
+	ID      string
+	Opt     *managementv1.ListSoftDeletedTabularsOptions
+	Options []core.RequestOptionFunc
+}
+
+// ListSoftDeletedTabulars implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) ListSoftDeletedTabulars(ctx context.Context, id string, opt *managementv1.ListSoftDeletedTabularsOptions, options ...core.RequestOptionFunc) (*managementv1.ListSoftDeletedTabularsResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.ListSoftDeletedTabularsCalls = append(mock.ListSoftDeletedTabularsCalls, WarehouseServiceListSoftDeletedTabularsCall{Ctx: ctx, ID: id, Opt: opt, Options: options})
+	fn := mock.ListSoftDeletedTabularsFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.ListSoftDeletedTabularsResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, opt, options...)
+}
+
+// WarehouseServiceRenameCall records a call to WarehouseService.Rename.
+type WarehouseServiceRenameCall struct {
+	Ctx     context.Context
+	ID      string
+	Opt     *managementv1.RenameWarehouseOptions
+	Options []core.RequestOptionFunc
+}
+
+// Rename implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) Rename(ctx context.Context, id string, opt *managementv1.RenameWarehouseOptions, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.RenameCalls = append(mock.RenameCalls, WarehouseServiceRenameCall{Ctx: ctx, ID: id, Opt: opt, Options: options})
+	fn := mock.RenameFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, opt, options...)
+}
+
+// WarehouseServiceSetNamespaceProtectionCall records a call to WarehouseService.SetNamespaceProtection.
+type WarehouseServiceSetNamespaceProtectionCall struct {
+	Ctx         context.Context
+	WarehouseID string
+	NamespaceID string
+	Opt         *managementv1.SetProtectionOptions
+	Options     []core.RequestOptionFunc
+}
+
+// SetNamespaceProtection implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) SetNamespaceProtection(ctx context.Context, warehouseID string, namespaceID string, opt *managementv1.SetProtectionOptions, options ...core.RequestOptionFunc) (*managementv1.GetProtectionResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.SetNamespaceProtectionCalls = append(mock.SetNamespaceProtectionCalls, WarehouseServiceSetNamespaceProtectionCall{Ctx: ctx, WarehouseID: warehouseID, NamespaceID: namespaceID, Opt: opt, Options: options})
+	fn := mock.SetNamespaceProtectionFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.GetProtectionResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, warehouseID, namespaceID, opt, options...)
+}
+
+// WarehouseServiceSetProtectionCall records a call to WarehouseService.SetProtection.
+type WarehouseServiceSetProtectionCall struct {
+	Ctx       context.Context
+	ID        string
+	Protected bool
+	Options   []core.RequestOptionFunc
+}
+
+// SetProtection implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) SetProtection(ctx context.Context, id string, protected bool, options ...core.RequestOptionFunc) (*managementv1.SetProtectionResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.SetProtectionCalls = append(mock.SetProtectionCalls, WarehouseServiceSetProtectionCall{Ctx: ctx, ID: id, Protected: protected, Options: options})
+	fn := mock.SetProtectionFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.SetProtectionResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, protected, options...)
+}
+
+// WarehouseServiceSetTableProtectionCall records a call to WarehouseService.SetTableProtection.
+type WarehouseServiceSetTableProtectionCall struct {
+	Ctx         context.Context
+	WarehouseID string
+	TableID     string
+	Opt         *managementv1.SetProtectionOptions
+	Options     []core.RequestOptionFunc
+}
+
+// SetTableProtection implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) SetTableProtection(ctx context.Context, warehouseID string, tableID string, opt *managementv1.SetProtectionOptions, options ...core.RequestOptionFunc) (*managementv1.GetProtectionResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.SetTableProtectionCalls = append(mock.SetTableProtectionCalls, WarehouseServiceSetTableProtectionCall{Ctx: ctx, WarehouseID: warehouseID, TableID: tableID, Opt: opt, Options: options})
+	fn := mock.SetTableProtectionFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.GetProtectionResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, warehouseID, tableID, opt, options...)
+}
+
+// WarehouseServiceSetViewProtectionCall records a call to WarehouseService.SetViewProtection.
+type WarehouseServiceSetViewProtectionCall struct {
+	Ctx         context.Context
+	WarehouseID string
+	ViewID      string
+	Opt         *managementv1.SetProtectionOptions
+	Options     []core.RequestOptionFunc
+}
+
+// SetViewProtection implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) SetViewProtection(ctx context.Context, warehouseID string, viewID string, opt *managementv1.SetProtectionOptions, options ...core.RequestOptionFunc) (*managementv1.GetProtectionResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.SetViewProtectionCalls = append(mock.SetViewProtectionCalls, WarehouseServiceSetViewProtectionCall{Ctx: ctx, WarehouseID: warehouseID, ViewID: viewID, Opt: opt, Options: options})
+	fn := mock.SetViewProtectionFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.GetProtectionResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, warehouseID, viewID, opt, options...)
+}
+
+// WarehouseServiceSetWarehouseProtectionCall records a call to WarehouseService.SetWarehouseProtection.
+type WarehouseServiceSetWarehouseProtectionCall struct {
+	Ctx     context.Context
+	ID      string
+	Opt     *managementv1.SetProtectionOptions
+	Options []core.RequestOptionFunc
+}
+
+// SetWarehouseProtection implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) SetWarehouseProtection(ctx context.Context, id string, opt *managementv1.SetProtectionOptions, options ...core.RequestOptionFunc) (*managementv1.GetProtectionResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.SetWarehouseProtectionCalls = append(mock.SetWarehouseProtectionCalls, WarehouseServiceSetWarehouseProtectionCall{Ctx: ctx, ID: id, Opt: opt, Options: options})
+	fn := mock.SetWarehouseProtectionFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *managementv1.GetProtectionResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, opt, options...)
+}
+
+// WarehouseServiceUndropTabularCall records a call to WarehouseService.UndropTabular.
+type WarehouseServiceUndropTabularCall struct {
+	Ctx     context.Context
+	ID      string
+	Opt     *managementv1.UndropTabularOptions
+	Options []core.RequestOptionFunc
+}
+
+// UndropTabular implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) UndropTabular(ctx context.Context, id string, opt *managementv1.UndropTabularOptions, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.UndropTabularCalls = append(mock.UndropTabularCalls, WarehouseServiceUndropTabularCall{Ctx: ctx, ID: id, Opt: opt, Options: options})
+	fn := mock.UndropTabularFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, opt, options...)
+}
+
+// WarehouseServiceUpdateDeleteProfileCall records a call to WarehouseService.UpdateDeleteProfile.
+type WarehouseServiceUpdateDeleteProfileCall struct {
+	Ctx     context.Context
+	ID      string
+	Opt     *managementv1.UpdateDeleteProfileOptions
+	Options []core.RequestOptionFunc
+}
+
+// UpdateDeleteProfile implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) UpdateDeleteProfile(ctx context.Context, id string, opt *managementv1.UpdateDeleteProfileOptions, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.UpdateDeleteProfileCalls = append(mock.UpdateDeleteProfileCalls, WarehouseServiceUpdateDeleteProfileCall{Ctx: ctx, ID: id, Opt: opt, Options: options})
+	fn := mock.UpdateDeleteProfileFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, opt, options...)
+}
+
+// WarehouseServiceUpdateStorageCredentialCall records a call to WarehouseService.UpdateStorageCredential.
+type WarehouseServiceUpdateStorageCredentialCall struct {
+	Ctx     context.Context
+	ID      string
+	Opt     *managementv1.UpdateStorageCredentialOptions
+	Options []core.RequestOptionFunc
+}
+
+// UpdateStorageCredential implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) UpdateStorageCredential(ctx context.Context, id string, opt *managementv1.UpdateStorageCredentialOptions, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.UpdateStorageCredentialCalls = append(mock.UpdateStorageCredentialCalls, WarehouseServiceUpdateStorageCredentialCall{Ctx: ctx, ID: id, Opt: opt, Options: options})
+	fn := mock.UpdateStorageCredentialFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, opt, options...)
+}
+
+// WarehouseServiceUpdateStorageProfileCall records a call to WarehouseService.UpdateStorageProfile.
+type WarehouseServiceUpdateStorageProfileCall struct {
+	Ctx     context.Context
+	ID      string
+	Opt     *managementv1.UpdateStorageProfileOptions
+	Options []core.RequestOptionFunc
+}
+
+// UpdateStorageProfile implements managementv1.WarehouseServiceInterface.
+func (mock *WarehouseService) UpdateStorageProfile(ctx context.Context, id string, opt *managementv1.UpdateStorageProfileOptions, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.UpdateStorageProfileCalls = append(mock.UpdateStorageProfileCalls, WarehouseServiceUpdateStorageProfileCall{Ctx: ctx, ID: id, Opt: opt, Options: options})
+	fn := mock.UpdateStorageProfileFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, opt, options...)
+}
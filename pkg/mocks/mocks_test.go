@@ -0,0 +1,86 @@
+package mocks_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+	"github.com/baptistegh/go-lakekeeper/pkg/client"
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+	"github.com/baptistegh/go-lakekeeper/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serverVersion is an example of code depending on the client interface.
+func serverVersion(ctx context.Context, c client.Interface) (string, error) {
+	info, _, err := c.ServerV1().Info(ctx)
+	if err != nil {
+		return "", err
+	}
+	return info.Version, nil
+}
+
+func TestClient_Stubbed(t *testing.T) {
+	t.Parallel()
+
+	server := &mocks.ServerService{
+		InfoFunc: func(context.Context, ...core.RequestOptionFunc) (*managementv1.ServerInfo, *http.Response, error) {
+			return &managementv1.ServerInfo{Version: "v0.9.0"}, nil, nil
+		},
+	}
+
+	c := &mocks.Client{
+		ServerV1Func: func() managementv1.ServerServiceInterface { return server },
+	}
+
+	version, err := serverVersion(t.Context(), c)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v0.9.0", version)
+	assert.Len(t, c.ServerV1Calls, 1)
+	assert.Len(t, server.InfoCalls, 1)
+}
+
+func TestRoleService_RecordsCalls(t *testing.T) {
+	t.Parallel()
+
+	roles := &mocks.RoleService{}
+
+	c := &mocks.Client{
+		RoleV1Func: func(string) managementv1.RoleServiceInterface { return roles },
+	}
+
+	role, resp, err := c.RoleV1("project").Get(t.Context(), "role-id")
+	require.NoError(t, err)
+	assert.Nil(t, role)
+	assert.Nil(t, resp)
+
+	require.Len(t, c.RoleV1Calls, 1)
+	assert.Equal(t, "project", c.RoleV1Calls[0].ProjectID)
+
+	require.Len(t, roles.GetCalls, 1)
+	assert.Equal(t, "role-id", roles.GetCalls[0].ID)
+}
+
+func TestPermissionService_Stubbed(t *testing.T) {
+	t.Parallel()
+
+	warehouse := &mocks.WarehousePermissionService{
+		UpdateFunc: func(context.Context, string, *permissionv1.UpdateWarehousePermissionsOptions, ...core.RequestOptionFunc) (*http.Response, error) {
+			return nil, core.APIErrorFromMessage("forbidden")
+		},
+	}
+
+	permissions := &mocks.PermissionService{
+		WarehousePermissionFunc: func() permissionv1.WarehousePermissionServiceInterface { return warehouse },
+	}
+
+	_, err := permissions.WarehousePermission().Update(t.Context(), "warehouse-id", &permissionv1.UpdateWarehousePermissionsOptions{})
+	require.Error(t, err)
+
+	require.Len(t, warehouse.UpdateCalls, 1)
+	assert.Equal(t, "warehouse-id", warehouse.UpdateCalls[0].ID)
+}
@@ -0,0 +1,658 @@
+// Code generated by internal/mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+)
+
+// PermissionService is a call-recording fake of permissionv1.PermissionServiceInterface.
+//
+// Set the <Method>Func fields to stub methods, methods without a stub
+// return zero values. Calls are recorded in the <Method>Calls fields.
+type PermissionService struct {
+	mu sync.Mutex
+
+	ProjectPermissionFunc  func() permissionv1.ProjectPermissionServiceInterface
+	ProjectPermissionCalls []PermissionServiceProjectPermissionCall
+
+	RolePermissionFunc  func() permissionv1.RolePermissionServiceInterface
+	RolePermissionCalls []PermissionServiceRolePermissionCall
+
+	ServerPermissionFunc  func() permissionv1.ServerPermissionServiceInterface
+	ServerPermissionCalls []PermissionServiceServerPermissionCall
+
+	WarehousePermissionFunc  func() permissionv1.WarehousePermissionServiceInterface
+	WarehousePermissionCalls []PermissionServiceWarehousePermissionCall
+}
+
+var _ permissionv1.PermissionServiceInterface = (*PermissionService)(nil)
+
+// PermissionServiceProjectPermissionCall records a call to PermissionService.ProjectPermission.
+type PermissionServiceProjectPermissionCall struct {
+}
+
+// ProjectPermission implements permissionv1.PermissionServiceInterface.
+func (mock *PermissionService) ProjectPermission() permissionv1.ProjectPermissionServiceInterface {
+	mock.mu.Lock()
+	mock.ProjectPermissionCalls = append(mock.ProjectPermissionCalls, PermissionServiceProjectPermissionCall{})
+	fn := mock.ProjectPermissionFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 permissionv1.ProjectPermissionServiceInterface
+		)
+		return r0
+	}
+
+	return fn()
+}
+
+// PermissionServiceRolePermissionCall records a call to PermissionService.RolePermission.
+type PermissionServiceRolePermissionCall struct {
+}
+
+// RolePermission implements permissionv1.PermissionServiceInterface.
+func (mock *PermissionService) RolePermission() permissionv1.RolePermissionServiceInterface {
+	mock.mu.Lock()
+	mock.RolePermissionCalls = append(mock.RolePermissionCalls, PermissionServiceRolePermissionCall{})
+	fn := mock.RolePermissionFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 permissionv1.RolePermissionServiceInterface
+		)
+		return r0
+	}
+
+	return fn()
+}
+
+// PermissionServiceServerPermissionCall records a call to PermissionService.ServerPermission.
+type PermissionServiceServerPermissionCall struct {
+}
+
+// ServerPermission implements permissionv1.PermissionServiceInterface.
+func (mock *PermissionService) ServerPermission() permissionv1.ServerPermissionServiceInterface {
+	mock.mu.Lock()
+	mock.ServerPermissionCalls = append(mock.ServerPermissionCalls, PermissionServiceServerPermissionCall{})
+	fn := mock.ServerPermissionFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 permissionv1.ServerPermissionServiceInterface
+		)
+		return r0
+	}
+
+	return fn()
+}
+
+// PermissionServiceWarehousePermissionCall records a call to PermissionService.WarehousePermission.
+type PermissionServiceWarehousePermissionCall struct {
+}
+
+// WarehousePermission implements permissionv1.PermissionServiceInterface.
+func (mock *PermissionService) WarehousePermission() permissionv1.WarehousePermissionServiceInterface {
+	mock.mu.Lock()
+	mock.WarehousePermissionCalls = append(mock.WarehousePermissionCalls, PermissionServiceWarehousePermissionCall{})
+	fn := mock.WarehousePermissionFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 permissionv1.WarehousePermissionServiceInterface
+		)
+		return r0
+	}
+
+	return fn()
+}
+
+// ServerPermissionService is a call-recording fake of permissionv1.ServerPermissionServiceInterface.
+//
+// Set the <Method>Func fields to stub methods, methods without a stub
+// return zero values. Calls are recorded in the <Method>Calls fields.
+type ServerPermissionService struct {
+	mu sync.Mutex
+
+	GetAccessFunc  func(ctx context.Context, opts *permissionv1.GetServerAccessOptions, options ...core.RequestOptionFunc) (*permissionv1.GetServerAccessResponse, *http.Response, error)
+	GetAccessCalls []ServerPermissionServiceGetAccessCall
+
+	GetAllowedAuthorizerActionsFunc  func(ctx context.Context, opts *permissionv1.GetServerAllowedAuthorizerActionsOptions, options ...core.RequestOptionFunc) (*permissionv1.GetServerAllowedAuthorizerActionsResponse, *http.Response, error)
+	GetAllowedAuthorizerActionsCalls []ServerPermissionServiceGetAllowedAuthorizerActionsCall
+
+	GetAssignmentsFunc  func(ctx context.Context, opts *permissionv1.GetServerAssignmentsOptions, options ...core.RequestOptionFunc) (*permissionv1.GetServerAssignmentsResponse, *http.Response, error)
+	GetAssignmentsCalls []ServerPermissionServiceGetAssignmentsCall
+
+	UpdateFunc  func(ctx context.Context, opts *permissionv1.UpdateServerPermissionsOptions, options ...core.RequestOptionFunc) (*http.Response, error)
+	UpdateCalls []ServerPermissionServiceUpdateCall
+}
+
+var _ permissionv1.ServerPermissionServiceInterface = (*ServerPermissionService)(nil)
+
+// ServerPermissionServiceGetAccessCall records a call to ServerPermissionService.GetAccess.
+type ServerPermissionServiceGetAccessCall struct {
+	Ctx     context.Context
+	Opts    *permissionv1.GetServerAccessOptions
+	Options []core.RequestOptionFunc
+}
+
+// GetAccess implements permissionv1.ServerPermissionServiceInterface.
+func (mock *ServerPermissionService) GetAccess(ctx context.Context, opts *permissionv1.GetServerAccessOptions, options ...core.RequestOptionFunc) (*permissionv1.GetServerAccessResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAccessCalls = append(mock.GetAccessCalls, ServerPermissionServiceGetAccessCall{Ctx: ctx, Opts: opts, Options: options})
+	fn := mock.GetAccessFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *permissionv1.GetServerAccessResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, opts, options...)
+}
+
+// ServerPermissionServiceGetAllowedAuthorizerActionsCall records a call to ServerPermissionService.GetAllowedAuthorizerActions.
+type ServerPermissionServiceGetAllowedAuthorizerActionsCall struct {
+	Ctx     context.Context
+	Opts    *permissionv1.GetServerAllowedAuthorizerActionsOptions
+	Options []core.RequestOptionFunc
+}
+
+// GetAllowedAuthorizerActions implements permissionv1.ServerPermissionServiceInterface.
+func (mock *ServerPermissionService) GetAllowedAuthorizerActions(ctx context.Context, opts *permissionv1.GetServerAllowedAuthorizerActionsOptions, options ...core.RequestOptionFunc) (*permissionv1.GetServerAllowedAuthorizerActionsResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAllowedAuthorizerActionsCalls = append(mock.GetAllowedAuthorizerActionsCalls, ServerPermissionServiceGetAllowedAuthorizerActionsCall{Ctx: ctx, Opts: opts, Options: options})
+	fn := mock.GetAllowedAuthorizerActionsFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *permissionv1.GetServerAllowedAuthorizerActionsResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, opts, options...)
+}
+
+// ServerPermissionServiceGetAssignmentsCall records a call to ServerPermissionService.GetAssignments.
+type ServerPermissionServiceGetAssignmentsCall struct {
+	Ctx     context.Context
+	Opts    *permissionv1.GetServerAssignmentsOptions
+	Options []core.RequestOptionFunc
+}
+
+// GetAssignments implements permissionv1.ServerPermissionServiceInterface.
+func (mock *ServerPermissionService) GetAssignments(ctx context.Context, opts *permissionv1.GetServerAssignmentsOptions, options ...core.RequestOptionFunc) (*permissionv1.GetServerAssignmentsResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAssignmentsCalls = append(mock.GetAssignmentsCalls, ServerPermissionServiceGetAssignmentsCall{Ctx: ctx, Opts: opts, Options: options})
+	fn := mock.GetAssignmentsFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *permissionv1.GetServerAssignmentsResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, opts, options...)
+}
+
+// ServerPermissionServiceUpdateCall records a call to ServerPermissionService.Update.
+type ServerPermissionServiceUpdateCall struct {
+	Ctx     context.Context
+	Opts    *permissionv1.UpdateServerPermissionsOptions
+	Options []core.RequestOptionFunc
+}
+
+// Update implements permissionv1.ServerPermissionServiceInterface.
+func (mock *ServerPermissionService) Update(ctx context.Context, opts *permissionv1.UpdateServerPermissionsOptions, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.UpdateCalls = append(mock.UpdateCalls, ServerPermissionServiceUpdateCall{Ctx: ctx, Opts: opts, Options: options})
+	fn := mock.UpdateFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, opts, options...)
+}
+
+// ProjectPermissionService is a call-recording fake of permissionv1.ProjectPermissionServiceInterface.
+//
+// Set the <Method>Func fields to stub methods, methods without a stub
+// return zero values. Calls are recorded in the <Method>Calls fields.
+type ProjectPermissionService struct {
+	mu sync.Mutex
+
+	GetAccessFunc  func(ctx context.Context, id string, opts *permissionv1.GetProjectAccessOptions, options ...core.RequestOptionFunc) (*permissionv1.GetProjectAccessResponse, *http.Response, error)
+	GetAccessCalls []ProjectPermissionServiceGetAccessCall
+
+	GetAssignmentsFunc  func(ctx context.Context, id string, opts *permissionv1.GetProjectAssignmentsOptions, options ...core.RequestOptionFunc) (*permissionv1.GetProjectAssignmentsResponse, *http.Response, error)
+	GetAssignmentsCalls []ProjectPermissionServiceGetAssignmentsCall
+
+	UpdateFunc  func(ctx context.Context, id string, opts *permissionv1.UpdateProjectPermissionsOptions, options ...core.RequestOptionFunc) (*http.Response, error)
+	UpdateCalls []ProjectPermissionServiceUpdateCall
+}
+
+var _ permissionv1.ProjectPermissionServiceInterface = (*ProjectPermissionService)(nil)
+
+// ProjectPermissionServiceGetAccessCall records a call to ProjectPermissionService.GetAccess.
+type ProjectPermissionServiceGetAccessCall struct {
+	Ctx     context.Context
+	ID      string
+	Opts    *permissionv1.GetProjectAccessOptions
+	Options []core.RequestOptionFunc
+}
+
+// GetAccess implements permissionv1.ProjectPermissionServiceInterface.
+func (mock *ProjectPermissionService) GetAccess(ctx context.Context, id string, opts *permissionv1.GetProjectAccessOptions, options ...core.RequestOptionFunc) (*permissionv1.GetProjectAccessResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAccessCalls = append(mock.GetAccessCalls, ProjectPermissionServiceGetAccessCall{Ctx: ctx, ID: id, Opts: opts, Options: options})
+	fn := mock.GetAccessFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *permissionv1.GetProjectAccessResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, opts, options...)
+}
+
+// ProjectPermissionServiceGetAssignmentsCall records a call to ProjectPermissionService.GetAssignments.
+type ProjectPermissionServiceGetAssignmentsCall struct {
+	Ctx     context.Context
+	ID      string
+	Opts    *permissionv1.GetProjectAssignmentsOptions
+	Options []core.RequestOptionFunc
+}
+
+// GetAssignments implements permissionv1.ProjectPermissionServiceInterface.
+func (mock *ProjectPermissionService) GetAssignments(ctx context.Context, id string, opts *permissionv1.GetProjectAssignmentsOptions, options ...core.RequestOptionFunc) (*permissionv1.GetProjectAssignmentsResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAssignmentsCalls = append(mock.GetAssignmentsCalls, ProjectPermissionServiceGetAssignmentsCall{Ctx: ctx, ID: id, Opts: opts, Options: options})
+	fn := mock.GetAssignmentsFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *permissionv1.GetProjectAssignmentsResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, opts, options...)
+}
+
+// ProjectPermissionServiceUpdateCall records a call to ProjectPermissionService.Update.
+type ProjectPermissionServiceUpdateCall struct {
+	Ctx     context.Context
+	ID      string
+	Opts    *permissionv1.UpdateProjectPermissionsOptions
+	Options []core.RequestOptionFunc
+}
+
+// Update implements permissionv1.ProjectPermissionServiceInterface.
+func (mock *ProjectPermissionService) Update(ctx context.Context, id string, opts *permissionv1.UpdateProjectPermissionsOptions, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.UpdateCalls = append(mock.UpdateCalls, ProjectPermissionServiceUpdateCall{Ctx: ctx, ID: id, Opts: opts, Options: options})
+	fn := mock.UpdateFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, opts, options...)
+}
+
+// RolePermissionService is a call-recording fake of permissionv1.RolePermissionServiceInterface.
+//
+// Set the <Method>Func fields to stub methods, methods without a stub
+// return zero values. Calls are recorded in the <Method>Calls fields.
+type RolePermissionService struct {
+	mu sync.Mutex
+
+	GetAccessFunc  func(ctx context.Context, id string, opts *permissionv1.GetRoleAccessOptions, options ...core.RequestOptionFunc) (*permissionv1.GetRoleAccessResponse, *http.Response, error)
+	GetAccessCalls []RolePermissionServiceGetAccessCall
+
+	GetAllowedAuthorizerActionsFunc  func(ctx context.Context, id string, opts *permissionv1.GetRoleAllowedAuthorizerActionsOptions, options ...core.RequestOptionFunc) (*permissionv1.GetRoleAllowedAuthorizerActionsResponse, *http.Response, error)
+	GetAllowedAuthorizerActionsCalls []RolePermissionServiceGetAllowedAuthorizerActionsCall
+
+	GetAssignmentsFunc  func(ctx context.Context, id string, opts *permissionv1.GetRoleAssignmentsOptions, options ...core.RequestOptionFunc) (*permissionv1.GetRoleAssignmentsResponse, *http.Response, error)
+	GetAssignmentsCalls []RolePermissionServiceGetAssignmentsCall
+
+	UpdateFunc  func(ctx context.Context, id string, opts *permissionv1.UpdateRolePermissionsOptions, options ...core.RequestOptionFunc) (*http.Response, error)
+	UpdateCalls []RolePermissionServiceUpdateCall
+}
+
+var _ permissionv1.RolePermissionServiceInterface = (*RolePermissionService)(nil)
+
+// RolePermissionServiceGetAccessCall records a call to RolePermissionService.GetAccess.
+type RolePermissionServiceGetAccessCall struct {
+	Ctx     context.Context
+	ID      string
+	Opts    *permissionv1.GetRoleAccessOptions
+	Options []core.RequestOptionFunc
+}
+
+// GetAccess implements permissionv1.RolePermissionServiceInterface.
+func (mock *RolePermissionService) GetAccess(ctx context.Context, id string, opts *permissionv1.GetRoleAccessOptions, options ...core.RequestOptionFunc) (*permissionv1.GetRoleAccessResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAccessCalls = append(mock.GetAccessCalls, RolePermissionServiceGetAccessCall{Ctx: ctx, ID: id, Opts: opts, Options: options})
+	fn := mock.GetAccessFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *permissionv1.GetRoleAccessResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, opts, options...)
+}
+
+// RolePermissionServiceGetAllowedAuthorizerActionsCall records a call to RolePermissionService.GetAllowedAuthorizerActions.
+type RolePermissionServiceGetAllowedAuthorizerActionsCall struct {
+	Ctx     context.Context
+	ID      string
+	Opts    *permissionv1.GetRoleAllowedAuthorizerActionsOptions
+	Options []core.RequestOptionFunc
+}
+
+// GetAllowedAuthorizerActions implements permissionv1.RolePermissionServiceInterface.
+func (mock *RolePermissionService) GetAllowedAuthorizerActions(ctx context.Context, id string, opts *permissionv1.GetRoleAllowedAuthorizerActionsOptions, options ...core.RequestOptionFunc) (*permissionv1.GetRoleAllowedAuthorizerActionsResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAllowedAuthorizerActionsCalls = append(mock.GetAllowedAuthorizerActionsCalls, RolePermissionServiceGetAllowedAuthorizerActionsCall{Ctx: ctx, ID: id, Opts: opts, Options: options})
+	fn := mock.GetAllowedAuthorizerActionsFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *permissionv1.GetRoleAllowedAuthorizerActionsResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, opts, options...)
+}
+
+// RolePermissionServiceGetAssignmentsCall records a call to RolePermissionService.GetAssignments.
+type RolePermissionServiceGetAssignmentsCall struct {
+	Ctx     context.Context
+	ID      string
+	Opts    *permissionv1.GetRoleAssignmentsOptions
+	Options []core.RequestOptionFunc
+}
+
+// GetAssignments implements permissionv1.RolePermissionServiceInterface.
+func (mock *RolePermissionService) GetAssignments(ctx context.Context, id string, opts *permissionv1.GetRoleAssignmentsOptions, options ...core.RequestOptionFunc) (*permissionv1.GetRoleAssignmentsResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAssignmentsCalls = append(mock.GetAssignmentsCalls, RolePermissionServiceGetAssignmentsCall{Ctx: ctx, ID: id, Opts: opts, Options: options})
+	fn := mock.GetAssignmentsFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *permissionv1.GetRoleAssignmentsResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, opts, options...)
+}
+
+// RolePermissionServiceUpdateCall records a call to RolePermissionService.Update.
+type RolePermissionServiceUpdateCall struct {
+	Ctx     context.Context
+	ID      string
+	Opts    *permissionv1.UpdateRolePermissionsOptions
+	Options []core.RequestOptionFunc
+}
+
+// Update implements permissionv1.RolePermissionServiceInterface.
+func (mock *RolePermissionService) Update(ctx context.Context, id string, opts *permissionv1.UpdateRolePermissionsOptions, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.UpdateCalls = append(mock.UpdateCalls, RolePermissionServiceUpdateCall{Ctx: ctx, ID: id, Opts: opts, Options: options})
+	fn := mock.UpdateFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, opts, options...)
+}
+
+// WarehousePermissionService is a call-recording fake of permissionv1.WarehousePermissionServiceInterface.
+//
+// Set the <Method>Func fields to stub methods, methods without a stub
+// return zero values. Calls are recorded in the <Method>Calls fields.
+type WarehousePermissionService struct {
+	mu sync.Mutex
+
+	GetAccessFunc  func(ctx context.Context, id string, opt *permissionv1.GetWarehouseAccessOptions, options ...core.RequestOptionFunc) (*permissionv1.GetWarehouseAccessResponse, *http.Response, error)
+	GetAccessCalls []WarehousePermissionServiceGetAccessCall
+
+	GetAllowedAuthorizerActionsFunc  func(ctx context.Context, id string, opts *permissionv1.GetWarehouseAllowedAuthorizerActionsOptions, option ...core.RequestOptionFunc) (*permissionv1.GetWarehouseAllowedAuthorizerActionsResponse, *http.Response, error)
+	GetAllowedAuthorizerActionsCalls []WarehousePermissionServiceGetAllowedAuthorizerActionsCall
+
+	GetAssignmentsFunc  func(ctx context.Context, id string, opt *permissionv1.GetWarehouseAssignmentsOptions, options ...core.RequestOptionFunc) (*permissionv1.GetWarehouseAssignmentsResponse, *http.Response, error)
+	GetAssignmentsCalls []WarehousePermissionServiceGetAssignmentsCall
+
+	GetAuthzPropertiesFunc  func(ctx context.Context, id string, options ...core.RequestOptionFunc) (*permissionv1.GetWarehouseAuthzPropertiesResponse, *http.Response, error)
+	GetAuthzPropertiesCalls []WarehousePermissionServiceGetAuthzPropertiesCall
+
+	SetManagedAccessFunc  func(ctx context.Context, id string, opts *permissionv1.SetWarehouseManagedAccessOptions, options ...core.RequestOptionFunc) (*http.Response, error)
+	SetManagedAccessCalls []WarehousePermissionServiceSetManagedAccessCall
+
+	UpdateFunc  func(ctx context.Context, id string, opts *permissionv1.UpdateWarehousePermissionsOptions, options ...core.RequestOptionFunc) (*http.Response, error)
+	UpdateCalls []WarehousePermissionServiceUpdateCall
+}
+
+var _ permissionv1.WarehousePermissionServiceInterface = (*WarehousePermissionService)(nil)
+
+// WarehousePermissionServiceGetAccessCall records a call to WarehousePermissionService.GetAccess.
+type WarehousePermissionServiceGetAccessCall struct {
+	Ctx     context.Context
+	ID      string
+	Opt     *permissionv1.GetWarehouseAccessOptions
+	Options []core.RequestOptionFunc
+}
+
+// GetAccess implements permissionv1.WarehousePermissionServiceInterface.
+func (mock *WarehousePermissionService) GetAccess(ctx context.Context, id string, opt *permissionv1.GetWarehouseAccessOptions, options ...core.RequestOptionFunc) (*permissionv1.GetWarehouseAccessResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAccessCalls = append(mock.GetAccessCalls, WarehousePermissionServiceGetAccessCall{Ctx: ctx, ID: id, Opt: opt, Options: options})
+	fn := mock.GetAccessFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *permissionv1.GetWarehouseAccessResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, opt, options...)
+}
+
+// WarehousePermissionServiceGetAllowedAuthorizerActionsCall records a call to WarehousePermissionService.GetAllowedAuthorizerActions.
+type WarehousePermissionServiceGetAllowedAuthorizerActionsCall struct {
+	Ctx    context.Context
+	ID     string
+	Opts   *permissionv1.GetWarehouseAllowedAuthorizerActionsOptions
+	Option []core.RequestOptionFunc
+}
+
+// GetAllowedAuthorizerActions implements permissionv1.WarehousePermissionServiceInterface.
+func (mock *WarehousePermissionService) GetAllowedAuthorizerActions(ctx context.Context, id string, opts *permissionv1.GetWarehouseAllowedAuthorizerActionsOptions, option ...core.RequestOptionFunc) (*permissionv1.GetWarehouseAllowedAuthorizerActionsResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAllowedAuthorizerActionsCalls = append(mock.GetAllowedAuthorizerActionsCalls, WarehousePermissionServiceGetAllowedAuthorizerActionsCall{Ctx: ctx, ID: id, Opts: opts, Option: option})
+	fn := mock.GetAllowedAuthorizerActionsFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *permissionv1.GetWarehouseAllowedAuthorizerActionsResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, opts, option...)
+}
+
+// WarehousePermissionServiceGetAssignmentsCall records a call to WarehousePermissionService.GetAssignments.
+type WarehousePermissionServiceGetAssignmentsCall struct {
+	Ctx     context.Context
+	ID      string
+	Opt     *permissionv1.GetWarehouseAssignmentsOptions
+	Options []core.RequestOptionFunc
+}
+
+// GetAssignments implements permissionv1.WarehousePermissionServiceInterface.
+func (mock *WarehousePermissionService) GetAssignments(ctx context.Context, id string, opt *permissionv1.GetWarehouseAssignmentsOptions, options ...core.RequestOptionFunc) (*permissionv1.GetWarehouseAssignmentsResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAssignmentsCalls = append(mock.GetAssignmentsCalls, WarehousePermissionServiceGetAssignmentsCall{Ctx: ctx, ID: id, Opt: opt, Options: options})
+	fn := mock.GetAssignmentsFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *permissionv1.GetWarehouseAssignmentsResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, opt, options...)
+}
+
+// WarehousePermissionServiceGetAuthzPropertiesCall records a call to WarehousePermissionService.GetAuthzProperties.
+type WarehousePermissionServiceGetAuthzPropertiesCall struct {
+	Ctx     context.Context
+	ID      string
+	Options []core.RequestOptionFunc
+}
+
+// GetAuthzProperties implements permissionv1.WarehousePermissionServiceInterface.
+func (mock *WarehousePermissionService) GetAuthzProperties(ctx context.Context, id string, options ...core.RequestOptionFunc) (*permissionv1.GetWarehouseAuthzPropertiesResponse, *http.Response, error) {
+	mock.mu.Lock()
+	mock.GetAuthzPropertiesCalls = append(mock.GetAuthzPropertiesCalls, WarehousePermissionServiceGetAuthzPropertiesCall{Ctx: ctx, ID: id, Options: options})
+	fn := mock.GetAuthzPropertiesFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *permissionv1.GetWarehouseAuthzPropertiesResponse
+			r1 *http.Response
+			r2 error
+		)
+		return r0, r1, r2
+	}
+
+	return fn(ctx, id, options...)
+}
+
+// WarehousePermissionServiceSetManagedAccessCall records a call to WarehousePermissionService.SetManagedAccess.
+type WarehousePermissionServiceSetManagedAccessCall struct {
+	Ctx     context.Context
+	ID      string
+	Opts    *permissionv1.SetWarehouseManagedAccessOptions
+	Options []core.RequestOptionFunc
+}
+
+// SetManagedAccess implements permissionv1.WarehousePermissionServiceInterface.
+func (mock *WarehousePermissionService) SetManagedAccess(ctx context.Context, id string, opts *permissionv1.SetWarehouseManagedAccessOptions, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.SetManagedAccessCalls = append(mock.SetManagedAccessCalls, WarehousePermissionServiceSetManagedAccessCall{Ctx: ctx, ID: id, Opts: opts, Options: options})
+	fn := mock.SetManagedAccessFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, opts, options...)
+}
+
+// WarehousePermissionServiceUpdateCall records a call to WarehousePermissionService.Update.
+type WarehousePermissionServiceUpdateCall struct {
+	Ctx     context.Context
+	ID      string
+	Opts    *permissionv1.UpdateWarehousePermissionsOptions
+	Options []core.RequestOptionFunc
+}
+
+// Update implements permissionv1.WarehousePermissionServiceInterface.
+func (mock *WarehousePermissionService) Update(ctx context.Context, id string, opts *permissionv1.UpdateWarehousePermissionsOptions, options ...core.RequestOptionFunc) (*http.Response, error) {
+	mock.mu.Lock()
+	mock.UpdateCalls = append(mock.UpdateCalls, WarehousePermissionServiceUpdateCall{Ctx: ctx, ID: id, Opts: opts, Options: options})
+	fn := mock.UpdateFunc
+	mock.mu.Unlock()
+
+	if fn == nil {
+		var (
+			r0 *http.Response
+			r1 error
+		)
+		return r0, r1
+	}
+
+	return fn(ctx, id, opts, options...)
+}
@@ -0,0 +1,249 @@
+// Package offboard removes a user from a Lakekeeper server.
+package offboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/baptistegh/go-lakekeeper/internal/listing"
+	"github.com/baptistegh/go-lakekeeper/pkg/client"
+
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+)
+
+// ownership is the assignment type transferred to the replacement principal.
+const ownership = "ownership"
+
+type (
+	// Options represents the NewPlan() options.
+	Options struct {
+		// TransferOwnershipTo receives the ownership assignments
+		// of the user before they are deleted.
+		TransferOwnershipTo *permissionv1.UserOrRole
+		// DeleteUser deletes the user once its assignments are deleted.
+		DeleteUser bool
+	}
+
+	// Plan lists the changes needed to offboard a user,
+	// with one permission plan per resource.
+	Plan struct {
+		User       string               `json:"user"`
+		Changes    []*permissionv1.Plan `json:"changes"`
+		DeleteUser bool                 `json:"delete-user"`
+		// UserDeleted reports whether the user was deleted by Apply().
+		UserDeleted bool `json:"user-deleted"`
+	}
+)
+
+// NewPlan discovers the assignments of user on the server, and on every
+// project, warehouse and role visible to the caller.
+func NewPlan(ctx context.Context, c client.Interface, user string, opts *Options) (*Plan, error) {
+	if user == "" {
+		return nil, errors.New("user must be provided")
+	}
+
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	if t := opts.TransferOwnershipTo; t != nil && t.Type == permissionv1.UserType && t.Value == user {
+		return nil, errors.New("ownership can't be transferred to the offboarded user")
+	}
+
+	p := &Plan{
+		User:       user,
+		Changes:    []*permissionv1.Plan{},
+		DeleteUser: opts.DeleteUser,
+	}
+
+	add := func(change *permissionv1.Plan) {
+		if !change.IsEmpty() {
+			p.Changes = append(p.Changes, change)
+		}
+	}
+
+	perms := c.PermissionV1()
+
+	server, _, err := perms.ServerPermission().GetAssignments(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	add(planChanges(permissionv1.ServerScope, "", server.Assignments, user, opts.TransferOwnershipTo, newServerAssignment))
+
+	projects, err := listing.Projects(ctx, c, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		resp, _, err := perms.ProjectPermission().GetAssignments(ctx, project.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+		add(planChanges(permissionv1.ProjectScope, project.ID, resp.Assignments, user, opts.TransferOwnershipTo, newProjectAssignment))
+
+		warehouses, err := listing.Warehouses(ctx, c, project.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, w := range warehouses {
+			resp, _, err := perms.WarehousePermission().GetAssignments(ctx, w.ID, nil)
+			if err != nil {
+				return nil, err
+			}
+			add(planChanges(permissionv1.WarehouseScope, w.ID, resp.Assignments, user, opts.TransferOwnershipTo, newWarehouseAssignment))
+		}
+
+		roles, err := listing.Roles(ctx, c, project.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range roles {
+			resp, _, err := perms.RolePermission().GetAssignments(ctx, r.ID, nil)
+			if err != nil {
+				return nil, err
+			}
+			add(planChanges(permissionv1.RoleScope, r.ID, resp.Assignments, user, opts.TransferOwnershipTo, newRoleAssignment))
+		}
+	}
+
+	return p, nil
+}
+
+// Apply sends one update per resource of the plan, then deletes the user
+// if requested. It keeps going on errors, and does not delete the user
+// if any update failed.
+func (p *Plan) Apply(ctx context.Context, c client.Interface) error {
+	var errs []error
+
+	perms := c.PermissionV1()
+	for _, change := range p.Changes {
+		var err error
+		switch change.Scope {
+		case permissionv1.ServerScope:
+			_, err = perms.ServerPermission().Update(ctx, &permissionv1.UpdateServerPermissionsOptions{
+				Writes:  assignments[*permissionv1.ServerAssignment](change.Writes),
+				Deletes: assignments[*permissionv1.ServerAssignment](change.Deletes),
+			})
+		case permissionv1.ProjectScope:
+			_, err = perms.ProjectPermission().Update(ctx, change.ResourceID, &permissionv1.UpdateProjectPermissionsOptions{
+				Writes:  assignments[*permissionv1.ProjectAssignment](change.Writes),
+				Deletes: assignments[*permissionv1.ProjectAssignment](change.Deletes),
+			})
+		case permissionv1.WarehouseScope:
+			_, err = perms.WarehousePermission().Update(ctx, change.ResourceID, &permissionv1.UpdateWarehousePermissionsOptions{
+				Writes:  assignments[*permissionv1.WarehouseAssignment](change.Writes),
+				Deletes: assignments[*permissionv1.WarehouseAssignment](change.Deletes),
+			})
+		case permissionv1.RoleScope:
+			_, err = perms.RolePermission().Update(ctx, change.ResourceID, &permissionv1.UpdateRolePermissionsOptions{
+				Writes:  assignments[*permissionv1.RoleAssignment](change.Writes),
+				Deletes: assignments[*permissionv1.RoleAssignment](change.Deletes),
+			})
+		default:
+			err = fmt.Errorf("unknown scope %s", change.Scope)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", change.Scope, change.ResourceID, err))
+			continue
+		}
+		change.Applied = true
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	if p.DeleteUser {
+		if _, err := c.UserV1().Delete(ctx, p.User); err != nil {
+			return fmt.Errorf("could not delete user %s, %w", p.User, err)
+		}
+		p.UserDeleted = true
+	}
+
+	return nil
+}
+
+// String returns a human-readable description of the plan.
+func (p *Plan) String() string {
+	var b strings.Builder
+
+	if len(p.Changes) == 0 {
+		fmt.Fprintf(&b, "No assignments found for user %s\n", p.User)
+	}
+
+	for _, change := range p.Changes {
+		b.WriteString(change.String())
+	}
+
+	if p.DeleteUser {
+		fmt.Fprintf(&b, "user %s will be deleted\n", p.User)
+	}
+
+	return b.String()
+}
+
+// planChanges returns the deletion of the assignments of user, and the
+// transfer of its ownerships to newOwner when set.
+func planChanges[T permissionv1.Assignment](scope permissionv1.Scope, id string, current []T, user string, newOwner *permissionv1.UserOrRole, build func(permissionv1.UserOrRole, string) T) *permissionv1.Plan {
+	p := &permissionv1.Plan{
+		Scope:      scope,
+		ResourceID: id,
+		Writes:     []permissionv1.Assignment{},
+		Deletes:    []permissionv1.Assignment{},
+	}
+
+	owned := false
+	for _, a := range current {
+		if a.GetPrincipalType() != permissionv1.UserType || a.GetPrincipalID() != user {
+			continue
+		}
+		p.Deletes = append(p.Deletes, a)
+		owned = owned || a.GetAssignment() == ownership
+	}
+
+	if !owned || newOwner == nil {
+		return p
+	}
+
+	// the new owner may already own the resource
+	for _, a := range current {
+		if a.GetPrincipalType() == newOwner.Type && a.GetPrincipalID() == newOwner.Value && a.GetAssignment() == ownership {
+			return p
+		}
+	}
+
+	p.Writes = append(p.Writes, build(*newOwner, ownership))
+
+	return p
+}
+
+// assignments converts the assignments of a plan back to their type.
+func assignments[T permissionv1.Assignment](in []permissionv1.Assignment) []T {
+	out := make([]T, 0, len(in))
+	for _, a := range in {
+		out = append(out, a.(T))
+	}
+	return out
+}
+
+func newServerAssignment(assignee permissionv1.UserOrRole, assignment string) *permissionv1.ServerAssignment {
+	return &permissionv1.ServerAssignment{Assignee: assignee, Assignment: permissionv1.ServerAssignmentType(assignment)}
+}
+
+func newProjectAssignment(assignee permissionv1.UserOrRole, assignment string) *permissionv1.ProjectAssignment {
+	return &permissionv1.ProjectAssignment{Assignee: assignee, Assignment: permissionv1.ProjectAssignmentType(assignment)}
+}
+
+func newWarehouseAssignment(assignee permissionv1.UserOrRole, assignment string) *permissionv1.WarehouseAssignment {
+	return &permissionv1.WarehouseAssignment{Assignee: assignee, Assignment: permissionv1.WarehouseAssignmentType(assignment)}
+}
+
+func newRoleAssignment(assignee permissionv1.UserOrRole, assignment string) *permissionv1.RoleAssignment {
+	return &permissionv1.RoleAssignment{Assignee: assignee, Assignment: permissionv1.RoleAssignmentType(assignment)}
+}
@@ -0,0 +1,117 @@
+package offboard_test
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/offboard"
+	"github.com/baptistegh/go-lakekeeper/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+)
+
+func TestOffboard(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	const (
+		user      = "oidc~alice"
+		project   = "01f2fdfc-81fc-444d-8368-5b6701566e35"
+		warehouse = "a4b2c1d0-0000-4000-8000-000000000001"
+		archive   = "a4b2c1d0-0000-4000-8000-000000000002"
+		role      = "role-analysts"
+	)
+
+	assignments := func(path string, body ...any) {
+		mux.HandleFunc("GET /management/v1/permissions/"+path+"/assignments", func(w http.ResponseWriter, _ *http.Request) {
+			testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": body})
+		})
+	}
+
+	assignments("server", map[string]string{"type": "admin", "user": "oidc~bob"})
+	assignments("project/"+project, map[string]string{"type": "describe", "user": user})
+	assignments("warehouse/"+warehouse,
+		map[string]string{"type": "ownership", "user": user},
+		map[string]string{"type": "select", "user": user},
+	)
+	assignments("warehouse/"+archive, map[string]string{"type": "select", "user": user})
+	assignments("role/"+role, map[string]string{"type": "assignee", "user": user})
+
+	testutil.HandleProjects(t, mux, testutil.Project{ID: project, Name: "analytics", Warehouses: []map[string]any{
+		testutil.S3Warehouse(warehouse, "lake", project, "lake", managementv1.WarehouseStatusActive),
+		testutil.S3Warehouse(archive, "archive", project, "archive", managementv1.WarehouseStatusInactive),
+	}})
+	mux.HandleFunc("GET /management/v1/role", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"roles": []any{
+			map[string]string{"id": role, "name": "analysts", "project-id": project},
+		}})
+	})
+
+	var updates, deleted atomic.Int32
+	mux.HandleFunc("POST /management/v1/permissions/warehouse/"+warehouse+"/assignments", func(w http.ResponseWriter, r *http.Request) {
+		updates.Add(1)
+		testutil.TestBodyJSON(t, r, map[string][]map[string]string{
+			"writes":  {{"type": "ownership", "user": "oidc~bob"}},
+			"deletes": {{"type": "ownership", "user": user}, {"type": "select", "user": user}},
+		})
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /management/v1/permissions/project/"+project+"/assignments", func(w http.ResponseWriter, r *http.Request) {
+		updates.Add(1)
+		testutil.TestBodyJSON(t, r, map[string][]map[string]string{
+			"deletes": {{"type": "describe", "user": user}},
+		})
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /management/v1/permissions/warehouse/"+archive+"/assignments", func(w http.ResponseWriter, r *http.Request) {
+		updates.Add(1)
+		testutil.TestBodyJSON(t, r, map[string][]map[string]string{
+			"deletes": {{"type": "select", "user": user}},
+		})
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /management/v1/permissions/role/"+role+"/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		updates.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("DELETE /management/v1/user/"+user, func(w http.ResponseWriter, _ *http.Request) {
+		deleted.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	plan, err := offboard.NewPlan(t.Context(), client, user, &offboard.Options{
+		TransferOwnershipTo: &permissionv1.UserOrRole{Type: permissionv1.UserType, Value: "oidc~bob"},
+		DeleteUser:          true,
+	})
+	require.NoError(t, err)
+
+	// the server has no assignment of the user, the inactive
+	// warehouse is offboarded as well
+	require.Len(t, plan.Changes, 4)
+	assert.Equal(t, permissionv1.ProjectScope, plan.Changes[0].Scope)
+	assert.Equal(t, permissionv1.WarehouseScope, plan.Changes[1].Scope)
+	assert.Equal(t, permissionv1.WarehouseScope, plan.Changes[2].Scope)
+	assert.Equal(t, archive, plan.Changes[2].ResourceID)
+	assert.Equal(t, permissionv1.RoleScope, plan.Changes[3].Scope)
+	assert.Contains(t, plan.String(), "user "+user+" will be deleted\n")
+
+	require.NoError(t, plan.Apply(t.Context(), client))
+
+	assert.Equal(t, int32(4), updates.Load())
+	assert.Equal(t, int32(1), deleted.Load())
+	assert.True(t, plan.UserDeleted)
+}
+
+func TestOffboard_TransferToSelf(t *testing.T) {
+	t.Parallel()
+	_, client := testutil.ServerMux(t)
+
+	_, err := offboard.NewPlan(t.Context(), client, "oidc~alice", &offboard.Options{
+		TransferOwnershipTo: &permissionv1.UserOrRole{Type: permissionv1.UserType, Value: "oidc~alice"},
+	})
+	require.Error(t, err)
+}
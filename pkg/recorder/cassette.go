@@ -0,0 +1,81 @@
+package recorder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// cassetteVersion is the format version written to new cassettes.
+const cassetteVersion = 1
+
+type (
+	// Cassette is a set of recorded HTTP interactions, stored as
+	// a JSON file on disk.
+	Cassette struct {
+		Version      int            `json:"version"`
+		Interactions []*Interaction `json:"interactions"`
+	}
+
+	// Interaction is a single request/response pair.
+	Interaction struct {
+		Request  Request  `json:"request"`
+		Response Response `json:"response"`
+	}
+
+	// Request is the recorded part of an HTTP request.
+	Request struct {
+		Method  string      `json:"method"`
+		URL     string      `json:"url"`
+		Headers http.Header `json:"headers,omitempty"`
+		Body    string      `json:"body,omitempty"`
+	}
+
+	// Response is the recorded part of an HTTP response.
+	Response struct {
+		StatusCode int         `json:"status_code"`
+		Headers    http.Header `json:"headers,omitempty"`
+		Body       string      `json:"body,omitempty"`
+	}
+)
+
+// LoadCassette reads a cassette from the given path.
+func LoadCassette(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read cassette %s, %w", path, err)
+	}
+
+	var c Cassette
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("could not decode cassette %s, %w", path, err)
+	}
+
+	if c.Version != cassetteVersion {
+		return nil, fmt.Errorf("unsupported cassette version %d in %s", c.Version, path)
+	}
+
+	return &c, nil
+}
+
+// Save writes the cassette to the given path, creating parent
+// directories when needed.
+func (c *Cassette) Save(path string) error {
+	if path == "" {
+		return errors.New("cassette path must be provided")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("could not create cassette directory, %w", err)
+	}
+
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode cassette, %w", err)
+	}
+
+	return os.WriteFile(path, append(b, '\n'), 0o600)
+}
@@ -0,0 +1,82 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+)
+
+// MatcherFunc reports whether a live request matches a recorded one.
+//
+// The live request is passed after sanitization, so it can be
+// compared with the recorded request field by field.
+type MatcherFunc func(live, recorded *Request) bool
+
+// MatchMethod matches requests with the same HTTP method.
+func MatchMethod(live, recorded *Request) bool {
+	return live.Method == recorded.Method
+}
+
+// MatchPath matches requests with the same URL path, ignoring
+// the scheme, the host and the query string.
+func MatchPath(live, recorded *Request) bool {
+	l, r, ok := parseURLs(live, recorded)
+	return ok && l.Path == r.Path
+}
+
+// MatchURL matches requests with the same URL path and query
+// parameters. Scheme and host are ignored so that a cassette can be
+// replayed against any base URL.
+func MatchURL(live, recorded *Request) bool {
+	l, r, ok := parseURLs(live, recorded)
+	return ok && l.Path == r.Path && l.Query().Encode() == r.Query().Encode()
+}
+
+// MatchBody matches requests with the same body. JSON bodies are
+// compared semantically, other bodies byte for byte.
+func MatchBody(live, recorded *Request) bool {
+	if live.Body == recorded.Body {
+		return true
+	}
+
+	var l, r any
+	if json.Unmarshal([]byte(live.Body), &l) != nil || json.Unmarshal([]byte(recorded.Body), &r) != nil {
+		return false
+	}
+
+	lb, _ := json.Marshal(l)
+	rb, _ := json.Marshal(r)
+
+	return bytes.Equal(lb, rb)
+}
+
+// MatchAll combines matchers, a request matches when every matcher
+// matches.
+func MatchAll(matchers ...MatcherFunc) MatcherFunc {
+	return func(live, recorded *Request) bool {
+		for _, m := range matchers {
+			if !m(live, recorded) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// DefaultMatcher matches on the HTTP method, the URL and the body, so
+// that repeated calls to the same endpoint are told apart.
+var DefaultMatcher = MatchAll(MatchMethod, MatchURL, MatchBody)
+
+func parseURLs(live, recorded *Request) (*url.URL, *url.URL, bool) {
+	l, err := url.Parse(live.URL)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	r, err := url.Parse(recorded.URL)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	return l, r, true
+}
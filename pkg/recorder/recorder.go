@@ -0,0 +1,278 @@
+// Package recorder provides an http.RoundTripper that records HTTP
+// interactions to cassette files and replays them offline.
+//
+// It is meant for tests: record once against a live Lakekeeper server,
+// then replay the cassette without any external dependency.
+//
+//	rec, err := recorder.New("testdata/cassettes/my-test.json", recorder.ModeReplay)
+//	if err != nil {
+//		...
+//	}
+//	defer rec.Stop()
+//
+//	c, err := client.NewClient(ctx, "token", baseURL, client.WithHTTPTransport(rec))
+package recorder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// Mode defines how the recorder handles requests.
+type Mode string
+
+const (
+	// ModeRecord sends requests to the real server and records every
+	// interaction. The cassette is written by Stop.
+	ModeRecord Mode = "record"
+	// ModeReplay serves requests from the cassette and never reaches
+	// the network.
+	ModeReplay Mode = "replay"
+	// ModePassthrough disables the recorder, requests are sent to the
+	// real server and nothing is recorded.
+	ModePassthrough Mode = "passthrough"
+)
+
+// ErrInteractionNotFound is returned in replay mode when no recorded
+// interaction matches a request.
+var ErrInteractionNotFound = errors.New("recorder: no matching interaction found")
+
+// ParseMode converts a string to a Mode. An empty string
+// defaults to ModePassthrough.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(s)); m {
+	case "":
+		return ModePassthrough, nil
+	case ModeRecord, ModeReplay, ModePassthrough:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid recorder mode: %s", s)
+	}
+}
+
+// Recorder is an http.RoundTripper recording or replaying
+// HTTP interactions.
+type Recorder struct {
+	path       string
+	mode       Mode
+	transport  http.RoundTripper
+	matcher    MatcherFunc
+	sanitizers []SanitizerFunc
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// OptionFunc can be used to customize a Recorder.
+type OptionFunc func(*Recorder)
+
+// WithTransport sets the transport used to reach the real server in
+// record and passthrough modes. Defaults to a pooled cleanhttp transport.
+func WithTransport(rt http.RoundTripper) OptionFunc {
+	return func(r *Recorder) {
+		r.transport = rt
+	}
+}
+
+// WithMatcher sets the function used to match requests in replay mode.
+// Defaults to DefaultMatcher.
+func WithMatcher(m MatcherFunc) OptionFunc {
+	return func(r *Recorder) {
+		r.matcher = m
+	}
+}
+
+// WithSanitizers adds sanitizers applied to every interaction,
+// after DefaultSanitizer.
+func WithSanitizers(s ...SanitizerFunc) OptionFunc {
+	return func(r *Recorder) {
+		r.sanitizers = append(r.sanitizers, s...)
+	}
+}
+
+// New creates a Recorder for the cassette at path.
+//
+// In replay mode the cassette must exist. In record mode any existing
+// cassette is overwritten when Stop is called.
+func New(path string, mode Mode, opts ...OptionFunc) (*Recorder, error) {
+	r := &Recorder{
+		path:       path,
+		mode:       mode,
+		transport:  cleanhttp.DefaultPooledTransport(),
+		matcher:    DefaultMatcher,
+		sanitizers: []SanitizerFunc{DefaultSanitizer},
+		cassette:   &Cassette{Version: cassetteVersion},
+	}
+
+	for _, fn := range opts {
+		if fn != nil {
+			fn(r)
+		}
+	}
+
+	switch mode {
+	case ModeReplay:
+		c, err := LoadCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.used = make([]bool, len(c.Interactions))
+	case ModeRecord, ModePassthrough:
+	default:
+		return nil, fmt.Errorf("invalid recorder mode: %s", mode)
+	}
+
+	return r, nil
+}
+
+// Mode returns the mode of the recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// HTTPClient returns an *http.Client using the recorder as transport.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Stop writes the cassette to disk in record mode.
+// It does nothing in the other modes.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.cassette.Save(r.path)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch r.mode {
+	case ModePassthrough:
+		return r.transport.RoundTrip(req)
+	case ModeReplay:
+		return r.replay(req)
+	default:
+		return r.record(req)
+	}
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	reqBody, err := drainBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := drainBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &Interaction{
+		Request: Request{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: req.Header.Clone(),
+			Body:    reqBody,
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header.Clone(),
+			Body:       respBody,
+		},
+	}
+	r.sanitize(i)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	body, err := drainBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	live := &Interaction{
+		Request: Request{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: req.Header.Clone(),
+			Body:    body,
+		},
+	}
+	r.sanitize(live)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Interactions are consumed in the recorded order, so the same
+	// request can be replayed with different responses.
+	for idx, i := range r.cassette.Interactions {
+		if r.used[idx] || !r.matcher(&live.Request, &i.Request) {
+			continue
+		}
+		r.used[idx] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			StatusCode:    i.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        i.Response.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(i.Response.Body)),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, req.Method, req.URL)
+}
+
+func (r *Recorder) sanitize(i *Interaction) {
+	for _, s := range r.sanitizers {
+		s(i)
+	}
+}
+
+// drainBody reads the body and replaces it with a fresh reader
+// so that it can still be consumed by the caller.
+func drainBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+
+	b, err := io.ReadAll(*body)
+	if err != nil {
+		return "", fmt.Errorf("recorder: could not read body, %w", err)
+	}
+
+	if err := (*body).Close(); err != nil {
+		return "", fmt.Errorf("recorder: could not close body, %w", err)
+	}
+
+	*body = io.NopCloser(bytes.NewReader(b))
+
+	return string(b), nil
+}
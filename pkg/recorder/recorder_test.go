@@ -0,0 +1,177 @@
+package recorder_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	credentialv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/credential"
+	profilev1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/profile"
+	"github.com/baptistegh/go-lakekeeper/pkg/client"
+	"github.com/baptistegh/go-lakekeeper/pkg/recorder"
+	"github.com/baptistegh/go-lakekeeper/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/management/v1/info", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestMethod(t, r, http.MethodGet)
+		testutil.MustWriteHTTPResponse(t, w, "../apis/management/v1/testdata/server_info.json")
+	})
+	mux.HandleFunc("/management/v1/warehouse", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestMethod(t, r, http.MethodPost)
+		testutil.MustWriteHTTPResponse(t, w, "../apis/management/v1/testdata/create_warehouse.json")
+	})
+	server := httptest.NewServer(mux)
+
+	// record against the live server
+	rec, err := recorder.New(path, recorder.ModeRecord)
+	require.NoError(t, err)
+
+	c, err := client.NewClient(t.Context(), "secret-token", server.URL, client.WithHTTPTransport(rec))
+	require.NoError(t, err)
+
+	want, _, err := c.ServerV1().Info(t.Context())
+	require.NoError(t, err)
+
+	opts := &managementv1.CreateWarehouseOptions{
+		Name:              "my-warehouse",
+		StorageProfile:    profilev1.NewS3StorageSettings("bucket", "eu-west-1").AsProfile(),
+		StorageCredential: credentialv1.NewS3CredentialAccessKey("my-access-key-id", "my-secret-key").AsCredential(),
+	}
+	_, _, err = c.WarehouseV1("").Create(t.Context(), opts)
+	require.NoError(t, err)
+
+	require.NoError(t, rec.Stop())
+	server.Close()
+
+	// secrets must not be written to the cassette
+	cassette, err := recorder.LoadCassette(path)
+	require.NoError(t, err)
+	require.Len(t, cassette.Interactions, 2)
+
+	assert.Equal(t, recorder.Redacted, cassette.Interactions[0].Request.Headers.Get("Authorization"))
+	assert.NotContains(t, cassette.Interactions[1].Request.Body, "my-secret-key")
+	assert.NotContains(t, cassette.Interactions[1].Request.Body, "my-access-key-id")
+	assert.Contains(t, cassette.Interactions[1].Request.Body, "my-warehouse")
+
+	// replay without any server, on another base URL
+	rec, err = recorder.New(path, recorder.ModeReplay)
+	require.NoError(t, err)
+
+	c, err = client.NewClient(t.Context(), "other-token", "http://lakekeeper.invalid", client.WithHTTPTransport(rec), client.WithoutRetries())
+	require.NoError(t, err)
+
+	got, _, err := c.ServerV1().Info(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, _, err = c.WarehouseV1("").Create(t.Context(), opts)
+	require.NoError(t, err)
+
+	// every interaction has been consumed
+	_, _, err = c.ServerV1().Info(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), recorder.ErrInteractionNotFound.Error())
+}
+
+func TestRecorder_ReplayMissingCassette(t *testing.T) {
+	t.Parallel()
+
+	_, err := recorder.New(filepath.Join(t.TempDir(), "missing.json"), recorder.ModeReplay)
+	require.Error(t, err)
+}
+
+func TestParseMode(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]recorder.Mode{
+		"":            recorder.ModePassthrough,
+		"record":      recorder.ModeRecord,
+		"REPLAY":      recorder.ModeReplay,
+		"passthrough": recorder.ModePassthrough,
+	} {
+		got, err := recorder.ParseMode(in)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := recorder.ParseMode("rewind")
+	require.Error(t, err)
+}
+
+func TestMatchers(t *testing.T) {
+	t.Parallel()
+
+	recorded := &recorder.Request{
+		Method: http.MethodPost,
+		URL:    "http://localhost:8181/management/v1/role?pageSize=10&name=admin",
+		Body:   `{"name":"admin","description":"d"}`,
+	}
+
+	live := &recorder.Request{
+		Method: http.MethodPost,
+		URL:    "https://lakekeeper.example.com/management/v1/role?name=admin&pageSize=10",
+		Body:   `{"description":"d", "name":"admin"}`,
+	}
+
+	assert.True(t, recorder.MatchMethod(live, recorded))
+	assert.True(t, recorder.MatchPath(live, recorded))
+	assert.True(t, recorder.MatchURL(live, recorded))
+	assert.True(t, recorder.MatchBody(live, recorded))
+	assert.True(t, recorder.DefaultMatcher(live, recorded))
+
+	live.Body = `{"name":"other"}`
+	assert.False(t, recorder.MatchBody(live, recorded))
+	assert.False(t, recorder.DefaultMatcher(live, recorded))
+	assert.True(t, recorder.MatchAll(recorder.MatchMethod, recorder.MatchURL)(live, recorded))
+
+	live.URL = strings.Replace(live.URL, "admin", "other", 1)
+	assert.False(t, recorder.MatchURL(live, recorded))
+	assert.True(t, recorder.MatchPath(live, recorded))
+}
+
+func TestSanitizeJSONFields(t *testing.T) {
+	t.Parallel()
+
+	i := &recorder.Interaction{
+		Request: recorder.Request{
+			Body: `{"storage-credential":{"type":"gcs","key":{"private_key":"pk","client_email":"me"}}}`,
+		},
+		Response: recorder.Response{
+			Body: "not json",
+		},
+	}
+
+	recorder.DefaultSanitizer(i)
+
+	assert.JSONEq(t, `{"storage-credential":{"type":"gcs","key":{"private_key":"REDACTED","client_email":"me"}}}`, i.Request.Body)
+	assert.Equal(t, "not json", i.Response.Body)
+}
+
+func TestSanitizeCredentialFields(t *testing.T) {
+	t.Parallel()
+
+	i := &recorder.Interaction{
+		Request: recorder.Request{
+			Body: `{"storage-credential":{"type":"az","credential-type":"shared-access-key","key":"secret"}}`,
+		},
+		Response: recorder.Response{
+			Body: `{"properties":{"key":"value"}}`,
+		},
+	}
+
+	recorder.DefaultSanitizer(i)
+
+	assert.JSONEq(t, `{"storage-credential":{"type":"az","credential-type":"shared-access-key","key":"REDACTED"}}`, i.Request.Body)
+	assert.JSONEq(t, `{"properties":{"key":"value"}}`, i.Response.Body)
+}
@@ -0,0 +1,159 @@
+package recorder
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Redacted is the value written in place of sensitive data.
+const Redacted = "REDACTED"
+
+// SanitizerFunc modifies an interaction before it is stored
+// or matched.
+type SanitizerFunc func(*Interaction)
+
+var (
+	// DefaultSensitiveHeaders are the headers redacted by default.
+	DefaultSensitiveHeaders = []string{
+		"Authorization",
+		"Cookie",
+		"Proxy-Authorization",
+		"Set-Cookie",
+	}
+
+	// DefaultSensitiveFields are the JSON body fields redacted by default.
+	// They cover the storage credentials sent to the management API.
+	DefaultSensitiveFields = []string{
+		"access-key-id",
+		"aws-access-key-id",
+		"aws-secret-access-key",
+		"client-secret",
+		"external-id",
+		"private_key",
+		"private_key_id",
+		"secret-access-key",
+		"token",
+	}
+)
+
+// SanitizeHeaders returns a sanitizer that redacts the given headers
+// on both requests and responses.
+func SanitizeHeaders(headers ...string) SanitizerFunc {
+	return func(i *Interaction) {
+		for _, h := range headers {
+			redactHeader(i.Request.Headers, h)
+			redactHeader(i.Response.Headers, h)
+		}
+	}
+}
+
+// SanitizeJSONFields returns a sanitizer that redacts the given fields,
+// at any depth, in JSON request and response bodies. Field names are
+// compared case-insensitively.
+func SanitizeJSONFields(fields ...string) SanitizerFunc {
+	set := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		set[strings.ToLower(f)] = struct{}{}
+	}
+
+	return func(i *Interaction) {
+		i.Request.Body = redactJSON(i.Request.Body, set)
+		i.Response.Body = redactJSON(i.Response.Body, set)
+	}
+}
+
+// SanitizeCredentialFields returns a sanitizer that redacts the given
+// fields, at any depth, only in the JSON objects with the given
+// credential-type. It covers secrets stored under generic field names,
+// like the key of an ADLS shared access key.
+func SanitizeCredentialFields(credentialType string, fields ...string) SanitizerFunc {
+	set := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		set[strings.ToLower(f)] = struct{}{}
+	}
+
+	scope := func(m map[string]any) bool {
+		t, ok := m["credential-type"].(string)
+		return ok && t == credentialType
+	}
+
+	return func(i *Interaction) {
+		i.Request.Body = redactJSONIn(i.Request.Body, set, scope)
+		i.Response.Body = redactJSONIn(i.Response.Body, set, scope)
+	}
+}
+
+// DefaultSanitizer redacts DefaultSensitiveHeaders, DefaultSensitiveFields
+// and the key of ADLS shared access keys.
+var DefaultSanitizer = func(i *Interaction) {
+	SanitizeHeaders(DefaultSensitiveHeaders...)(i)
+	SanitizeJSONFields(DefaultSensitiveFields...)(i)
+	SanitizeCredentialFields("shared-access-key", "key")(i)
+}
+
+func redactHeader(h http.Header, key string) {
+	if h == nil || h.Get(key) == "" {
+		return
+	}
+	h.Set(key, Redacted)
+}
+
+func redactJSON(body string, fields map[string]struct{}) string {
+	return redactJSONIn(body, fields, nil)
+}
+
+// redactJSONIn redacts the fields of the objects in scope,
+// every object is in scope when scope is nil.
+func redactJSONIn(body string, fields map[string]struct{}, scope func(map[string]any) bool) string {
+	if body == "" {
+		return body
+	}
+
+	var v any
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return body
+	}
+
+	if !redactValue(v, fields, scope) {
+		return body
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+
+	return string(b)
+}
+
+// redactValue walks a decoded JSON value and reports whether
+// anything was redacted.
+func redactValue(v any, fields map[string]struct{}, scope func(map[string]any) bool) bool {
+	changed := false
+
+	switch t := v.(type) {
+	case map[string]any:
+		inScope := scope == nil || scope(t)
+		for k, val := range t {
+			if _, ok := fields[strings.ToLower(k)]; ok && inScope {
+				if _, isString := val.(string); isString {
+					t[k] = Redacted
+					changed = true
+					continue
+				}
+			}
+			if redactValue(val, fields, scope) {
+				changed = true
+			}
+		}
+	case []any:
+		for _, val := range t {
+			if redactValue(val, fields, scope) {
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,244 @@
+// Package report builds audit reports from the management API.
+package report
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/baptistegh/go-lakekeeper/internal/listing"
+	"github.com/baptistegh/go-lakekeeper/pkg/bulk"
+	"github.com/baptistegh/go-lakekeeper/pkg/client"
+	"github.com/baptistegh/go-lakekeeper/pkg/core"
+
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+)
+
+type (
+	// AccessOptions represents the Access() options.
+	AccessOptions struct {
+		// ProjectIDs limits the report to these projects.
+		// If empty, all the projects visible to the caller are reported.
+		ProjectIDs []string
+		// Actions limits the report to these actions.
+		// If empty, all allowed actions are reported.
+		Actions []string
+		// Concurrency is the number of access checks sent at the same time,
+		// bulk.DefaultConcurrency if not set.
+		Concurrency int
+	}
+
+	// Principal is a user or a role.
+	Principal struct {
+		Type permissionv1.UserOrRoleType `json:"type"`
+		ID   string                      `json:"id"`
+		Name string                      `json:"name"`
+	}
+
+	// Resource is a project or a warehouse.
+	Resource struct {
+		Type      permissionv1.Scope `json:"type"`
+		ID        string             `json:"id"`
+		Name      string             `json:"name"`
+		ProjectID string             `json:"project-id"`
+	}
+
+	// AccessEntry lists the actions a principal is allowed on a resource.
+	AccessEntry struct {
+		Principal Principal `json:"principal"`
+		Resource  Resource  `json:"resource"`
+		Actions   []string  `json:"actions"`
+	}
+
+	// AccessMatrix is the result of Access(). Principals without
+	// any reported action on a resource are omitted.
+	AccessMatrix struct {
+		Entries []*AccessEntry `json:"entries"`
+	}
+)
+
+// Access computes the actions each user and role is allowed on each
+// project and warehouse.
+//
+// Users are listed with UserV1().List(), roles with RoleV1().List() in
+// each project, and their access is checked with the GetAccess() calls
+// of the permission services. Roles are only checked on the resources
+// of their own project.
+func Access(ctx context.Context, c client.Interface, opts *AccessOptions) (*AccessMatrix, error) {
+	if opts == nil {
+		opts = &AccessOptions{}
+	}
+
+	projects, err := listing.Projects(ctx, c, opts.ProjectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := listing.Users(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]Principal, 0, len(list))
+	for _, u := range list {
+		users = append(users, Principal{Type: permissionv1.UserType, ID: u.ID, Name: u.Name})
+	}
+
+	type check struct {
+		principal Principal
+		resource  Resource
+	}
+
+	var checks []check
+	for _, p := range projects {
+		list, err := listing.Roles(ctx, c, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		roles := make([]Principal, 0, len(list))
+		for _, r := range list {
+			roles = append(roles, Principal{Type: permissionv1.RoleType, ID: r.ID, Name: r.Name})
+		}
+
+		warehouses, err := listing.Warehouses(ctx, c, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		resources := []Resource{{Type: permissionv1.ProjectScope, ID: p.ID, Name: p.Name, ProjectID: p.ID}}
+		for _, w := range warehouses {
+			resources = append(resources, Resource{Type: permissionv1.WarehouseScope, ID: w.ID, Name: w.Name, ProjectID: p.ID})
+		}
+
+		principals := slices.Concat(users, roles)
+		for _, r := range resources {
+			for _, pr := range principals {
+				checks = append(checks, check{principal: pr, resource: r})
+			}
+		}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = bulk.DefaultConcurrency
+	}
+
+	results := bulk.Run(ctx, checks, func(ctx context.Context, ch check) ([]string, error) {
+		return allowedActions(ctx, c, ch.principal, ch.resource)
+	}, bulk.WithConcurrency(concurrency), bulk.WithStopOnError())
+
+	if err := results.Err(); err != nil {
+		return nil, err
+	}
+
+	m := &AccessMatrix{Entries: []*AccessEntry{}}
+	for _, r := range results {
+		actions := r.Value
+		if len(opts.Actions) > 0 {
+			actions = slices.DeleteFunc(actions, func(a string) bool {
+				return !slices.Contains(opts.Actions, a)
+			})
+		}
+		if len(actions) == 0 {
+			continue
+		}
+
+		m.Entries = append(m.Entries, &AccessEntry{
+			Principal: r.Item.principal,
+			Resource:  r.Item.resource,
+			Actions:   actions,
+		})
+	}
+
+	return m, nil
+}
+
+var header = []string{"principal type", "principal id", "principal name", "resource type", "resource id", "resource name", "project id", "actions"}
+
+func (e *AccessEntry) record() []string {
+	return []string{
+		string(e.Principal.Type), e.Principal.ID, e.Principal.Name,
+		string(e.Resource.Type), e.Resource.ID, e.Resource.Name, e.Resource.ProjectID,
+		strings.Join(e.Actions, " "),
+	}
+}
+
+// WriteCSV writes the matrix as CSV, one line per principal and resource,
+// with the allowed actions separated by spaces.
+func (m *AccessMatrix) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, e := range m.Entries {
+		if err := cw.Write(e.record()); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteMarkdown writes the matrix as a Markdown table.
+func (m *AccessMatrix) WriteMarkdown(w io.Writer) error {
+	row := func(cells []string) string {
+		escaped := make([]string, len(cells))
+		for i, c := range cells {
+			escaped[i] = strings.ReplaceAll(c, "|", `\|`)
+		}
+		return "| " + strings.Join(escaped, " | ") + " |\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(row(header))
+	b.WriteString("|" + strings.Repeat(" --- |", len(header)) + "\n")
+	for _, e := range m.Entries {
+		b.WriteString(row(e.record()))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func allowedActions(ctx context.Context, c client.Interface, p Principal, r Resource) ([]string, error) {
+	var user, role *string
+	if p.Type == permissionv1.UserType {
+		user = core.Ptr(p.ID)
+	} else {
+		role = core.Ptr(p.ID)
+	}
+
+	var actions []string
+	switch r.Type {
+	case permissionv1.ProjectScope:
+		resp, _, err := c.PermissionV1().ProjectPermission().GetAccess(ctx, r.ID, &permissionv1.GetProjectAccessOptions{
+			PrincipalUser: user,
+			PrincipalRole: role,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not get access of %s %s on project %s, %w", p.Type, p.ID, r.ID, err)
+		}
+		for _, a := range resp.AllowedActions {
+			actions = append(actions, string(a))
+		}
+	case permissionv1.WarehouseScope:
+		allowed, err := permissionv1.WarehouseAccess(ctx, c.PermissionV1().WarehousePermission(), r.ID, &permissionv1.GetWarehouseAccessOptions{
+			PrincipalUser: user,
+			PrincipalRole: role,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not get access of %s %s on warehouse %s, %w", p.Type, p.ID, r.ID, err)
+		}
+		for _, a := range allowed {
+			actions = append(actions, string(a))
+		}
+	}
+
+	return actions, nil
+}
@@ -0,0 +1,118 @@
+package report_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/report"
+	"github.com/baptistegh/go-lakekeeper/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+)
+
+func TestAccess(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	const (
+		project   = "01f2fdfc-81fc-444d-8368-5b6701566e35"
+		warehouse = "a4b2c1d0-0000-4000-8000-000000000001"
+		archive   = "a4b2c1d0-0000-4000-8000-000000000002"
+	)
+
+	testutil.HandleProjects(t, mux, testutil.Project{ID: project, Name: "analytics", Warehouses: []map[string]any{
+		testutil.S3Warehouse(warehouse, "lake", project, "lake", managementv1.WarehouseStatusActive),
+		testutil.S3Warehouse(archive, "archive", project, "archive", managementv1.WarehouseStatusInactive),
+	}})
+	mux.HandleFunc("GET /management/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		// two pages of users
+		if r.URL.Query().Get("pageToken") == "" {
+			testutil.MustWriteJSONResponse(t, w, map[string]any{
+				"users":           []any{map[string]string{"id": "oidc~alice", "name": "Alice"}},
+				"next-page-token": "next",
+			})
+			return
+		}
+		testutil.MustWriteJSONResponse(t, w, map[string]any{
+			"users": []any{map[string]string{"id": "oidc~bob", "name": "Bob"}},
+		})
+	})
+	mux.HandleFunc("GET /management/v1/role", func(w http.ResponseWriter, r *http.Request) {
+		testutil.TestHeader(t, r, "x-project-id", project)
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"roles": []any{
+			map[string]string{"id": "role-analysts", "name": "analysts", "project-id": project},
+		}})
+	})
+	mux.HandleFunc("GET /management/v1/permissions/project/"+project+"/access", func(w http.ResponseWriter, r *http.Request) {
+		actions := []string{}
+		if r.URL.Query().Get("principalUser") == "oidc~alice" {
+			actions = []string{"create_warehouse", "list_warehouses"}
+		}
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"allowed-actions": actions})
+	})
+	mux.HandleFunc("GET /management/v1/permissions/warehouse/"+warehouse+"/access", func(w http.ResponseWriter, r *http.Request) {
+		actions := []string{}
+		if r.URL.Query().Get("principalRole") == "role-analysts" {
+			actions = []string{"get_config", "list_namespaces"}
+		}
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"allowed-actions": actions})
+	})
+	// inactive warehouses keep their permissions
+	mux.HandleFunc("GET /management/v1/permissions/warehouse/"+archive+"/access", func(w http.ResponseWriter, r *http.Request) {
+		actions := []string{}
+		if r.URL.Query().Get("principalUser") == "oidc~bob" {
+			actions = []string{"list_namespaces"}
+		}
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"allowed-actions": actions})
+	})
+
+	m, err := report.Access(t.Context(), client, &report.AccessOptions{
+		Actions: []string{"create_warehouse", "list_namespaces"},
+	})
+	require.NoError(t, err)
+
+	want := &report.AccessMatrix{Entries: []*report.AccessEntry{
+		{
+			Principal: report.Principal{Type: permissionv1.UserType, ID: "oidc~alice", Name: "Alice"},
+			Resource:  report.Resource{Type: permissionv1.ProjectScope, ID: project, Name: "analytics", ProjectID: project},
+			Actions:   []string{"create_warehouse"},
+		},
+		{
+			Principal: report.Principal{Type: permissionv1.RoleType, ID: "role-analysts", Name: "analysts"},
+			Resource:  report.Resource{Type: permissionv1.WarehouseScope, ID: warehouse, Name: "lake", ProjectID: project},
+			Actions:   []string{"list_namespaces"},
+		},
+		{
+			Principal: report.Principal{Type: permissionv1.UserType, ID: "oidc~bob", Name: "Bob"},
+			Resource:  report.Resource{Type: permissionv1.WarehouseScope, ID: archive, Name: "archive", ProjectID: project},
+			Actions:   []string{"list_namespaces"},
+		},
+	}}
+	assert.Equal(t, want, m)
+
+	var csv bytes.Buffer
+	require.NoError(t, m.WriteCSV(&csv))
+	assert.Equal(t, "principal type,principal id,principal name,resource type,resource id,resource name,project id,actions\n"+
+		"user,oidc~alice,Alice,project,"+project+",analytics,"+project+",create_warehouse\n"+
+		"role,role-analysts,analysts,warehouse,"+warehouse+",lake,"+project+",list_namespaces\n"+
+		"user,oidc~bob,Bob,warehouse,"+archive+",archive,"+project+",list_namespaces\n", csv.String())
+
+	var md bytes.Buffer
+	require.NoError(t, m.WriteMarkdown(&md))
+	assert.Contains(t, md.String(), "| --- | --- |")
+	assert.Contains(t, md.String(), "| role | role-analysts | analysts | warehouse | "+warehouse+" | lake | "+project+" | list_namespaces |\n")
+}
+
+func TestAccess_UnknownProject(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	testutil.HandleProjects(t, mux)
+
+	_, err := report.Access(t.Context(), client, &report.AccessOptions{ProjectIDs: []string{"missing"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,368 @@
+// Package rotate replaces the storage credential of many warehouses,
+// verifying each one and rolling it back on failure.
+package rotate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/baptistegh/go-lakekeeper/internal/listing"
+	"github.com/baptistegh/go-lakekeeper/pkg/client"
+
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	"github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/credential"
+	"github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/profile"
+)
+
+type (
+	// Status is the outcome of the rotation of a warehouse.
+	Status string
+
+	// Selector selects the warehouses to rotate, active or inactive.
+	// Empty fields match every warehouse.
+	Selector struct {
+		// ProjectIDs limits the rotation to these projects.
+		// If empty, all the projects visible to the caller are selected.
+		ProjectIDs []string
+		// Warehouses limits the rotation to these warehouses, by ID or name.
+		Warehouses []string
+		// Family limits the rotation to a storage family. Only the
+		// warehouses accepting the new credential are selected anyway.
+		Family profile.StorageFamily
+		// Bucket matches the S3 or GCS bucket, or the ADLS filesystem.
+		Bucket string
+		// Endpoint matches the S3 endpoint or the ADLS host.
+		Endpoint string
+	}
+
+	// VerifyFunc checks that a warehouse works with its new credential.
+	VerifyFunc func(ctx context.Context, c client.Interface, w *managementv1.Warehouse) error
+
+	// Options represents the Rotate() options.
+	Options struct {
+		Selector Selector
+		// Credential is the new storage credential.
+		Credential credential.StorageCredential
+		// Previous is restored on the warehouses failing verification.
+		// The API never returns the stored credentials, so no rollback
+		// is possible if it is not set.
+		Previous *credential.StorageCredential
+		// Verify checks each rotated warehouse, VerifyCatalog if not set.
+		Verify VerifyFunc
+		// DryRun selects the warehouses without rotating them.
+		DryRun bool
+		// StopOnFailure skips the remaining warehouses after a failure.
+		StopOnFailure bool
+	}
+
+	// Result is the rotation of a warehouse.
+	Result struct {
+		ProjectID     string `json:"project-id"`
+		WarehouseID   string `json:"warehouse-id"`
+		WarehouseName string `json:"warehouse-name"`
+		Status        Status `json:"status"`
+		// Verified is false for inactive warehouses,
+		// which cannot be verified.
+		Verified bool `json:"verified"`
+		// Error is why the update or the verification failed.
+		Error string `json:"error,omitempty"`
+		// RollbackError is why the previous credential
+		// could not be restored.
+		RollbackError string `json:"rollback-error,omitempty"`
+	}
+
+	// Report is the result of Rotate(), with one result per
+	// selected warehouse.
+	Report struct {
+		DryRun  bool      `json:"dry-run"`
+		Results []*Result `json:"results"`
+	}
+)
+
+const (
+	// StatusPlanned is a warehouse selected by a dry run.
+	StatusPlanned Status = "planned"
+	// StatusRotated is a warehouse using the new credential.
+	StatusRotated Status = "rotated"
+	// StatusFailed is a warehouse whose update was rejected,
+	// it still uses its previous credential.
+	StatusFailed Status = "failed"
+	// StatusRolledBack is a warehouse that failed verification
+	// and was restored to the previous credential.
+	StatusRolledBack Status = "rolled-back"
+	// StatusNotRolledBack is a warehouse that failed verification,
+	// with no previous credential to restore.
+	StatusNotRolledBack Status = "not-rolled-back"
+	// StatusRollbackFailed is a warehouse that failed verification
+	// and could not be restored to the previous credential.
+	StatusRollbackFailed Status = "rollback-failed"
+	// StatusSkipped is a warehouse left untouched after a failure,
+	// with StopOnFailure.
+	StatusSkipped Status = "skipped"
+)
+
+// statuses is the order of the statuses in the report summary.
+var statuses = []Status{
+	StatusPlanned,
+	StatusRotated,
+	StatusFailed,
+	StatusRolledBack,
+	StatusNotRolledBack,
+	StatusRollbackFailed,
+	StatusSkipped,
+}
+
+// VerifyCatalog loads the catalog config of the warehouse with
+// CatalogV1() and lists its namespaces.
+func VerifyCatalog(ctx context.Context, c client.Interface, w *managementv1.Warehouse) error {
+	catalog, err := c.CatalogV1(ctx, w.ProjectID, w.Name)
+	if err != nil {
+		return fmt.Errorf("could not load the catalog config, %w", err)
+	}
+
+	if _, err := catalog.ListNamespaces(ctx, nil); err != nil {
+		return fmt.Errorf("could not list namespaces, %w", err)
+	}
+
+	return nil
+}
+
+// Select returns the warehouses matching the selector and accepting
+// credentials of the given family.
+func Select(ctx context.Context, c client.Interface, s *Selector, family credential.CredentialFamily) ([]*managementv1.Warehouse, error) {
+	if s == nil {
+		s = &Selector{}
+	}
+
+	if s.Family != "" && managementv1.CredentialFamilyOf(s.Family) != family {
+		return nil, fmt.Errorf("%s credentials cannot be used with %s warehouses", family, s.Family)
+	}
+
+	projects, err := listing.Projects(ctx, c, s.ProjectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []*managementv1.Warehouse
+	for _, p := range projects {
+		warehouses, err := listing.Warehouses(ctx, c, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, w := range warehouses {
+			if s.matches(w, family) {
+				selected = append(selected, w)
+			}
+		}
+	}
+
+	return selected, nil
+}
+
+func (s *Selector) matches(w *managementv1.Warehouse, family credential.CredentialFamily) bool {
+	settings := w.StorageProfile.StorageSettings
+	if settings == nil || managementv1.CredentialFamilyOf(settings.GetStorageFamily()) != family {
+		return false
+	}
+
+	if s.Family != "" && settings.GetStorageFamily() != s.Family {
+		return false
+	}
+
+	if len(s.Warehouses) > 0 && !slices.Contains(s.Warehouses, w.ID) && !slices.Contains(s.Warehouses, w.Name) {
+		return false
+	}
+
+	bucket, endpoint := location(settings)
+
+	if s.Bucket != "" && s.Bucket != bucket {
+		return false
+	}
+
+	return s.Endpoint == "" || strings.TrimSuffix(s.Endpoint, "/") == strings.TrimSuffix(endpoint, "/")
+}
+
+// location returns the bucket and the endpoint of a storage profile.
+func location(settings profile.StorageSettings) (bucket, endpoint string) {
+	switch sp := settings.(type) {
+	case *profile.S3StorageSettings:
+		if sp.Endpoint != nil {
+			endpoint = *sp.Endpoint
+		}
+		return sp.Bucket, endpoint
+	case *profile.ADLSStorageSettings:
+		if sp.Host != nil {
+			endpoint = *sp.Host
+		}
+		return sp.Filesystem, endpoint
+	case *profile.GCSStorageSettings:
+		return sp.Bucket, ""
+	default:
+		return "", ""
+	}
+}
+
+// Rotate updates the storage credential of the selected warehouses, one
+// at a time. Each active warehouse is verified after its update, and
+// restored to opts.Previous if the verification fails.
+//
+// Failures of a warehouse are reported in its result, and do not stop
+// the rotation unless opts.StopOnFailure is set. An error is returned
+// if the options are invalid or the warehouses cannot be listed.
+func Rotate(ctx context.Context, c client.Interface, opts *Options) (*Report, error) {
+	if opts == nil || opts.Credential.Settings == nil {
+		return nil, errors.New("a new storage credential must be provided")
+	}
+
+	if err := opts.Credential.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid storage credential, %w", err)
+	}
+
+	family := opts.Credential.Settings.GetCredentialFamily()
+
+	if prev := opts.Previous; prev != nil {
+		if prev.Settings == nil {
+			return nil, errors.New("the previous storage credential is empty")
+		}
+		if prev.Settings.GetCredentialFamily() != family {
+			return nil, fmt.Errorf("the previous storage credential is a %s credential, the new one a %s credential", prev.Settings.GetCredentialFamily(), family)
+		}
+	}
+
+	verify := opts.Verify
+	if verify == nil {
+		verify = VerifyCatalog
+	}
+
+	warehouses, err := Select(ctx, c, &opts.Selector, family)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Report{
+		DryRun:  opts.DryRun,
+		Results: make([]*Result, 0, len(warehouses)),
+	}
+
+	stopped := false
+	for _, w := range warehouses {
+		result := &Result{
+			ProjectID:     w.ProjectID,
+			WarehouseID:   w.ID,
+			WarehouseName: w.Name,
+		}
+		r.Results = append(r.Results, result)
+
+		switch {
+		case opts.DryRun:
+			result.Status = StatusPlanned
+		case stopped:
+			result.Status = StatusSkipped
+		default:
+			rotate(ctx, c, w, opts, verify, result)
+			stopped = opts.StopOnFailure && result.Status != StatusRotated
+		}
+	}
+
+	return r, nil
+}
+
+func rotate(ctx context.Context, c client.Interface, w *managementv1.Warehouse, opts *Options, verify VerifyFunc, result *Result) {
+	service := c.WarehouseV1(w.ProjectID)
+
+	if _, err := service.UpdateStorageCredential(ctx, w.ID, &managementv1.UpdateStorageCredentialOptions{
+		StorageCredential: &opts.Credential,
+	}); err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return
+	}
+
+	// Inactive warehouses do not serve the catalog API.
+	if !w.IsActive() {
+		result.Status = StatusRotated
+		return
+	}
+
+	err := verify(ctx, c, w)
+	if err == nil {
+		result.Status = StatusRotated
+		result.Verified = true
+		return
+	}
+	result.Error = err.Error()
+
+	if opts.Previous == nil {
+		result.Status = StatusNotRolledBack
+		return
+	}
+
+	if _, err := service.UpdateStorageCredential(ctx, w.ID, &managementv1.UpdateStorageCredentialOptions{
+		StorageCredential: opts.Previous,
+	}); err != nil {
+		result.Status = StatusRollbackFailed
+		result.RollbackError = err.Error()
+		return
+	}
+	result.Status = StatusRolledBack
+}
+
+// Count returns the number of warehouses with the given status.
+func (r *Report) Count(status Status) int {
+	n := 0
+	for _, v := range r.Results {
+		if v.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed reports whether any warehouse is not using the new credential,
+// except for dry runs.
+func (r *Report) Failed() bool {
+	for _, v := range r.Results {
+		if v.Status != StatusRotated && v.Status != StatusPlanned {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a human-readable summary of the report.
+func (r *Report) String() string {
+	var b strings.Builder
+
+	if len(r.Results) == 0 {
+		b.WriteString("No warehouse selected\n")
+		return b.String()
+	}
+
+	for _, v := range r.Results {
+		fmt.Fprintf(&b, "%-16s %s/%s (%s)", v.Status, v.ProjectID, v.WarehouseName, v.WarehouseID)
+		if v.Status == StatusRotated && !v.Verified {
+			b.WriteString(", not verified, the warehouse is inactive")
+		}
+		b.WriteString("\n")
+		if v.Error != "" {
+			fmt.Fprintf(&b, "  error: %s\n", v.Error)
+		}
+		if v.RollbackError != "" {
+			fmt.Fprintf(&b, "  rollback error: %s\n", v.RollbackError)
+		}
+	}
+
+	var counts []string
+	for _, s := range statuses {
+		if n := r.Count(s); n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, s))
+		}
+	}
+	fmt.Fprintf(&b, "%d warehouses: %s\n", len(r.Results), strings.Join(counts, ", "))
+
+	return b.String()
+}
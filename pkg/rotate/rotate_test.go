@@ -0,0 +1,206 @@
+package rotate_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/client"
+	"github.com/baptistegh/go-lakekeeper/pkg/rotate"
+	"github.com/baptistegh/go-lakekeeper/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	"github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/storage/credential"
+)
+
+const project = "01f2fdfc-81fc-444d-8368-5b6701566e35"
+
+func setup(t *testing.T) (*http.ServeMux, *client.Client) {
+	t.Helper()
+	mux, c := testutil.ServerMux(t)
+
+	testutil.HandleProjects(t, mux, testutil.Project{ID: project, Name: "analytics", Warehouses: []map[string]any{
+		testutil.S3Warehouse("w1", "sales", project, "lake", managementv1.WarehouseStatusActive),
+		testutil.S3Warehouse("w2", "marketing", project, "lake", managementv1.WarehouseStatusActive),
+		testutil.S3Warehouse("w3", "archive", project, "lake", managementv1.WarehouseStatusInactive),
+		testutil.S3Warehouse("w4", "other", project, "other", managementv1.WarehouseStatusActive),
+		{
+			"id": "w5", "name": "azure", "project-id": project, "status": "active",
+			"storage-profile": map[string]any{"type": "adls", "account-name": "account", "filesystem": "lake"},
+		},
+	}})
+
+	return mux, c
+}
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+	mux, c := setup(t)
+
+	var (
+		mu      sync.Mutex
+		updates = map[string][]string{}
+	)
+	mux.HandleFunc("POST /management/v1/warehouse/{id}/storage-credential", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Credential map[string]any `json:"new-storage-credential"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		mu.Lock()
+		defer mu.Unlock()
+		updates[r.PathValue("id")] = append(updates[r.PathValue("id")], body.Credential["aws-access-key-id"].(string))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	previous := credential.NewS3CredentialAccessKey("AKIAOLD", "old-secret").AsCredential()
+	report, err := rotate.Rotate(t.Context(), c, &rotate.Options{
+		Selector:   rotate.Selector{Bucket: "lake"},
+		Credential: credential.NewS3CredentialAccessKey("AKIANEW", "new-secret").AsCredential(),
+		Previous:   &previous,
+		Verify: func(_ context.Context, _ client.Interface, w *managementv1.Warehouse) error {
+			assert.True(t, w.IsActive(), "inactive warehouse %s verified", w.Name)
+			if w.Name == "marketing" {
+				return errors.New("access denied")
+			}
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	statuses := map[string]rotate.Status{}
+	verified := map[string]bool{}
+	for _, r := range report.Results {
+		statuses[r.WarehouseName] = r.Status
+		verified[r.WarehouseName] = r.Verified
+	}
+	assert.Equal(t, map[string]rotate.Status{
+		"sales":     rotate.StatusRotated,
+		"marketing": rotate.StatusRolledBack,
+		"archive":   rotate.StatusRotated,
+	}, statuses)
+
+	// the inactive warehouse is rotated, but cannot be verified
+	assert.Equal(t, map[string]bool{"sales": true, "marketing": false, "archive": false}, verified)
+
+	assert.Equal(t, map[string][]string{
+		"w1": {"AKIANEW"},
+		"w2": {"AKIANEW", "AKIAOLD"},
+		"w3": {"AKIANEW"},
+	}, updates)
+
+	assert.True(t, report.Failed())
+	assert.Equal(t, 2, report.Count(rotate.StatusRotated))
+	assert.Contains(t, report.String(), "3 warehouses: 2 rotated, 1 rolled-back")
+	assert.Contains(t, report.String(), "  error: access denied")
+}
+
+func TestRotate_StopOnFailure(t *testing.T) {
+	t.Parallel()
+	mux, c := setup(t)
+
+	mux.HandleFunc("POST /management/v1/warehouse/{id}/storage-credential", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "w1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		t.Errorf("unexpected update of warehouse %s", r.PathValue("id"))
+	})
+
+	report, err := rotate.Rotate(t.Context(), c, &rotate.Options{
+		Selector:      rotate.Selector{Warehouses: []string{"sales", "w2"}},
+		Credential:    credential.NewS3CredentialAccessKey("AKIANEW", "new-secret").AsCredential(),
+		StopOnFailure: true,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, rotate.StatusFailed, report.Results[0].Status)
+	assert.NotEmpty(t, report.Results[0].Error)
+	assert.Equal(t, rotate.StatusSkipped, report.Results[1].Status)
+}
+
+func TestRotate_NoPrevious(t *testing.T) {
+	t.Parallel()
+	mux, c := setup(t)
+
+	mux.HandleFunc("POST /management/v1/warehouse/{id}/storage-credential", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	report, err := rotate.Rotate(t.Context(), c, &rotate.Options{
+		Selector:   rotate.Selector{Warehouses: []string{"w1"}},
+		Credential: credential.NewS3CredentialAccessKey("AKIANEW", "new-secret").AsCredential(),
+		Verify: func(context.Context, client.Interface, *managementv1.Warehouse) error {
+			return errors.New("access denied")
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, rotate.StatusNotRolledBack, report.Results[0].Status)
+}
+
+func TestRotate_DryRun(t *testing.T) {
+	t.Parallel()
+	_, c := setup(t)
+
+	report, err := rotate.Rotate(t.Context(), c, &rotate.Options{
+		Credential: credential.NewAZCredentialSharedAccessKey("key").AsCredential(),
+		DryRun:     true,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, "azure", report.Results[0].WarehouseName)
+	assert.Equal(t, rotate.StatusPlanned, report.Results[0].Status)
+	assert.False(t, report.Failed())
+}
+
+func TestRotate_InvalidOptions(t *testing.T) {
+	t.Parallel()
+	_, c := setup(t)
+
+	_, err := rotate.Rotate(t.Context(), c, &rotate.Options{
+		Selector:   rotate.Selector{Family: "gcs"},
+		Credential: credential.NewS3CredentialAccessKey("AKIANEW", "new-secret").AsCredential(),
+	})
+	require.EqualError(t, err, "s3 credentials cannot be used with gcs warehouses")
+
+	previous := credential.NewAZCredentialSharedAccessKey("key").AsCredential()
+	_, err = rotate.Rotate(t.Context(), c, &rotate.Options{
+		Credential: credential.NewS3CredentialAccessKey("AKIANEW", "new-secret").AsCredential(),
+		Previous:   &previous,
+	})
+	require.EqualError(t, err, "the previous storage credential is a az credential, the new one a s3 credential")
+}
+
+func TestVerifyCatalog(t *testing.T) {
+	t.Parallel()
+	mux, c := testutil.ServerMux(t)
+
+	mux.HandleFunc("GET /catalog/v1/config", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("warehouse") != project+"/sales" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		testutil.MustWriteJSONResponse(t, w, map[string]any{
+			"defaults":  map[string]string{},
+			"overrides": map[string]string{"prefix": "w1"},
+		})
+	})
+	mux.HandleFunc("GET /catalog/v1/w1/namespaces", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"namespaces": [][]string{{"finance"}}})
+	})
+
+	err := rotate.VerifyCatalog(t.Context(), c, &managementv1.Warehouse{ID: "w1", Name: "sales", ProjectID: project})
+	require.NoError(t, err)
+
+	err = rotate.VerifyCatalog(t.Context(), c, &managementv1.Warehouse{ID: "w2", Name: "marketing", ProjectID: project})
+	require.Error(t, err)
+}
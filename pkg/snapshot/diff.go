@@ -0,0 +1,139 @@
+package snapshot
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type (
+	// ChangeType is the type of a change between two snapshots.
+	ChangeType string
+
+	// Change is an assignment added or removed on a resource.
+	Change struct {
+		Type       ChangeType  `json:"type"`
+		Resource   *Resource   `json:"resource"`
+		Assignment *Assignment `json:"assignment"`
+	}
+
+	// Diff is the list of changes between two snapshots.
+	Diff struct {
+		Changes []*Change `json:"changes"`
+	}
+)
+
+const (
+	Added   ChangeType = "added"
+	Removed ChangeType = "removed"
+)
+
+// Compare returns the assignments added and removed between from and to.
+// Changes are ordered by resource, removals first. The snapshots are
+// left unchanged.
+func Compare(from, to *Snapshot) *Diff {
+	from, to = from.sorted(), to.sorted()
+
+	type key struct {
+		scope string
+		id    string
+	}
+
+	index := func(s *Snapshot) map[key]*Resource {
+		m := make(map[key]*Resource, len(s.Resources))
+		for _, r := range s.Resources {
+			m[key{string(r.Scope), r.ID}] = r
+		}
+		return m
+	}
+
+	before, after := index(from), index(to)
+
+	// resources of both snapshots, in the snapshot order
+	resources := slices.Clone(from.Resources)
+	for k, r := range after {
+		if _, ok := before[k]; !ok {
+			resources = append(resources, r)
+		}
+	}
+	slices.SortStableFunc(resources, compareResources)
+
+	keys := make([]key, 0, len(resources))
+	for _, r := range resources {
+		keys = append(keys, key{string(r.Scope), r.ID})
+	}
+
+	d := &Diff{Changes: []*Change{}}
+	for _, k := range keys {
+		old, cur := before[k], after[k]
+
+		var oldAssignments, curAssignments []*Assignment
+		resource := cur
+		if old != nil {
+			oldAssignments = old.Assignments
+			resource = old
+		}
+		if cur != nil {
+			curAssignments = cur.Assignments
+			resource = cur
+		}
+
+		for _, a := range missing(oldAssignments, curAssignments) {
+			d.Changes = append(d.Changes, &Change{Type: Removed, Resource: resource, Assignment: a})
+		}
+		for _, a := range missing(curAssignments, oldAssignments) {
+			d.Changes = append(d.Changes, &Change{Type: Added, Resource: resource, Assignment: a})
+		}
+	}
+
+	return d
+}
+
+// IsEmpty reports whether both snapshots have the same assignments.
+func (d *Diff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// String returns a human-readable description of the changes,
+// grouped by resource.
+func (d *Diff) String() string {
+	if d.IsEmpty() {
+		return "No changes\n"
+	}
+
+	var (
+		b    strings.Builder
+		last *Resource
+	)
+
+	for _, c := range d.Changes {
+		if c.Resource != last {
+			fmt.Fprintf(&b, "%s\n", c.Resource)
+			last = c.Resource
+		}
+
+		sign := "+"
+		if c.Type == Removed {
+			sign = "-"
+		}
+		fmt.Fprintf(&b, "  %s %s\n", sign, c.Assignment)
+	}
+
+	return b.String()
+}
+
+// missing returns the assignments of a not in b.
+func missing(a, b []*Assignment) []*Assignment {
+	in := make(map[Assignment]bool, len(b))
+	for _, x := range b {
+		in[*x] = true
+	}
+
+	var out []*Assignment
+	for _, x := range a {
+		if !in[*x] {
+			out = append(out, x)
+		}
+	}
+	return out
+}
@@ -0,0 +1,227 @@
+// Package snapshot captures the permission assignments of a Lakekeeper
+// server and compares them over time to detect drift.
+package snapshot
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/baptistegh/go-lakekeeper/internal/listing"
+	"github.com/baptistegh/go-lakekeeper/pkg/client"
+
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+)
+
+// Version is the version of the snapshot file format.
+const Version = 1
+
+type (
+	// Snapshot is the set of assignments of every resource at a point in time.
+	Snapshot struct {
+		Version   int         `json:"version"`
+		CreatedAt time.Time   `json:"created-at"`
+		Resources []*Resource `json:"resources"`
+	}
+
+	// Resource is a resource and its assignments.
+	Resource struct {
+		Scope       permissionv1.Scope `json:"scope"`
+		ID          string             `json:"id,omitempty"`
+		Name        string             `json:"name,omitempty"`
+		ProjectID   string             `json:"project-id,omitempty"`
+		Assignments []*Assignment      `json:"assignments"`
+	}
+
+	// Assignment is the serialized form of the assignment types
+	// of the permission package.
+	Assignment struct {
+		PrincipalType permissionv1.UserOrRoleType `json:"principal-type"`
+		PrincipalID   string                      `json:"principal-id"`
+		Assignment    string                      `json:"assignment"`
+	}
+)
+
+var _ permissionv1.Assignment = (*Assignment)(nil)
+
+func (a *Assignment) GetPrincipalType() permissionv1.UserOrRoleType {
+	return a.PrincipalType
+}
+
+func (a *Assignment) GetPrincipalID() string {
+	return a.PrincipalID
+}
+
+func (a *Assignment) GetAssignment() string {
+	return a.Assignment
+}
+
+// Take captures the assignments of the server, and of every project,
+// warehouse and role visible to the caller.
+func Take(ctx context.Context, c client.Interface) (*Snapshot, error) {
+	s := &Snapshot{
+		Version:   Version,
+		CreatedAt: time.Now().UTC(),
+		Resources: []*Resource{},
+	}
+
+	perms := c.PermissionV1()
+
+	server, _, err := perms.ServerPermission().GetAssignments(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	add(s, &Resource{Scope: permissionv1.ServerScope}, server.Assignments)
+
+	projects, err := listing.Projects(ctx, c, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range projects {
+		resp, _, err := perms.ProjectPermission().GetAssignments(ctx, p.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+		add(s, &Resource{Scope: permissionv1.ProjectScope, ID: p.ID, Name: p.Name, ProjectID: p.ID}, resp.Assignments)
+
+		warehouses, err := listing.Warehouses(ctx, c, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, w := range warehouses {
+			resp, _, err := perms.WarehousePermission().GetAssignments(ctx, w.ID, nil)
+			if err != nil {
+				return nil, err
+			}
+			add(s, &Resource{Scope: permissionv1.WarehouseScope, ID: w.ID, Name: w.Name, ProjectID: p.ID}, resp.Assignments)
+		}
+
+		roles, err := listing.Roles(ctx, c, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range roles {
+			resp, _, err := perms.RolePermission().GetAssignments(ctx, r.ID, nil)
+			if err != nil {
+				return nil, err
+			}
+			add(s, &Resource{Scope: permissionv1.RoleScope, ID: r.ID, Name: r.Name, ProjectID: p.ID}, resp.Assignments)
+		}
+	}
+
+	s.Sort()
+
+	return s, nil
+}
+
+func add[T permissionv1.Assignment](s *Snapshot, r *Resource, assignments []T) {
+	r.Assignments = make([]*Assignment, 0, len(assignments))
+	for _, a := range assignments {
+		r.Assignments = append(r.Assignments, &Assignment{
+			PrincipalType: a.GetPrincipalType(),
+			PrincipalID:   a.GetPrincipalID(),
+			Assignment:    a.GetAssignment(),
+		})
+	}
+	s.Resources = append(s.Resources, r)
+}
+
+// Sort orders the resources by scope then ID, and the assignments of
+// each resource with permissionv1.CompareAssignments, so that
+// snapshots of identical assignments serialize identically.
+func (s *Snapshot) Sort() {
+	slices.SortStableFunc(s.Resources, compareResources)
+	for _, r := range s.Resources {
+		permissionv1.SortAssignments(r.Assignments)
+	}
+}
+
+// sorted returns a sorted copy of the snapshot.
+func (s *Snapshot) sorted() *Snapshot {
+	c := *s
+	c.Resources = make([]*Resource, 0, len(s.Resources))
+	for _, r := range s.Resources {
+		rc := *r
+		rc.Assignments = slices.Clone(r.Assignments)
+		c.Resources = append(c.Resources, &rc)
+	}
+	c.Sort()
+	return &c
+}
+
+// Load reads a snapshot from a file.
+func Load(path string) (*Snapshot, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read snapshot %s, %w", path, err)
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("could not decode snapshot %s, %w", path, err)
+	}
+
+	if s.Version != Version {
+		return nil, fmt.Errorf("unsupported snapshot version %d in %s", s.Version, path)
+	}
+
+	s.Sort()
+
+	return &s, nil
+}
+
+// Marshal returns the sorted, indented JSON form of the snapshot.
+func (s *Snapshot) Marshal() ([]byte, error) {
+	s.Sort()
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// Save writes the snapshot to a file.
+func (s *Snapshot) Save(path string) error {
+	b, err := s.Marshal()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
+
+// scopeOrder is the order of the resources in a snapshot.
+var scopeOrder = []permissionv1.Scope{
+	permissionv1.ServerScope,
+	permissionv1.ProjectScope,
+	permissionv1.WarehouseScope,
+	permissionv1.RoleScope,
+}
+
+func compareResources(a, b *Resource) int {
+	return cmp.Or(
+		cmp.Compare(slices.Index(scopeOrder, a.Scope), slices.Index(scopeOrder, b.Scope)),
+		cmp.Compare(a.ID, b.ID),
+	)
+}
+
+func (r *Resource) String() string {
+	if r.ID == "" {
+		return string(r.Scope)
+	}
+	if r.Name != "" {
+		return fmt.Sprintf("%s %s (%s)", r.Scope, r.ID, r.Name)
+	}
+	return fmt.Sprintf("%s %s", r.Scope, r.ID)
+}
+
+func (a *Assignment) String() string {
+	return strings.Join([]string{string(a.PrincipalType), a.PrincipalID, a.Assignment}, " ")
+}
@@ -0,0 +1,107 @@
+package snapshot_test
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/baptistegh/go-lakekeeper/pkg/snapshot"
+	"github.com/baptistegh/go-lakekeeper/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+	permissionv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1/permission"
+)
+
+func TestTake(t *testing.T) {
+	t.Parallel()
+	mux, client := testutil.ServerMux(t)
+
+	const project = "01f2fdfc-81fc-444d-8368-5b6701566e35"
+
+	mux.HandleFunc("GET /management/v1/permissions/server/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+			map[string]string{"type": "operator", "user": "oidc~bob"},
+			map[string]string{"type": "admin", "user": "oidc~bob"},
+			map[string]string{"type": "admin", "role": "admins"},
+		}})
+	})
+	testutil.HandleProjects(t, mux, testutil.Project{ID: project, Name: "analytics", Warehouses: []map[string]any{
+		testutil.S3Warehouse("wh", "archive", project, "archive", managementv1.WarehouseStatusInactive),
+	}})
+	mux.HandleFunc("GET /management/v1/permissions/warehouse/wh/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{
+			map[string]string{"type": "select", "role": "analysts"},
+		}})
+	})
+	mux.HandleFunc("GET /management/v1/permissions/project/"+project+"/assignments", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"assignments": []any{}})
+	})
+	mux.HandleFunc("GET /management/v1/role", func(w http.ResponseWriter, _ *http.Request) {
+		testutil.MustWriteJSONResponse(t, w, map[string]any{"roles": []any{}})
+	})
+
+	s, err := snapshot.Take(t.Context(), client)
+	require.NoError(t, err)
+
+	require.Len(t, s.Resources, 3)
+	assert.Equal(t, permissionv1.ServerScope, s.Resources[0].Scope)
+	assert.Equal(t, []*snapshot.Assignment{
+		{PrincipalType: permissionv1.RoleType, PrincipalID: "admins", Assignment: "admin"},
+		{PrincipalType: permissionv1.UserType, PrincipalID: "oidc~bob", Assignment: "admin"},
+		{PrincipalType: permissionv1.UserType, PrincipalID: "oidc~bob", Assignment: "operator"},
+	}, s.Resources[0].Assignments)
+	assert.Equal(t, "project "+project+" (analytics)", s.Resources[1].String())
+	// inactive warehouses keep their assignments
+	assert.Equal(t, "warehouse wh (archive)", s.Resources[2].String())
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, s.Save(path))
+
+	loaded, err := snapshot.Load(path)
+	require.NoError(t, err)
+	assert.True(t, snapshot.Compare(s, loaded).IsEmpty())
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	from := &snapshot.Snapshot{Version: snapshot.Version, Resources: []*snapshot.Resource{
+		{Scope: permissionv1.WarehouseScope, ID: "wh", Assignments: []*snapshot.Assignment{
+			{PrincipalType: permissionv1.RoleType, PrincipalID: "analysts", Assignment: "select"},
+			{PrincipalType: permissionv1.UserType, PrincipalID: "oidc~alice", Assignment: "modify"},
+		}},
+		{Scope: permissionv1.RoleScope, ID: "old-role", Assignments: []*snapshot.Assignment{
+			{PrincipalType: permissionv1.UserType, PrincipalID: "oidc~alice", Assignment: "assignee"},
+		}},
+	}}
+
+	// not sorted
+	to := &snapshot.Snapshot{Version: snapshot.Version, Resources: []*snapshot.Resource{
+		{Scope: permissionv1.WarehouseScope, ID: "wh", Assignments: []*snapshot.Assignment{
+			{PrincipalType: permissionv1.UserType, PrincipalID: "oidc~alice", Assignment: "ownership"},
+			{PrincipalType: permissionv1.RoleType, PrincipalID: "analysts", Assignment: "select"},
+		}},
+		{Scope: permissionv1.ServerScope, Assignments: []*snapshot.Assignment{
+			{PrincipalType: permissionv1.UserType, PrincipalID: "oidc~mallory", Assignment: "admin"},
+		}},
+	}}
+
+	d := snapshot.Compare(from, to)
+
+	// the snapshots are not sorted in place
+	assert.Equal(t, permissionv1.WarehouseScope, to.Resources[0].Scope)
+	assert.Equal(t, "oidc~alice", to.Resources[0].Assignments[0].PrincipalID)
+
+	assert.Equal(t, "server\n"+
+		"  + user oidc~mallory admin\n"+
+		"warehouse wh\n"+
+		"  - user oidc~alice modify\n"+
+		"  + user oidc~alice ownership\n"+
+		"role old-role\n"+
+		"  - user oidc~alice assignee\n", d.String())
+
+	assert.True(t, snapshot.Compare(to, to).IsEmpty())
+	assert.Equal(t, "No changes\n", snapshot.Compare(to, to).String())
+}
@@ -0,0 +1,77 @@
+package testutil
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	managementv1 "github.com/baptistegh/go-lakekeeper/pkg/apis/management/v1"
+)
+
+// Project is a project served by HandleProjects.
+type Project struct {
+	ID   string
+	Name string
+
+	// Warehouses of the project, as returned by the management
+	// API, e.g. built with S3Warehouse.
+	Warehouses []map[string]any
+}
+
+// S3Warehouse returns a warehouse of a project as returned by the
+// management API, with an S3 storage profile on bucket.
+func S3Warehouse(id, name, project, bucket string, status managementv1.WarehouseStatus) map[string]any {
+	return map[string]any{
+		"id":         id,
+		"name":       name,
+		"project-id": project,
+		"status":     status,
+		"storage-profile": map[string]any{
+			"type": "s3", "bucket": bucket, "region": "eu-west-1", "sts-enabled": false,
+		},
+	}
+}
+
+// HandleProjects registers on mux the endpoints listing the projects
+// and their warehouses.
+//
+// Like the server, the warehouses are those of the project of the
+// x-project-id header, filtered on the warehouseStatus[] query
+// parameter, and only the active ones are listed when it is missing.
+func HandleProjects(t *testing.T, mux *http.ServeMux, projects ...Project) {
+	t.Helper()
+
+	mux.HandleFunc("GET /management/v1/project-list", func(w http.ResponseWriter, _ *http.Request) {
+		list := make([]map[string]string, 0, len(projects))
+		for _, p := range projects {
+			list = append(list, map[string]string{"project-id": p.ID, "project-name": p.Name})
+		}
+		MustWriteJSONResponse(t, w, map[string]any{"projects": list})
+	})
+
+	mux.HandleFunc("GET /management/v1/warehouse", func(w http.ResponseWriter, r *http.Request) {
+		statuses := r.URL.Query()["warehouseStatus[]"]
+		if len(statuses) == 0 {
+			statuses = []string{string(managementv1.WarehouseStatusActive)}
+		}
+
+		warehouses := []map[string]any{}
+		for _, p := range projects {
+			if p.ID != r.Header.Get(managementv1.ProjectIDHeader) {
+				continue
+			}
+			for _, wh := range p.Warehouses {
+				status := string(managementv1.WarehouseStatusActive)
+				if s, ok := wh["status"]; ok {
+					status = fmt.Sprint(s)
+				}
+				if slices.Contains(statuses, status) {
+					warehouses = append(warehouses, wh)
+				}
+			}
+		}
+
+		MustWriteJSONResponse(t, w, map[string]any{"warehouses": warehouses})
+	})
+}